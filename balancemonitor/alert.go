@@ -0,0 +1,71 @@
+package balancemonitor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/kriswu/go_deepseek/httpclient"
+)
+
+// Alert 表示一次低余额告警
+type Alert struct {
+	Key       string    `json:"key"`
+	Balance   float64   `json:"balance"` // 总余额
+	Threshold float64   `json:"threshold"`
+	Time      time.Time `json:"time"`
+}
+
+// Alerter 负责发送告警
+type Alerter interface {
+	Alert(alert Alert) error
+}
+
+// LogAlerter 将告警写入日志
+type LogAlerter struct{}
+
+// Alert 写入日志
+func (LogAlerter) Alert(alert Alert) error {
+	log.Printf("余额告警: 密钥%s的总余额%.2f低于阈值%.2f", alert.Key, alert.Balance, alert.Threshold)
+	return nil
+}
+
+// WebhookAlerter 以POST JSON方式发送告警
+type WebhookAlerter struct {
+	URL    string
+	client *httpclient.Client
+}
+
+// NewWebhookAlerter 创建webhook告警
+func NewWebhookAlerter(url string) *WebhookAlerter {
+	return &WebhookAlerter{
+		URL:    url,
+		client: httpclient.NewClient(httpclient.WithTimeout(10 * time.Second)),
+	}
+}
+
+// Alert 发送告警
+func (w *WebhookAlerter) Alert(alert Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("序列化告警失败: %w", err)
+	}
+	resp, err := w.client.Post(context.Background(), w.URL, "application/json", string(body))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook返回状态码: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// FuncAlerter 将函数适配为Alerter
+type FuncAlerter func(alert Alert) error
+
+// Alert 调用函数
+func (f FuncAlerter) Alert(alert Alert) error {
+	return f(alert)
+}
@@ -0,0 +1,211 @@
+package balancemonitor
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/kriswu/go_deepseek/httpclient"
+	"github.com/kriswu/go_deepseek/siliconproxy"
+)
+
+// 默认轮询间隔
+const DefaultInterval = 5 * time.Minute
+
+// 通过expvar导出的指标，键为密钥名称
+var (
+	balanceMetric       = expvar.NewMap("silicon_balance_total")
+	chargeBalanceMetric = expvar.NewMap("silicon_balance_charge")
+	giftBalanceMetric   = expvar.NewMap("silicon_balance_gift")
+	pollErrorsMetric    = expvar.NewMap("silicon_balance_poll_errors")
+	alertsMetric        = expvar.NewMap("silicon_balance_alerts")
+)
+
+// Key 表示一个被监控的API密钥
+type Key struct {
+	Name  string // 用于指标和告警的名称，为空时使用脱敏后的密钥
+	Token string
+}
+
+// Config 表示余额监控配置
+type Config struct {
+	Keys      []Key
+	Interval  time.Duration // 轮询间隔，默认5分钟
+	Threshold float64       // 总余额低于该值时告警
+	Alerters  []Alerter     // 为空时只写日志
+	BaseURL   string        // 上游API基础URL，为空时使用默认地址
+}
+
+// KeyBalance 表示一个密钥最近一次查询到的余额
+type KeyBalance struct {
+	Key       string
+	Balance   float64 // 赠送余额
+	Charge    float64 // 充值余额
+	Total     float64 // 总余额
+	UpdatedAt time.Time
+	Err       error // 最近一次查询的错误
+}
+
+// Monitor 定期查询各密钥的余额并在低于阈值时告警
+// 告警在余额跌破阈值时触发一次，余额恢复到阈值以上后重新生效
+type Monitor struct {
+	conf    Config
+	proxies map[string]*siliconproxy.SiliconProxy
+
+	mu       sync.Mutex
+	balances map[string]*KeyBalance
+	alerting map[string]bool
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// New 创建余额监控，options用于每个密钥的HTTP客户端
+func New(conf Config, options ...httpclient.ClientOption) *Monitor {
+	if conf.Interval <= 0 {
+		conf.Interval = DefaultInterval
+	}
+	if len(conf.Alerters) == 0 {
+		conf.Alerters = []Alerter{LogAlerter{}}
+	}
+	conf.Keys = append([]Key(nil), conf.Keys...)
+
+	m := &Monitor{
+		conf:     conf,
+		proxies:  make(map[string]*siliconproxy.SiliconProxy),
+		balances: make(map[string]*KeyBalance),
+		alerting: make(map[string]bool),
+		stop:     make(chan struct{}),
+	}
+	for i, key := range conf.Keys {
+		if key.Name == "" {
+			conf.Keys[i].Name = maskToken(key.Token)
+		}
+		sp := siliconproxy.NewSiliconProxy(key.Token, options...)
+		if conf.BaseURL != "" {
+			sp.SetBaseURL(conf.BaseURL)
+		}
+		m.proxies[conf.Keys[i].Name] = sp
+	}
+	return m
+}
+
+// Start 在后台开始轮询，立即执行第一次查询
+func (m *Monitor) Start() {
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+
+		ticker := time.NewTicker(m.conf.Interval)
+		defer ticker.Stop()
+		for {
+			m.PollOnce()
+			select {
+			case <-m.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop 停止轮询
+func (m *Monitor) Stop() {
+	close(m.stop)
+	m.wg.Wait()
+}
+
+// SetThreshold 更换告警阈值，下次查询时生效
+// 已告警的密钥在余额恢复到新阈值以上之前不会再次告警
+func (m *Monitor) SetThreshold(threshold float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.conf.Threshold = threshold
+}
+
+// PollOnce 查询全部密钥的余额一次
+func (m *Monitor) PollOnce() {
+	for _, key := range m.conf.Keys {
+		m.poll(key.Name)
+	}
+}
+
+// Balances 返回全部密钥最近一次查询的余额
+func (m *Monitor) Balances() []KeyBalance {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := make([]KeyBalance, 0, len(m.conf.Keys))
+	for _, key := range m.conf.Keys {
+		if b, ok := m.balances[key.Name]; ok {
+			result = append(result, *b)
+		}
+	}
+	return result
+}
+
+func (m *Monitor) poll(name string) {
+	info, err := m.proxies[name].GetUserInfo(context.Background())
+	var balance, charge, total float64
+	if err == nil {
+		balance, charge, total, err = info.Data.Balances()
+	}
+
+	m.mu.Lock()
+	kb, ok := m.balances[name]
+	if !ok {
+		kb = &KeyBalance{Key: name}
+		m.balances[name] = kb
+	}
+	kb.Err = err
+	if err != nil {
+		m.mu.Unlock()
+		pollErrorsMetric.Add(name, 1)
+		log.Printf("查询密钥%s的余额失败: %v", name, err)
+		return
+	}
+
+	kb.Balance, kb.Charge, kb.Total = balance, charge, total
+	kb.UpdatedAt = time.Now()
+
+	threshold := m.conf.Threshold
+	fire := false
+	if total < threshold {
+		fire = !m.alerting[name]
+		m.alerting[name] = true
+	} else {
+		m.alerting[name] = false
+	}
+	m.mu.Unlock()
+
+	setFloat(balanceMetric, name, total)
+	setFloat(chargeBalanceMetric, name, charge)
+	setFloat(giftBalanceMetric, name, balance)
+
+	if fire {
+		alertsMetric.Add(name, 1)
+		alert := Alert{Key: name, Balance: total, Threshold: threshold, Time: time.Now()}
+		for _, a := range m.conf.Alerters {
+			if err := a.Alert(alert); err != nil {
+				log.Printf("发送余额告警失败: %v", err)
+			}
+		}
+	}
+}
+
+func setFloat(m *expvar.Map, key string, value float64) {
+	v := new(expvar.Float)
+	v.Set(value)
+	m.Set(key, v)
+}
+
+// 脱敏密钥，只保留前后各4位
+func maskToken(token string) string {
+	if len(token) <= 8 {
+		return "****"
+	}
+	return fmt.Sprintf("%s...%s", token[:4], token[len(token)-4:])
+}
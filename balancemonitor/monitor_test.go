@@ -0,0 +1,73 @@
+package balancemonitor
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// 记录告警的Alerter
+type recordingAlerter struct {
+	mu     sync.Mutex
+	alerts []Alert
+}
+
+func (r *recordingAlerter) Alert(alert Alert) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.alerts = append(r.alerts, alert)
+	return nil
+}
+
+func TestMonitorAlerts(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold float64
+		balances  []string // 依次轮询时上游返回的总余额
+		setTo     float64  // 非0时在第一次轮询后更换阈值
+		wantTotal float64  // 最后一次轮询的总余额
+		want      int      // 告警次数
+	}{
+		{name: "高于阈值不告警", threshold: 10, balances: []string{"20.5"}, wantTotal: 20.5},
+		{name: "跌破阈值告警一次", threshold: 10, balances: []string{"5", "4", "3"}, wantTotal: 3, want: 1},
+		{name: "恢复后再次跌破重新告警", threshold: 10, balances: []string{"5", "20", "5"}, wantTotal: 5, want: 2},
+		{name: "阈值为0时余额为0也不告警", threshold: 0, balances: []string{"0", "0.00"}, wantTotal: 0},
+		{name: "更换阈值后按新阈值告警", threshold: 1, balances: []string{"5", "5"}, setTo: 10, wantTotal: 5, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var poll int
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// 余额以字符串返回
+				fmt.Fprintf(w, `{"code":20000,"status":true,"data":{"balance":"0","chargeBalance":"%s","totalBalance":"%s"}}`,
+					tt.balances[poll], tt.balances[poll])
+				poll++
+			}))
+			defer srv.Close()
+
+			alerter := &recordingAlerter{}
+			m := New(Config{
+				Keys:      []Key{{Name: "main", Token: "token"}},
+				Threshold: tt.threshold,
+				Alerters:  []Alerter{alerter},
+				BaseURL:   srv.URL,
+			})
+			for i := range tt.balances {
+				m.PollOnce()
+				if i == 0 && tt.setTo != 0 {
+					m.SetThreshold(tt.setTo)
+				}
+			}
+
+			balances := m.Balances()
+			if len(balances) != 1 || balances[0].Err != nil || balances[0].Total != tt.wantTotal {
+				t.Fatalf("余额为%+v，期望总余额%v", balances, tt.wantTotal)
+			}
+			if len(alerter.alerts) != tt.want {
+				t.Fatalf("告警%d次，期望%d次: %+v", len(alerter.alerts), tt.want, alerter.alerts)
+			}
+		})
+	}
+}
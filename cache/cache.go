@@ -0,0 +1,53 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Cache 是响应缓存的存储后端
+type Cache interface {
+	// Get 获取缓存值，不存在或已过期时返回false
+	Get(key string) ([]byte, bool)
+	// Set 写入缓存值，ttl为0表示永不过期
+	Set(key string, value []byte, ttl time.Duration)
+	// Delete 删除缓存值
+	Delete(key string)
+}
+
+// Key 根据租户、接口路径、模型和请求体计算规范化的缓存键
+// 请求体会先解析再重新序列化，使字段顺序不同但语义相同的请求得到相同的键
+func Key(tenant, path, model string, body []byte) (string, error) {
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return "", fmt.Errorf("解析请求体失败: %w", err)
+	}
+	canonical, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("序列化请求体失败: %w", err)
+	}
+
+	h := sha256.New()
+	for _, part := range []string{tenant, path, model} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	h.Write(canonical)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// 计算过期时间，ttl为0表示永不过期
+func expiresAt(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(ttl)
+}
+
+// 判断是否已过期
+func expired(t time.Time) bool {
+	return !t.IsZero() && time.Now().After(t)
+}
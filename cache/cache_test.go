@@ -0,0 +1,187 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestKey(t *testing.T) {
+	tests := []struct {
+		name      string
+		a, b      [4]string // 租户、路径、模型、请求体
+		wantEqual bool
+	}{
+		{
+			name:      "字段顺序不同",
+			a:         [4]string{"t", "/chat", "m", `{"a":1,"b":[1,2]}`},
+			b:         [4]string{"t", "/chat", "m", `{ "b":[1,2], "a":1 }`},
+			wantEqual: true,
+		},
+		{
+			name: "租户不同",
+			a:    [4]string{"t1", "/chat", "m", `{}`},
+			b:    [4]string{"t2", "/chat", "m", `{}`},
+		},
+		{
+			name: "接口不同",
+			a:    [4]string{"t", "/chat", "m", `{}`},
+			b:    [4]string{"t", "/embeddings", "m", `{}`},
+		},
+		{
+			name: "分隔符防止拼接冲突",
+			a:    [4]string{"ab", "c", "m", `{}`},
+			b:    [4]string{"a", "bc", "m", `{}`},
+		},
+		{
+			name: "数组顺序不同",
+			a:    [4]string{"t", "/chat", "m", `{"a":[1,2]}`},
+			b:    [4]string{"t", "/chat", "m", `{"a":[2,1]}`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ka, err := Key(tt.a[0], tt.a[1], tt.a[2], []byte(tt.a[3]))
+			if err != nil {
+				t.Fatal(err)
+			}
+			kb, err := Key(tt.b[0], tt.b[1], tt.b[2], []byte(tt.b[3]))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (ka == kb) != tt.wantEqual {
+				t.Fatalf("键%s和%s，期望相等: %v", ka, kb, tt.wantEqual)
+			}
+		})
+	}
+
+	if _, err := Key("t", "/chat", "m", []byte("{")); err == nil {
+		t.Fatal("无效的请求体应返回错误")
+	}
+}
+
+// 两种后端共用的行为测试
+func TestCacheBackends(t *testing.T) {
+	backends := []struct {
+		name string
+		new  func(t *testing.T) Cache
+	}{
+		{"memory", func(t *testing.T) Cache { return NewMemoryCache(10) }},
+		{"disk", func(t *testing.T) Cache {
+			c, err := NewDiskCache(t.TempDir())
+			if err != nil {
+				t.Fatal(err)
+			}
+			return c
+		}},
+	}
+	tests := []struct {
+		name   string
+		ttl    time.Duration
+		delete bool
+		wantOK bool
+	}{
+		{name: "永不过期", ttl: 0, wantOK: true},
+		{name: "未过期", ttl: time.Hour, wantOK: true},
+		{name: "已过期", ttl: time.Nanosecond},
+		{name: "已删除", ttl: time.Hour, delete: true},
+	}
+
+	for _, b := range backends {
+		for _, tt := range tests {
+			t.Run(b.name+"/"+tt.name, func(t *testing.T) {
+				c := b.new(t)
+				c.Set("key", []byte("value"), tt.ttl)
+				if tt.delete {
+					c.Delete("key")
+				}
+				time.Sleep(time.Millisecond)
+
+				got, ok := c.Get("key")
+				if ok != tt.wantOK {
+					t.Fatalf("命中为%v，期望%v", ok, tt.wantOK)
+				}
+				if ok && string(got) != "value" {
+					t.Fatalf("值为%q", got)
+				}
+			})
+		}
+	}
+}
+
+func TestMemoryCacheLRU(t *testing.T) {
+	c := NewMemoryCache(2)
+	c.Set("a", []byte("1"), 0)
+	c.Set("b", []byte("2"), 0)
+	c.Get("a") // a变为最近使用
+	c.Set("c", []byte("3"), 0)
+
+	if c.Len() != 2 {
+		t.Fatalf("条目数%d，期望2", c.Len())
+	}
+	for key, want := range map[string]bool{"a": true, "b": false, "c": true} {
+		if _, ok := c.Get(key); ok != want {
+			t.Fatalf("%s命中为%v，期望%v", key, ok, want)
+		}
+	}
+
+	// 覆盖已有的键不增加条目
+	c.Set("a", []byte("4"), 0)
+	if got, _ := c.Get("a"); string(got) != "4" || c.Len() != 2 {
+		t.Fatalf("覆盖后值为%q，条目数%d", got, c.Len())
+	}
+}
+
+func TestDiskCacheLayout(t *testing.T) {
+	dir := t.TempDir()
+	c, err := NewDiskCache(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := strings.Repeat("ab", 32)
+	c.Set(key, []byte("v"), 0)
+
+	if _, err := os.Stat(filepath.Join(dir, "ab", key)); err != nil {
+		t.Fatalf("条目文件不在分片目录中: %v", err)
+	}
+	if tmps, _ := filepath.Glob(filepath.Join(dir, "ab", "*.tmp")); len(tmps) > 0 {
+		t.Fatalf("残留了临时文件: %v", tmps)
+	}
+
+	// 损坏的条目视为未命中
+	os.WriteFile(filepath.Join(dir, "ab", key), []byte("{"), 0o644)
+	if _, ok := c.Get(key); ok {
+		t.Fatal("损坏的条目不应命中")
+	}
+}
+
+func TestDiskCacheConcurrentSet(t *testing.T) {
+	dir := t.TempDir()
+	c, err := NewDiskCache(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := strings.Repeat("cd", 32)
+	value := []byte(strings.Repeat("x", 64<<10))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.Set(key, value, 0)
+		}()
+	}
+	wg.Wait()
+
+	if got, ok := c.Get(key); !ok || len(got) != len(value) {
+		t.Fatalf("并发写入后读取到%d字节，命中%v", len(got), ok)
+	}
+	if tmps, _ := filepath.Glob(filepath.Join(dir, "cd", "*.tmp")); len(tmps) > 0 {
+		t.Fatalf("残留了临时文件: %v", tmps)
+	}
+}
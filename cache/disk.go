@@ -0,0 +1,91 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DiskCache 是基于本地文件的缓存，每个条目保存为一个文件
+type DiskCache struct {
+	dir string
+}
+
+type diskEntry struct {
+	ExpiresAt time.Time `json:"expires_at"`
+	Value     []byte    `json:"value"`
+}
+
+// NewDiskCache 创建以dir为存储目录的磁盘缓存
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("创建缓存目录失败: %w", err)
+	}
+	return &DiskCache{dir: dir}, nil
+}
+
+// Get 获取缓存值
+func (c *DiskCache) Get(key string) ([]byte, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry diskEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+	if expired(entry.ExpiresAt) {
+		c.Delete(key)
+		return nil, false
+	}
+	return entry.Value, true
+}
+
+// Set 写入缓存值，写入失败时静默忽略
+func (c *DiskCache) Set(key string, value []byte, ttl time.Duration) {
+	data, err := json.Marshal(diskEntry{ExpiresAt: expiresAt(ttl), Value: value})
+	if err != nil {
+		return
+	}
+
+	// 先写临时文件再重命名，避免读到写了一半的条目
+	path := c.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return
+	}
+	// 临时文件名唯一，并发写同一个键时不会互相覆盖写了一半的文件
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return
+	}
+	tmp := f.Name()
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp, 0o644)
+	}
+	if err == nil {
+		err = os.Rename(tmp, path)
+	}
+	if err != nil {
+		os.Remove(tmp)
+	}
+}
+
+// Delete 删除缓存值
+func (c *DiskCache) Delete(key string) {
+	os.Remove(c.path(key))
+}
+
+// 按键的前两个字符分目录，避免单个目录文件过多
+func (c *DiskCache) path(key string) string {
+	if len(key) < 2 {
+		return filepath.Join(c.dir, key)
+	}
+	return filepath.Join(c.dir, key[:2], key)
+}
@@ -0,0 +1,222 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Mode 表示录制回放模式
+type Mode int
+
+const (
+	// Replay 只从文件回放，没有匹配的记录时返回错误，不访问网络
+	Replay Mode = iota
+	// Record 转发请求并记录响应，Save时写入文件
+	Record
+)
+
+// ErrNoInteraction 表示回放时没有匹配的记录
+var ErrNoInteraction = errors.New("录制文件中没有匹配的请求")
+
+// Request 表示录制的请求，不包含Authorization等请求头
+type Request struct {
+	Method string `json:"method"`
+	Path   string `json:"path"` // 包含查询参数，不含主机名，回放时可以使用不同的基础URL
+	Body   string `json:"body,omitempty"`
+}
+
+// Response 表示录制的响应，流式响应的全部内容保存在Body中
+type Response struct {
+	StatusCode  int    `json:"status_code"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        string `json:"body"`
+	// 录制时的耗时，回放时可以按此延迟返回
+	DurationMS int64 `json:"duration_ms,omitempty"`
+}
+
+// Interaction 表示一次请求和响应
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+type file struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Cassette 是录制和回放HTTP交互的http.RoundTripper
+// 请求按方法、路径和请求体匹配；相同请求有多条记录时按录制顺序返回，用完后重复最后一条
+type Cassette struct {
+	path string
+	mode Mode
+	next http.RoundTripper
+
+	delay bool // 回放时模拟录制时的耗时
+
+	mu           sync.Mutex
+	interactions []Interaction
+	replayed     map[string]int // 请求键到已回放次数
+}
+
+// Open 打开录制文件，回放模式下文件必须存在，录制模式下追加到已有记录之后
+func Open(path string, mode Mode) (*Cassette, error) {
+	c := &Cassette{path: path, mode: mode, next: http.DefaultTransport, replayed: make(map[string]int)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && mode == Record {
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取录制文件失败: %w", err)
+	}
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("解析录制文件%s失败: %w", path, err)
+	}
+	c.interactions = f.Interactions
+	return c, nil
+}
+
+// Len 返回记录数
+func (c *Cassette) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.interactions)
+}
+
+// SimulateLatency 设置回放时是否按录制时的耗时延迟返回，使回放结果中的延迟有参考意义
+func (c *Cassette) SimulateLatency(enabled bool) {
+	c.delay = enabled
+}
+
+// RoundTrip 实现http.RoundTripper
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded := Request{Method: req.Method, Path: req.URL.RequestURI()}
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("读取请求体失败: %w", err)
+		}
+		recorded.Body = string(body)
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	if c.mode == Replay {
+		resp, ok := c.replay(recorded)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, recorded.Method, recorded.Path)
+		}
+		if c.delay && resp.DurationMS > 0 {
+			timer := time.NewTimer(time.Duration(resp.DurationMS) * time.Millisecond)
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+			case <-req.Context().Done():
+				return nil, req.Context().Err()
+			}
+		}
+		return toHTTP(resp, req), nil
+	}
+
+	start := time.Now()
+	upstream, err := c.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer upstream.Body.Close()
+	body, err := io.ReadAll(upstream.Body)
+	if err != nil {
+		return nil, fmt.Errorf("读取响应体失败: %w", err)
+	}
+	resp := Response{
+		StatusCode:  upstream.StatusCode,
+		ContentType: upstream.Header.Get("Content-Type"),
+		Body:        string(body),
+		DurationMS:  time.Since(start).Milliseconds(),
+	}
+
+	c.mu.Lock()
+	c.interactions = append(c.interactions, Interaction{Request: recorded, Response: resp})
+	c.mu.Unlock()
+	return toHTTP(resp, req), nil
+}
+
+// 查找匹配的记录
+func (c *Cassette) replay(req Request) (Response, bool) {
+	key := requestKey(req)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var matches []Response
+	for _, it := range c.interactions {
+		if requestKey(it.Request) == key {
+			matches = append(matches, it.Response)
+		}
+	}
+	if len(matches) == 0 {
+		return Response{}, false
+	}
+	n := c.replayed[key]
+	c.replayed[key] = n + 1
+	if n >= len(matches) {
+		n = len(matches) - 1
+	}
+	return matches[n], true
+}
+
+// 请求键，JSON请求体规范化后比较，避免字段顺序不同导致不匹配
+func requestKey(req Request) string {
+	body := req.Body
+	var v any
+	if json.Unmarshal([]byte(body), &v) == nil {
+		if normalized, err := json.Marshal(v); err == nil {
+			body = string(normalized)
+		}
+	}
+	return req.Method + " " + req.Path + "\n" + body
+}
+
+func toHTTP(resp Response, req *http.Request) *http.Response {
+	header := make(http.Header)
+	if resp.ContentType != "" {
+		header.Set("Content-Type", resp.ContentType)
+	}
+	return &http.Response{
+		Status:        strconv.Itoa(resp.StatusCode) + " " + http.StatusText(resp.StatusCode),
+		StatusCode:    resp.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewBufferString(resp.Body)),
+		ContentLength: int64(len(resp.Body)),
+		Request:       req,
+	}
+}
+
+// Save 将记录写入文件，回放模式下不做任何操作
+func (c *Cassette) Save() error {
+	if c.mode != Record {
+		return nil
+	}
+	c.mu.Lock()
+	data, err := json.MarshalIndent(file{Interactions: c.interactions}, "", "  ")
+	c.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("序列化录制文件失败: %w", err)
+	}
+	if err := os.WriteFile(c.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("写入录制文件失败: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+package cassette
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func do(t *testing.T, client *http.Client, method, url, body string) (int, string, error) {
+	t.Helper()
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	req, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer secret-token")
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(data), nil
+}
+
+// 录制若干请求后保存，返回录制文件路径
+func record(t *testing.T) string {
+	t.Helper()
+	var calls atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+		fmt.Fprintf(w, `{"call":%d,"path":%q,"body":%q}`, n, r.URL.RequestURI(), body)
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "tape.json")
+	tape, err := Open(path, Record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: tape}
+	for _, r := range []struct{ method, path, body string }{
+		{"POST", "/chat", `{"model":"m","n":1}`},
+		{"POST", "/chat", `{"model":"m","n":1}`},
+		{"POST", "/chat", `{"model":"m","n":2}`},
+		{"GET", "/models?type=text", ""},
+		{"GET", "/missing", ""},
+	} {
+		if _, _, err := do(t, client, r.method, srv.URL+r.path, r.body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if tape.Len() != 5 {
+		t.Fatalf("录制了%d条，期望5条", tape.Len())
+	}
+	if err := tape.Save(); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestReplay(t *testing.T) {
+	path := record(t)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "secret-token") {
+		t.Fatal("录制文件中包含了令牌")
+	}
+
+	tape, err := Open(path, Replay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: tape}
+	// 回放使用不同的主机，按路径匹配
+	const base = "http://replay.invalid"
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		wantCode int
+		wantBody string
+		wantErr  error
+	}{
+		{name: "第一次按录制顺序", method: "POST", path: "/chat", body: `{"model":"m","n":1}`, wantCode: 200, wantBody: `"call":1`},
+		{name: "第二次按录制顺序", method: "POST", path: "/chat", body: `{"n":1, "model":"m"}`, wantCode: 200, wantBody: `"call":2`},
+		{name: "用完后重复最后一条", method: "POST", path: "/chat", body: `{"model":"m","n":1}`, wantCode: 200, wantBody: `"call":2`},
+		{name: "请求体不同", method: "POST", path: "/chat", body: `{"model":"m","n":2}`, wantCode: 200, wantBody: `"call":3`},
+		{name: "查询参数", method: "GET", path: "/models?type=text", wantCode: 200, wantBody: `"call":4`},
+		{name: "错误状态码", method: "GET", path: "/missing", wantCode: 404, wantBody: `"call":5`},
+		{name: "查询参数不同", method: "GET", path: "/models?type=image", wantErr: ErrNoInteraction},
+		{name: "方法不同", method: "GET", path: "/chat", wantErr: ErrNoInteraction},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, body, err := do(t, client, tt.method, base+tt.path, tt.body)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("错误为%v，期望%v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if code != tt.wantCode || !strings.Contains(body, tt.wantBody) {
+				t.Fatalf("响应%d %s，期望%d且包含%s", code, body, tt.wantCode, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestOpen(t *testing.T) {
+	dir := t.TempDir()
+	missing := filepath.Join(dir, "missing.json")
+	invalid := filepath.Join(dir, "invalid.json")
+	os.WriteFile(invalid, []byte("{"), 0o644)
+
+	tests := []struct {
+		name    string
+		path    string
+		mode    Mode
+		wantErr bool
+	}{
+		{name: "录制时文件可以不存在", path: missing, mode: Record},
+		{name: "回放时文件必须存在", path: missing, mode: Replay, wantErr: true},
+		{name: "文件格式错误", path: invalid, mode: Replay, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Open(tt.path, tt.mode)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("错误为%v，期望出错: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRecordAppends(t *testing.T) {
+	path := record(t)
+	tape, err := Open(path, Record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tape.Len() != 5 {
+		t.Fatalf("追加录制时加载了%d条，期望5条", tape.Len())
+	}
+
+	// 回放模式下Save不修改文件
+	before, _ := os.ReadFile(path)
+	replay, _ := Open(path, Replay)
+	if err := replay.Save(); err != nil {
+		t.Fatal(err)
+	}
+	after, _ := os.ReadFile(path)
+	if string(before) != string(after) {
+		t.Fatal("回放模式下Save修改了文件")
+	}
+}
+
+func TestSimulateLatency(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tape.json")
+	os.WriteFile(path, []byte(`{"interactions":[{"request":{"method":"GET","path":"/slow"},"response":{"status_code":200,"body":"ok","duration_ms":50}}]}`), 0o644)
+	tape, err := Open(path, Replay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tape.SimulateLatency(true)
+
+	start := time.Now()
+	req, _ := http.NewRequest("GET", "http://x/slow", nil)
+	if _, err := tape.RoundTrip(req); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Fatalf("回放耗时%v，期望至少50ms", elapsed)
+	}
+
+	// 取消请求时立即返回
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, _ = http.NewRequestWithContext(ctx, "GET", "http://x/slow", nil)
+	if _, err := tape.RoundTrip(req); !errors.Is(err, context.Canceled) {
+		t.Fatalf("错误为%v，期望context.Canceled", err)
+	}
+}
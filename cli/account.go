@@ -0,0 +1,167 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/kriswu/go_deepseek/siliconproxy"
+)
+
+// voice 管理自定义音色
+func runVoice(env *Env, args []string) error {
+	if len(args) == 0 {
+		return errors.New("用法: voice upload [参数] <音频文件> | voice list | voice delete <uri>")
+	}
+	switch args[0] {
+	case "upload":
+		return runVoiceUpload(env, args[1:])
+	case "list":
+		return runVoiceList(env, args[1:])
+	case "delete":
+		return runVoiceDelete(env, args[1:])
+	default:
+		return fmt.Errorf("未知的voice子命令: %s", args[0])
+	}
+}
+
+func runVoiceUpload(env *Env, args []string) error {
+	fs := env.FlagSet("voice upload")
+	model := fs.String("model", DefaultSpeechModel, "模型")
+	name := fs.String("name", "", "自定义音色名称（必填）")
+	text := fs.String("text", "", "参考音频对应的文字（必填）")
+	if err := env.Parse(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 || *name == "" || *text == "" {
+		return errors.New("用法: voice upload -name 名称 -text 文字 <音频文件>")
+	}
+	sp, err := env.Proxy()
+	if err != nil {
+		return err
+	}
+
+	resp, err := sp.UploadVoice(env.Context, &siliconproxy.UploadVoiceRequest{
+		Model:      *model,
+		CustomName: *name,
+		Text:       *text,
+		FilePath:   fs.Arg(0),
+	})
+	if err != nil {
+		return err
+	}
+	if env.Output == OutputJSON {
+		return env.JSON(resp)
+	}
+	fmt.Fprintln(env.Stdout, resp.URI)
+	return nil
+}
+
+func runVoiceList(env *Env, args []string) error {
+	fs := env.FlagSet("voice list")
+	if err := env.Parse(fs, args); err != nil {
+		return err
+	}
+	sp, err := env.Proxy()
+	if err != nil {
+		return err
+	}
+
+	resp, err := sp.GetVoiceList(env.Context)
+	if err != nil {
+		return err
+	}
+	if env.Output == OutputJSON {
+		return env.JSON(resp.Result)
+	}
+	rows := make([][]string, 0, len(resp.Result))
+	for _, v := range resp.Result {
+		rows = append(rows, []string{v.CustomName, v.Model, v.URI, truncate(v.Text, 30)})
+	}
+	return env.Table([]string{"NAME", "MODEL", "URI", "TEXT"}, rows)
+}
+
+func runVoiceDelete(env *Env, args []string) error {
+	fs := env.FlagSet("voice delete")
+	if err := env.Parse(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New("用法: voice delete <uri>")
+	}
+	sp, err := env.Proxy()
+	if err != nil {
+		return err
+	}
+
+	resp, err := sp.DeleteVoice(env.Context, &siliconproxy.DeleteVoiceRequest{URI: fs.Arg(0)})
+	if err != nil {
+		return err
+	}
+	if env.Output == OutputJSON {
+		return env.JSON(resp)
+	}
+	fmt.Fprintf(env.Stdout, "已删除 %s\n", resp.URI)
+	return nil
+}
+
+// models 列出上游模型
+func runModels(env *Env, args []string) error {
+	fs := env.FlagSet("models")
+	typ := fs.String("type", "", "模型类型，text、image、audio或video")
+	subType := fs.String("sub-type", "", "子类型，如chat、embedding、reranker、text-to-image")
+	if err := env.Parse(fs, args); err != nil {
+		return err
+	}
+	sp, err := env.Proxy()
+	if err != nil {
+		return err
+	}
+
+	resp, err := sp.GetModelListWithOptions(env.Context, siliconproxy.ModelListOptions{Type: *typ, SubType: *subType})
+	if err != nil {
+		return err
+	}
+	if env.Output == OutputJSON {
+		return env.JSON(resp.Data)
+	}
+	rows := make([][]string, 0, len(resp.Data))
+	for _, m := range resp.Data {
+		rows = append(rows, []string{m.ID, m.OwnedBy})
+	}
+	return env.Table([]string{"ID", "OWNED_BY"}, rows)
+}
+
+// balance 查询账户余额
+func runBalance(env *Env, args []string) error {
+	fs := env.FlagSet("balance")
+	if err := env.Parse(fs, args); err != nil {
+		return err
+	}
+	sp, err := env.Proxy()
+	if err != nil {
+		return err
+	}
+
+	resp, err := sp.GetUserInfo(env.Context)
+	if err != nil {
+		return err
+	}
+	balance, charge, total, err := resp.Data.Balances()
+	if err != nil {
+		return err
+	}
+	if env.Output == OutputJSON {
+		return env.JSON(map[string]any{
+			"name":           resp.Data.Name,
+			"status":         resp.Data.Status,
+			"balance":        balance,
+			"charge_balance": charge,
+			"total_balance":  total,
+		})
+	}
+	format := func(v float64) string { return strconv.FormatFloat(v, 'f', 4, 64) }
+	return env.Table([]string{"NAME", "STATUS", "BALANCE", "CHARGE_BALANCE", "TOTAL_BALANCE"}, [][]string{
+		{resp.Data.Name, resp.Data.Status, format(balance), format(charge), format(total)},
+	})
+}
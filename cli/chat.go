@@ -0,0 +1,255 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/kriswu/go_deepseek/siliconproxy"
+)
+
+// chat 发送单轮对话，table格式下流式输出到终端，json格式下输出完整响应
+func runChat(env *Env, args []string) error {
+	fs := env.FlagSet("chat")
+	model := fs.String("model", DefaultChatModel, "模型")
+	system := fs.String("system", "", "系统提示词")
+	temperature := fs.Float64("temperature", 0, "采样温度，0表示使用模型默认值")
+	topP := fs.Float64("top-p", 0, "核采样概率，0表示使用模型默认值")
+	maxTokens := fs.Int("max-tokens", 0, "最大生成token数，0表示使用模型默认值")
+	noStream := fs.Bool("no-stream", false, "等待完整响应后再输出")
+	reasoning := fs.Bool("reasoning", false, "将reasoning_content输出到标准错误")
+	if err := env.Parse(fs, args); err != nil {
+		return err
+	}
+	prompt, err := env.Input(fs.Args())
+	if err != nil {
+		return err
+	}
+	sp, err := env.Proxy()
+	if err != nil {
+		return err
+	}
+
+	req := &siliconproxy.ChatCompletionRequest{
+		Model:     *model,
+		TopP:      *topP,
+		MaxTokens: *maxTokens,
+	}
+	if *temperature != 0 {
+		req.Temperature = siliconproxy.Float64(*temperature)
+	}
+	if *system != "" {
+		req.Messages = append(req.Messages, siliconproxy.ChatCompletionMessage{Role: "system", Content: *system})
+	}
+	req.Messages = append(req.Messages, siliconproxy.ChatCompletionMessage{Role: "user", Content: prompt})
+
+	if env.Output == OutputJSON || *noStream {
+		resp, err := sp.CreateChatCompletion(env.Context, req)
+		if err != nil {
+			return err
+		}
+		if env.Output == OutputJSON {
+			return env.JSON(resp)
+		}
+		if len(resp.Choices) == 0 {
+			return errors.New("响应中没有结果")
+		}
+		msg := resp.Choices[0].Message
+		if *reasoning && msg.ReasoningContent != "" {
+			fmt.Fprintln(env.Stderr, msg.ReasoningContent)
+		}
+		fmt.Fprintln(env.Stdout, msg.Content)
+		printUsage(env, &resp.Usage)
+		return nil
+	}
+
+	result, err := streamChat(env, sp, req, *reasoning, nil)
+	if err != nil {
+		return err
+	}
+	printUsage(env, result.Usage)
+	return nil
+}
+
+// 流式对话的结果
+type streamResult struct {
+	Content   string
+	Reasoning string
+	Usage     *siliconproxy.ChatCompletionUsage // 上游未返回用量时为nil
+}
+
+// 流式输出回答，reasoning为true时将思考过程输出到标准错误
+// stop被关闭时中断读取并返回errInterrupted，已收到的内容仍然返回
+func streamChat(env *Env, sp *siliconproxy.SiliconProxy, req *siliconproxy.ChatCompletionRequest, reasoning bool, stop <-chan struct{}) (*streamResult, error) {
+	stream, err := sp.CreateChatCompletionStream(env.Context, req)
+	if err != nil {
+		return nil, err
+	}
+	done := make(chan struct{})
+	defer close(done)
+	interrupted := make(chan struct{})
+	go func() {
+		select {
+		case <-stop:
+			close(interrupted)
+			stream.Close()
+		case <-done:
+			stream.Close()
+		}
+	}()
+
+	var result streamResult
+	var content, thought strings.Builder
+	thinking := false
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			select {
+			case <-interrupted:
+				err = errInterrupted
+			default:
+			}
+			fmt.Fprintln(env.Stdout)
+			result.Content, result.Reasoning = content.String(), thought.String()
+			return &result, err
+		}
+		if chunk.Usage != nil {
+			result.Usage = chunk.Usage
+		}
+		for _, choice := range chunk.Choices {
+			thought.WriteString(choice.Delta.ReasoningContent)
+			content.WriteString(choice.Delta.Content)
+			if reasoning && choice.Delta.ReasoningContent != "" {
+				fmt.Fprint(env.Stderr, choice.Delta.ReasoningContent)
+				thinking = true
+			}
+			if thinking && choice.Delta.Content != "" {
+				// 思考过程结束后换行，与回答分开
+				fmt.Fprintln(env.Stderr)
+				thinking = false
+			}
+			fmt.Fprint(env.Stdout, choice.Delta.Content)
+		}
+	}
+	fmt.Fprintln(env.Stdout)
+	result.Content, result.Reasoning = content.String(), thought.String()
+	return &result, nil
+}
+
+// 流式对话被用户中断
+var errInterrupted = errors.New("已中断")
+
+// 在标准错误输出token用量，不干扰标准输出中的回答
+func printUsage(env *Env, usage *siliconproxy.ChatCompletionUsage) {
+	if usage == nil || usage.TotalTokens == 0 {
+		return
+	}
+	fmt.Fprintf(env.Stderr, "[tokens: 输入%d 输出%d 合计%d]\n", usage.PromptTokens, usage.CompletionTokens, usage.TotalTokens)
+}
+
+// embed 为每段文本生成向量
+func runEmbed(env *Env, args []string) error {
+	fs := env.FlagSet("embed")
+	model := fs.String("model", DefaultEmbedModel, "模型")
+	dimensions := fs.Int("dimensions", 0, "输出向量维度，仅部分模型支持")
+	if err := env.Parse(fs, args); err != nil {
+		return err
+	}
+	texts, err := env.Lines(fs.Args())
+	if err != nil {
+		return err
+	}
+	sp, err := env.Proxy()
+	if err != nil {
+		return err
+	}
+
+	resp, err := sp.CreateEmbedding(env.Context, &siliconproxy.EmbeddingRequest{
+		Model:      *model,
+		Input:      texts,
+		Dimensions: *dimensions,
+	})
+	if err != nil {
+		return err
+	}
+	if env.Output == OutputJSON {
+		return env.JSON(resp)
+	}
+
+	rows := make([][]string, 0, len(resp.Data))
+	for _, d := range resp.Data {
+		text := ""
+		if d.Index < len(texts) {
+			text = truncate(texts[d.Index], 40)
+		}
+		rows = append(rows, []string{strconv.Itoa(d.Index), strconv.Itoa(len(d.Embedding)), previewVector(d.Embedding), text})
+	}
+	return env.Table([]string{"INDEX", "DIM", "VECTOR", "TEXT"}, rows)
+}
+
+// 输出向量的前几个分量
+func previewVector(v []float64) string {
+	const n = 4
+	s := "["
+	for i, x := range v {
+		if i == n {
+			s += ", …"
+			break
+		}
+		if i > 0 {
+			s += ", "
+		}
+		s += strconv.FormatFloat(x, 'f', 4, 64)
+	}
+	return s + "]"
+}
+
+// rerank 按与问题的相关性对文档排序
+func runRerank(env *Env, args []string) error {
+	fs := env.FlagSet("rerank")
+	model := fs.String("model", DefaultRerankModel, "模型")
+	query := fs.String("query", "", "问题（必填）")
+	topN := fs.Int("top-n", 0, "返回前N个结果，0表示全部")
+	if err := env.Parse(fs, args); err != nil {
+		return err
+	}
+	if *query == "" {
+		return errors.New("缺少-query参数")
+	}
+	docs, err := env.Lines(fs.Args())
+	if err != nil {
+		return err
+	}
+	sp, err := env.Proxy()
+	if err != nil {
+		return err
+	}
+
+	resp, err := sp.CreateRerank(env.Context, &siliconproxy.RerankRequest{
+		Model:     *model,
+		Query:     *query,
+		Documents: docs,
+		TopN:      *topN,
+	})
+	if err != nil {
+		return err
+	}
+	if env.Output == OutputJSON {
+		return env.JSON(resp)
+	}
+
+	rows := make([][]string, 0, len(resp.Results))
+	for rank, r := range resp.Results {
+		doc := ""
+		if r.Index < len(docs) {
+			doc = truncate(docs[r.Index], 60)
+		}
+		rows = append(rows, []string{strconv.Itoa(rank + 1), strconv.Itoa(r.Index), strconv.FormatFloat(r.RelevanceScore, 'f', 4, 64), doc})
+	}
+	return env.Table([]string{"RANK", "INDEX", "SCORE", "DOCUMENT"}, rows)
+}
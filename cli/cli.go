@@ -0,0 +1,230 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kriswu/go_deepseek/config"
+	"github.com/kriswu/go_deepseek/httpclient"
+	"github.com/kriswu/go_deepseek/siliconproxy"
+)
+
+// 各子命令的默认模型
+const (
+	DefaultChatModel   = "deepseek-ai/DeepSeek-V3"
+	DefaultEmbedModel  = "BAAI/bge-m3"
+	DefaultRerankModel = "BAAI/bge-reranker-v2-m3"
+	DefaultImageModel  = "black-forest-labs/FLUX.1-schnell"
+	DefaultSpeechModel = "FunAudioLLM/CosyVoice2-0.5B"
+	DefaultVoice       = DefaultSpeechModel + ":alex"
+	DefaultVideoModel  = "Wan-AI/Wan2.1-T2V-14B"
+)
+
+// 输出格式
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+)
+
+// Env 表示子命令的运行环境
+type Env struct {
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+	Output string // table或json
+
+	// 上游请求使用的上下文
+	Context context.Context
+
+	loader  *config.Loader
+	conf    *config.Config
+	sp      *siliconproxy.SiliconProxy
+	options []httpclient.ClientOption // Proxy创建代理时附加的选项
+}
+
+type command struct {
+	args    string
+	summary string
+	run     func(env *Env, args []string) error
+}
+
+var commands map[string]command
+
+func init() {
+	commands = map[string]command{
+		"chat":    {"[参数] [提示词|-]", "发送对话，默认流式输出", runChat},
+		"repl":    {"[参数]", "交互式多轮对话，输入/help查看命令", runREPL},
+		"embed":   {"[参数] [文本...]", "生成文本向量，无参数时按行读取标准输入", runEmbed},
+		"rerank":  {"-query 问题 [文档...]", "文档重排序，无文档参数时按行读取标准输入", runRerank},
+		"image":   {"[参数] [提示词|-]", "生成图像并保存到文件", runImage},
+		"tts":     {"[参数] [文本|-]", "文本转语音并保存到文件", runSpeech},
+		"voice":   {"upload|list|delete", "管理自定义音色", runVoice},
+		"video":   {"submit|wait", "提交视频任务或等待任务完成并下载", runVideo},
+		"models":  {"[-type 类型] [-sub-type 子类型]", "列出模型", runModels},
+		"balance": {"", "查询账户余额", runBalance},
+		"eval":    {"-suite 套件文件 [参数]", "在多个模型和提示词上运行评测并输出对比报告", runEval},
+	}
+}
+
+// IsCommand 判断name是否为命令行子命令
+func IsCommand(name string) bool {
+	_, ok := commands[name]
+	return ok
+}
+
+// Run 执行子命令，args以子命令名开头
+// 每个子命令都支持全局参数：-config、-base-url、-token-file、-o
+func Run(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	if len(args) == 0 || !IsCommand(args[0]) {
+		Usage(stderr)
+		return flag.ErrHelp
+	}
+	cmd := commands[args[0]]
+	env := &Env{Stdin: stdin, Stdout: stdout, Stderr: stderr, Context: context.Background()}
+	return cmd.run(env, args[1:])
+}
+
+// Usage 输出子命令列表
+func Usage(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(w, "用法: go_deepseek [serve] [服务器参数]")
+	fmt.Fprintln(w, "      go_deepseek <子命令> [参数]")
+	fmt.Fprintln(w, "\n子命令:")
+	tw := tabwriter.NewWriter(w, 0, 4, 4, ' ', 0)
+	for _, name := range names {
+		fmt.Fprintf(tw, "  %s %s\t%s\n", name, commands[name].args, commands[name].summary)
+	}
+	tw.Flush()
+	fmt.Fprintln(w, "\n全局参数: -config、-base-url、-token-file同服务器，-o table|json选择输出格式")
+	fmt.Fprintln(w, "上游令牌与服务器一样通过SILICON_TOKEN、SILICON_TOKEN_FILE或配置文件提供")
+}
+
+// FlagSet 创建子命令的参数集，并注册全局参数
+func (env *Env) FlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(env.Stderr)
+	env.loader = config.BindFlags(fs)
+	fs.StringVar(&env.Output, "o", OutputTable, "输出格式，table或json")
+	return fs
+}
+
+// Parse 解析参数并检查输出格式
+func (env *Env) Parse(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if env.Output != OutputTable && env.Output != OutputJSON {
+		return fmt.Errorf("不支持的输出格式: %s", env.Output)
+	}
+	return nil
+}
+
+// Config 加载与服务器相同的配置，首次调用时读取
+func (env *Env) Config() (*config.Config, error) {
+	if env.conf == nil {
+		conf, err := env.loader.Load()
+		if err != nil {
+			return nil, fmt.Errorf("加载配置失败: %w", err)
+		}
+		env.conf = conf
+	}
+	return env.conf, nil
+}
+
+// Proxy 根据配置创建上游代理，首次调用时创建
+func (env *Env) Proxy() (*siliconproxy.SiliconProxy, error) {
+	if env.sp != nil {
+		return env.sp, nil
+	}
+	conf, err := env.Config()
+	if err != nil {
+		return nil, err
+	}
+	options := env.options
+	if conf.Upstream.TimeoutSeconds > 0 {
+		options = append(options, httpclient.WithTimeout(time.Duration(conf.Upstream.TimeoutSeconds)*time.Second))
+	}
+	env.sp = siliconproxy.NewSiliconProxy(conf.Upstream.Token, options...)
+	env.sp.SetBaseURL(conf.Upstream.BaseURL)
+	return env.sp, nil
+}
+
+// Input 返回位置参数拼接成的文本，没有参数或参数为"-"时读取标准输入
+func (env *Env) Input(args []string) (string, error) {
+	if len(args) > 0 && !(len(args) == 1 && args[0] == "-") {
+		return strings.Join(args, " "), nil
+	}
+	data, err := io.ReadAll(env.Stdin)
+	if err != nil {
+		return "", fmt.Errorf("读取标准输入失败: %w", err)
+	}
+	text := strings.TrimSpace(string(data))
+	if text == "" {
+		return "", errors.New("输入为空")
+	}
+	return text, nil
+}
+
+// Lines 返回位置参数，没有参数时按行读取标准输入并跳过空行
+func (env *Env) Lines(args []string) ([]string, error) {
+	if len(args) > 0 && !(len(args) == 1 && args[0] == "-") {
+		return args, nil
+	}
+	data, err := io.ReadAll(env.Stdin)
+	if err != nil {
+		return nil, fmt.Errorf("读取标准输入失败: %w", err)
+	}
+	var lines []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) == 0 {
+		return nil, errors.New("输入为空")
+	}
+	return lines, nil
+}
+
+// JSON 以缩进格式输出v
+func (env *Env) JSON(v any) error {
+	enc := json.NewEncoder(env.Stdout)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(v)
+}
+
+// Table 以制表符对齐输出表格，header为空时不输出表头
+func (env *Env) Table(header []string, rows [][]string) error {
+	tw := tabwriter.NewWriter(env.Stdout, 0, 4, 2, ' ', 0)
+	if len(header) > 0 {
+		fmt.Fprintln(tw, strings.Join(header, "\t"))
+	}
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// 截断过长的文本，用于表格输出
+func truncate(s string, n int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
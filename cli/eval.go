@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+
+	"github.com/kriswu/go_deepseek/cassette"
+	"github.com/kriswu/go_deepseek/eval"
+	"github.com/kriswu/go_deepseek/httpclient"
+	"github.com/kriswu/go_deepseek/siliconproxy"
+)
+
+// eval 运行评测套件
+// 指定-cassette时从录制文件回放，不需要令牌和网络；加上-record时访问上游并录制
+func runEval(env *Env, args []string) error {
+	fs := env.FlagSet("eval")
+	suitePath := fs.String("suite", "", "评测套件文件（必填）")
+	cassettePath := fs.String("cassette", "", "录制文件，默认只回放")
+	record := fs.Bool("record", false, "访问上游并将请求录制到-cassette文件")
+	concurrency := fs.Int("concurrency", 0, "并发数，覆盖套件配置")
+	reportPath := fs.String("report", "", "同时将报告写入文件，.md为Markdown，其他为JSON")
+	latency := fs.Bool("simulate-latency", true, "回放时按录制时的耗时延迟返回，使报告中的延迟有参考意义")
+	quiet := fs.Bool("q", false, "不输出进度")
+	if err := env.Parse(fs, args); err != nil {
+		return err
+	}
+	if *suitePath == "" || fs.NArg() != 0 {
+		return errors.New("用法: eval -suite 套件文件 [-cassette 录制文件 [-record]] [-report 报告文件]")
+	}
+	if *record && *cassettePath == "" {
+		return errors.New("-record需要同时指定-cassette")
+	}
+
+	suite, err := eval.LoadSuite(*suitePath)
+	if err != nil {
+		return err
+	}
+	if *concurrency > 0 {
+		suite.Concurrency = *concurrency
+	}
+	cases, err := eval.LoadDataset(suite.Dataset)
+	if err != nil {
+		return err
+	}
+
+	var tape *cassette.Cassette
+	var sp *siliconproxy.SiliconProxy
+	switch {
+	case *cassettePath == "":
+		sp, err = env.Proxy()
+	case *record:
+		if tape, err = cassette.Open(*cassettePath, cassette.Record); err == nil {
+			env.options = append(env.options, httpclient.WithTransport(tape))
+			sp, err = env.Proxy()
+		}
+	default:
+		// 回放不需要配置和令牌
+		if tape, err = cassette.Open(*cassettePath, cassette.Replay); err == nil {
+			tape.SimulateLatency(*latency)
+			sp = siliconproxy.NewSiliconProxy("", httpclient.WithTransport(tape))
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	runner, err := eval.NewRunner(sp, suite)
+	if err != nil {
+		return err
+	}
+	if !*quiet {
+		runner.Progress = func(done, total int, r *eval.Result) {
+			status := "通过"
+			if r.Error != "" {
+				status = "错误: " + truncate(r.Error, 60)
+			} else if !r.Passed() {
+				status = "未通过"
+			}
+			fmt.Fprintf(env.Stderr, "[%d/%d] %s %s %s %s\n", done, total, r.Model, r.Prompt, r.CaseID, status)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	report, runErr := runner.Run(ctx, cases)
+
+	// 中断时也保存已录制的请求和已完成的报告
+	if tape != nil {
+		if err := tape.Save(); err != nil {
+			return err
+		}
+		if *record {
+			fmt.Fprintf(env.Stderr, "已录制%d条请求到%s\n", tape.Len(), *cassettePath)
+		}
+	}
+	if *reportPath != "" {
+		if err := writeReport(*reportPath, report); err != nil {
+			return err
+		}
+	}
+	if env.Output == OutputJSON {
+		if err := report.WriteJSON(env.Stdout); err != nil {
+			return err
+		}
+	} else if err := report.WriteTable(env.Stdout); err != nil {
+		return err
+	}
+	return runErr
+}
+
+func writeReport(path string, report *eval.Report) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("创建报告文件失败: %w", err)
+	}
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext == ".md" || ext == ".markdown" {
+		err = report.WriteMarkdown(f)
+	} else {
+		err = report.WriteJSON(f)
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("写入报告失败: %w", err)
+	}
+	return nil
+}
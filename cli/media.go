@@ -0,0 +1,285 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/kriswu/go_deepseek/siliconproxy"
+)
+
+// 保存媒体文件的结果，用于json输出
+type savedFile struct {
+	Path  string `json:"path"`
+	URL   string `json:"url,omitempty"`
+	Bytes int64  `json:"bytes"`
+}
+
+// image 生成图像，按<out><序号><扩展名>保存
+func runImage(env *Env, args []string) error {
+	fs := env.FlagSet("image")
+	model := fs.String("model", DefaultImageModel, "模型")
+	size := fs.String("size", "", "图像尺寸，如1024x1024")
+	n := fs.Int("n", 1, "生成数量")
+	seed := fs.Int64("seed", 0, "随机种子，0表示随机")
+	steps := fs.Int("steps", 0, "推理步数，0表示使用模型默认值")
+	negative := fs.String("negative", "", "反向提示词")
+	input := fs.String("image", "", "图生图的输入图像文件")
+	out := fs.String("out", "image", "输出文件名前缀")
+	if err := env.Parse(fs, args); err != nil {
+		return err
+	}
+	prompt, err := env.Input(fs.Args())
+	if err != nil {
+		return err
+	}
+	sp, err := env.Proxy()
+	if err != nil {
+		return err
+	}
+
+	req := &siliconproxy.ImageGenerationRequest{
+		Model:             *model,
+		Prompt:            prompt,
+		ImageSize:         *size,
+		BatchSize:         *n,
+		Seed:              *seed,
+		NumInferenceSteps: *steps,
+		NegativePrompt:    *negative,
+	}
+	if *input != "" {
+		if req.Image, err = siliconproxy.DataURIFromFile(*input); err != nil {
+			return err
+		}
+	}
+	resp, err := sp.CreateImageGeneration(env.Context, req)
+	if err != nil {
+		return err
+	}
+
+	var files []savedFile
+	for i, data := range resp.AllImages() {
+		img, err := sp.FetchImage(env.Context, data, 0)
+		if err != nil {
+			return fmt.Errorf("获取第%d张图像失败: %w", i+1, err)
+		}
+		path := *out + img.Extension()
+		if len(resp.AllImages()) > 1 {
+			path = fmt.Sprintf("%s-%d%s", *out, i+1, img.Extension())
+		}
+		if err := img.Save(path); err != nil {
+			return err
+		}
+		files = append(files, savedFile{Path: path, URL: data.URL, Bytes: int64(len(img.Data))})
+	}
+	if len(files) == 0 {
+		return errors.New("响应中没有图像")
+	}
+	return env.savedFiles(files)
+}
+
+// 输出已保存的文件
+func (env *Env) savedFiles(files []savedFile) error {
+	if env.Output == OutputJSON {
+		return env.JSON(files)
+	}
+	rows := make([][]string, 0, len(files))
+	for _, f := range files {
+		rows = append(rows, []string{f.Path, strconv.FormatInt(f.Bytes, 10)})
+	}
+	return env.Table([]string{"FILE", "BYTES"}, rows)
+}
+
+// tts 文本转语音，-out为"-"时写入标准输出
+func runSpeech(env *Env, args []string) error {
+	fs := env.FlagSet("tts")
+	model := fs.String("model", DefaultSpeechModel, "模型")
+	voice := fs.String("voice", DefaultVoice, "音色，可以是系统音色或voice upload返回的URI")
+	format := fs.String("format", siliconproxy.SpeechFormatMP3, "音频格式，mp3、opus、wav或pcm")
+	speed := fs.Float64("speed", 0, "语速，0表示使用默认值")
+	gain := fs.Float64("gain", 0, "音量增益(dB)")
+	out := fs.String("out", "", "输出文件，默认speech.<格式>，-表示标准输出")
+	if err := env.Parse(fs, args); err != nil {
+		return err
+	}
+	text, err := env.Input(fs.Args())
+	if err != nil {
+		return err
+	}
+	sp, err := env.Proxy()
+	if err != nil {
+		return err
+	}
+
+	stream, err := sp.CreateSpeechStream(env.Context, &siliconproxy.CreateSpeechRequest{
+		Model:          *model,
+		Input:          text,
+		Voice:          *voice,
+		ResponseFormat: *format,
+		Speed:          *speed,
+		Gain:           *gain,
+	})
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	if *out == "-" {
+		if _, err := io.Copy(env.Stdout, stream); err != nil {
+			return fmt.Errorf("读取音频数据失败: %w", err)
+		}
+		return nil
+	}
+	path := *out
+	if path == "" {
+		path = "speech." + *format
+	}
+	n, err := writeFile(path, stream)
+	if err != nil {
+		return err
+	}
+	return env.savedFiles([]savedFile{{Path: path, Bytes: n}})
+}
+
+// 边读取边写入文件，失败时删除不完整的文件
+func writeFile(path string, r io.Reader) (int64, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return 0, fmt.Errorf("创建文件失败: %w", err)
+	}
+	n, err := io.Copy(f, r)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(path)
+		return n, fmt.Errorf("写入文件%s失败: %w", path, err)
+	}
+	return n, nil
+}
+
+// video 提交视频任务或等待任务完成
+func runVideo(env *Env, args []string) error {
+	if len(args) == 0 {
+		return errors.New("用法: video submit [参数] [提示词|-] 或 video wait [参数] <requestId>")
+	}
+	switch args[0] {
+	case "submit":
+		return runVideoSubmit(env, args[1:])
+	case "wait":
+		return runVideoWait(env, args[1:])
+	default:
+		return fmt.Errorf("未知的video子命令: %s", args[0])
+	}
+}
+
+func runVideoSubmit(env *Env, args []string) error {
+	fs := env.FlagSet("video submit")
+	model := fs.String("model", DefaultVideoModel, "模型")
+	size := fs.String("size", "", "视频尺寸，1280x720、720x1280或960x960")
+	seed := fs.Int64("seed", 0, "随机种子，0表示随机")
+	negative := fs.String("negative", "", "反向提示词")
+	input := fs.String("image", "", "图生视频的输入图像文件")
+	if err := env.Parse(fs, args); err != nil {
+		return err
+	}
+	prompt, err := env.Input(fs.Args())
+	if err != nil {
+		return err
+	}
+
+	req := &siliconproxy.VideoSubmitRequest{
+		Model:          *model,
+		Prompt:         prompt,
+		ImageSize:      *size,
+		Seed:           *seed,
+		NegativePrompt: *negative,
+	}
+	if *input != "" {
+		if err := req.SetImageFromFile(*input); err != nil {
+			return err
+		}
+	}
+	if err := req.Validate(); err != nil {
+		return err
+	}
+	sp, err := env.Proxy()
+	if err != nil {
+		return err
+	}
+	resp, err := sp.CreateVideoSubmit(env.Context, req)
+	if err != nil {
+		return err
+	}
+	if env.Output == OutputJSON {
+		return env.JSON(resp)
+	}
+	fmt.Fprintln(env.Stdout, resp.RequestID)
+	return nil
+}
+
+func runVideoWait(env *Env, args []string) error {
+	fs := env.FlagSet("video wait")
+	interval := fs.Duration("interval", 5*time.Second, "轮询间隔")
+	timeout := fs.Duration("timeout", 30*time.Minute, "最长等待时间")
+	out := fs.String("out", "", "下载视频的文件路径，默认<requestId>.mp4，-表示不下载")
+	if err := env.Parse(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New("用法: video wait [参数] <requestId>")
+	}
+	id := fs.Arg(0)
+	sp, err := env.Proxy()
+	if err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(*timeout)
+	var status *siliconproxy.VideoStatusResponse
+	for {
+		status, err = sp.GetVideoStatus(env.Context, &siliconproxy.VideoStatusRequest{RequestID: id})
+		if err != nil {
+			return err
+		}
+		if status.Done() {
+			break
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("等待视频任务%s超时，当前状态: %s", id, status.Status)
+		}
+		fmt.Fprintf(env.Stderr, "状态: %s\n", status.Status)
+		time.Sleep(*interval)
+	}
+	if status.Status == siliconproxy.VideoStatusFailed {
+		return fmt.Errorf("视频任务%s失败: %s", id, status.Reason)
+	}
+
+	if *out == "-" || status.VideoURL() == "" {
+		if env.Output == OutputJSON {
+			return env.JSON(status)
+		}
+		fmt.Fprintln(env.Stdout, status.VideoURL())
+		return nil
+	}
+	path := *out
+	if path == "" {
+		path = id + ".mp4"
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("创建文件失败: %w", err)
+	}
+	n, err := sp.DownloadTo(env.Context, status.VideoURL(), f, 0)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(path)
+		return err
+	}
+	return env.savedFiles([]savedFile{{Path: path, URL: status.VideoURL(), Bytes: n}})
+}
@@ -0,0 +1,328 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+
+	"github.com/kriswu/go_deepseek/modelcatalog"
+	"github.com/kriswu/go_deepseek/siliconproxy"
+)
+
+const replHelp = `命令:
+  /model [名称]            查看或切换模型
+  /system [提示词]         查看或设置系统提示词，/system -清除
+  /temperature [值]        设置采样温度，0表示使用模型默认值
+  /top_p [值]              设置核采样概率，0表示使用模型默认值
+  /max_tokens [值]         设置最大生成token数，0表示使用模型默认值
+  /reasoning [on|off]      切换是否显示reasoning_content
+  /history                 显示对话历史
+  /usage                   显示本次会话累计用量和费用
+  /clear                   清空对话历史，保留参数
+  /save <文件>             保存对话记录，.md保存为Markdown，其余保存为JSON
+  /load <文件>             加载对话记录和参数
+  /exit                    退出
+以\结尾的行与下一行合并为一条消息；回答过程中按Ctrl-C中断本轮对话`
+
+// 交互式对话的状态
+type repl struct {
+	env        *Env
+	sp         *siliconproxy.SiliconProxy
+	transcript *Transcript
+	prices     map[string]modelcatalog.Capabilities
+	reasoning  bool
+
+	// 本次会话累计值
+	promptTokens     int
+	completionTokens int
+	cost             float64
+}
+
+// repl 启动交互式对话
+func runREPL(env *Env, args []string) error {
+	fs := env.FlagSet("repl")
+	model := fs.String("model", DefaultChatModel, "模型")
+	system := fs.String("system", "", "系统提示词")
+	load := fs.String("load", "", "启动时加载的对话记录")
+	capabilities := fs.String("capabilities", "", "模型能力文件，用于计算费用，默认使用配置中的model_catalog.capabilities_file")
+	reasoning := fs.Bool("reasoning", false, "显示reasoning_content")
+	if err := env.Parse(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("未知参数: %s", strings.Join(fs.Args(), " "))
+	}
+	sp, err := env.Proxy()
+	if err != nil {
+		return err
+	}
+
+	r := &repl{
+		env:        env,
+		sp:         sp,
+		transcript: &Transcript{Settings: Settings{Model: *model, System: *system}},
+		reasoning:  *reasoning,
+	}
+	if *capabilities == "" {
+		if conf, err := env.Config(); err == nil && conf.ModelCatalog != nil {
+			*capabilities = conf.ModelCatalog.CapabilitiesFile
+		}
+	}
+	if *capabilities != "" {
+		if r.prices, err = modelcatalog.LoadCapabilities(*capabilities); err != nil {
+			return err
+		}
+	}
+	if *load != "" {
+		if err := r.load(*load); err != nil {
+			return err
+		}
+	}
+	return r.run()
+}
+
+func (r *repl) run() error {
+	// 回答过程中Ctrl-C只中断本轮，等待输入时Ctrl-C退出
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	defer signal.Stop(interrupts)
+
+	lines := make(chan string)
+	readErr := make(chan error, 1)
+	go func() {
+		scanner := bufio.NewScanner(r.env.Stdin)
+		scanner.Buffer(make([]byte, 64*1024), 16<<20)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		readErr <- scanner.Err()
+	}()
+
+	fmt.Fprintf(r.env.Stderr, "模型: %s，输入/help查看命令\n", r.transcript.Settings.Model)
+	var pending []string
+	for {
+		if len(pending) == 0 {
+			fmt.Fprint(r.env.Stderr, "> ")
+		} else {
+			fmt.Fprint(r.env.Stderr, ". ")
+		}
+
+		var line string
+		select {
+		case line = <-lines:
+		case err := <-readErr:
+			fmt.Fprintln(r.env.Stderr)
+			return err
+		case <-interrupts:
+			fmt.Fprintln(r.env.Stderr)
+			return nil
+		}
+
+		if strings.HasSuffix(line, `\`) {
+			pending = append(pending, strings.TrimSuffix(line, `\`))
+			continue
+		}
+		input := strings.Join(append(pending, line), "\n")
+		pending = nil
+
+		trimmed := strings.TrimSpace(input)
+		switch {
+		case trimmed == "":
+			continue
+		case strings.HasPrefix(trimmed, "/"):
+			quit, err := r.command(trimmed)
+			if err != nil {
+				fmt.Fprintf(r.env.Stderr, "错误: %v\n", err)
+			}
+			if quit {
+				return nil
+			}
+		default:
+			if err := r.send(input, interrupts); err != nil {
+				fmt.Fprintf(r.env.Stderr, "错误: %v\n", err)
+			}
+		}
+	}
+}
+
+// 发送一轮对话，失败或被中断时不记入历史
+func (r *repl) send(input string, interrupts <-chan os.Signal) error {
+	t := r.transcript
+	t.Turns = append(t.Turns, Turn{Role: "user", Content: input})
+	req := &siliconproxy.ChatCompletionRequest{
+		Model:     t.Settings.Model,
+		Messages:  t.Messages(),
+		TopP:      t.Settings.TopP,
+		MaxTokens: t.Settings.MaxTokens,
+	}
+	if t.Settings.Temperature != 0 {
+		req.Temperature = siliconproxy.Float64(t.Settings.Temperature)
+	}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-interrupts:
+			close(stop)
+		case <-done:
+		}
+	}()
+
+	result, err := streamChat(r.env, r.sp, req, r.reasoning, stop)
+	if err != nil {
+		t.Turns = t.Turns[:len(t.Turns)-1]
+		return err
+	}
+
+	usage, estimated := result.Usage, false
+	if usage == nil {
+		// 上游未返回用量时按文本长度估算
+		estimated = true
+		usage = &siliconproxy.ChatCompletionUsage{CompletionTokens: siliconproxy.EstimateTokens(result.Content + result.Reasoning)}
+		for _, m := range req.Messages {
+			usage.PromptTokens += siliconproxy.EstimateTokens(m.Content)
+		}
+		usage.TotalTokens = usage.PromptTokens + usage.CompletionTokens
+	}
+	cost, priced := r.costOf(t.Settings.Model, usage)
+	t.Turns = append(t.Turns, Turn{
+		Role:      "assistant",
+		Content:   result.Content,
+		Reasoning: result.Reasoning,
+		Model:     t.Settings.Model,
+		Usage:     usage,
+		Cost:      cost,
+	})
+	r.promptTokens += usage.PromptTokens
+	r.completionTokens += usage.CompletionTokens
+	r.cost += cost
+
+	summary := fmt.Sprintf("tokens: 输入%d 输出%d 合计%d", usage.PromptTokens, usage.CompletionTokens, usage.TotalTokens)
+	if estimated {
+		summary = "估算" + summary
+	}
+	if priced {
+		summary += fmt.Sprintf("  费用: ¥%.6f  累计: ¥%.6f", cost, r.cost)
+	}
+	fmt.Fprintf(r.env.Stderr, "[%s]\n", summary)
+	return nil
+}
+
+// 按能力文件中的价格计算费用，模型没有价格信息时返回false
+func (r *repl) costOf(model string, usage *siliconproxy.ChatCompletionUsage) (float64, bool) {
+	caps, ok := r.prices[model]
+	if !ok || (caps.InputPrice == 0 && caps.OutputPrice == 0) {
+		return 0, false
+	}
+	return (float64(usage.PromptTokens)*caps.InputPrice + float64(usage.CompletionTokens)*caps.OutputPrice) / 1e6, true
+}
+
+// 执行斜杠命令，返回是否退出
+func (r *repl) command(line string) (bool, error) {
+	name, arg, _ := strings.Cut(line, " ")
+	arg = strings.TrimSpace(arg)
+	s := &r.transcript.Settings
+	out := r.env.Stderr
+
+	switch name {
+	case "/help", "/?":
+		fmt.Fprintln(out, replHelp)
+	case "/exit", "/quit":
+		return true, nil
+	case "/model":
+		if arg != "" {
+			s.Model = arg
+		}
+		fmt.Fprintf(out, "模型: %s\n", s.Model)
+	case "/system":
+		switch arg {
+		case "":
+		case "-":
+			s.System = ""
+		default:
+			s.System = arg
+		}
+		fmt.Fprintf(out, "系统提示词: %s\n", s.System)
+	case "/temperature", "/top_p":
+		if arg != "" {
+			v, err := strconv.ParseFloat(arg, 64)
+			if err != nil || v < 0 {
+				return false, fmt.Errorf("无效的数值: %s", arg)
+			}
+			if name == "/temperature" {
+				s.Temperature = v
+			} else {
+				s.TopP = v
+			}
+		}
+		fmt.Fprintf(out, "temperature: %g  top_p: %g\n", s.Temperature, s.TopP)
+	case "/max_tokens":
+		if arg != "" {
+			v, err := strconv.Atoi(arg)
+			if err != nil || v < 0 {
+				return false, fmt.Errorf("无效的数值: %s", arg)
+			}
+			s.MaxTokens = v
+		}
+		fmt.Fprintf(out, "max_tokens: %d\n", s.MaxTokens)
+	case "/reasoning":
+		switch arg {
+		case "":
+			r.reasoning = !r.reasoning
+		case "on":
+			r.reasoning = true
+		case "off":
+			r.reasoning = false
+		default:
+			return false, fmt.Errorf("用法: /reasoning [on|off]")
+		}
+		fmt.Fprintf(out, "显示reasoning_content: %v\n", r.reasoning)
+	case "/history":
+		for _, turn := range r.transcript.Turns {
+			fmt.Fprintf(out, "[%s] %s\n", turn.Role, turn.Content)
+		}
+	case "/usage":
+		fmt.Fprintf(out, "tokens: 输入%d 输出%d  费用: ¥%.6f\n", r.promptTokens, r.completionTokens, r.cost)
+	case "/clear":
+		r.transcript.Turns = nil
+		fmt.Fprintln(out, "已清空对话历史")
+	case "/save":
+		if arg == "" {
+			return false, errors.New("用法: /save <文件>")
+		}
+		if err := r.transcript.Save(arg); err != nil {
+			return false, err
+		}
+		fmt.Fprintf(out, "已保存到%s\n", arg)
+	case "/load":
+		if arg == "" {
+			return false, errors.New("用法: /load <文件>")
+		}
+		if err := r.load(arg); err != nil {
+			return false, err
+		}
+	default:
+		return false, fmt.Errorf("未知命令%s，输入/help查看命令", name)
+	}
+	return false, nil
+}
+
+// 加载对话记录，记录中没有模型时保留当前模型
+func (r *repl) load(path string) error {
+	t, err := LoadTranscript(path)
+	if err != nil {
+		return err
+	}
+	if t.Settings.Model == "" {
+		t.Settings.Model = r.transcript.Settings.Model
+	}
+	r.transcript = t
+	fmt.Fprintf(r.env.Stderr, "已加载%d条消息，模型: %s\n", len(t.Turns), t.Settings.Model)
+	return nil
+}
@@ -0,0 +1,228 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/kriswu/go_deepseek/siliconproxy"
+)
+
+// Settings 表示对话参数，零值表示使用模型默认值
+type Settings struct {
+	Model       string  `json:"model"`
+	System      string  `json:"system,omitempty"`
+	Temperature float64 `json:"temperature,omitempty"`
+	TopP        float64 `json:"top_p,omitempty"`
+	MaxTokens   int     `json:"max_tokens,omitempty"`
+}
+
+// Turn 表示对话记录中的一条消息，助手消息附带用量和费用
+type Turn struct {
+	Role      string                            `json:"role"`
+	Content   string                            `json:"content"`
+	Reasoning string                            `json:"reasoning_content,omitempty"`
+	Model     string                            `json:"model,omitempty"`
+	Usage     *siliconproxy.ChatCompletionUsage `json:"usage,omitempty"`
+	Cost      float64                           `json:"cost,omitempty"` // 元
+}
+
+// Transcript 表示多轮对话记录
+type Transcript struct {
+	Settings Settings `json:"settings"`
+	Turns    []Turn   `json:"turns"`
+}
+
+// Messages 返回发送给上游的消息列表，系统提示词在最前面
+func (t *Transcript) Messages() []siliconproxy.ChatCompletionMessage {
+	messages := make([]siliconproxy.ChatCompletionMessage, 0, len(t.Turns)+1)
+	if t.Settings.System != "" {
+		messages = append(messages, siliconproxy.ChatCompletionMessage{Role: "system", Content: t.Settings.System})
+	}
+	for _, turn := range t.Turns {
+		messages = append(messages, siliconproxy.ChatCompletionMessage{Role: turn.Role, Content: turn.Content})
+	}
+	return messages
+}
+
+// Save 保存对话记录，.md和.markdown文件保存为Markdown，其余保存为JSON
+func (t *Transcript) Save(path string) error {
+	var data []byte
+	if isMarkdown(path) {
+		data = []byte(t.Markdown())
+	} else {
+		var err error
+		if data, err = json.MarshalIndent(t, "", "  "); err != nil {
+			return fmt.Errorf("序列化对话记录失败: %w", err)
+		}
+		data = append(data, '\n')
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("写入对话记录失败: %w", err)
+	}
+	return nil
+}
+
+// LoadTranscript 读取Save保存的对话记录
+func LoadTranscript(path string) (*Transcript, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取对话记录失败: %w", err)
+	}
+	if isMarkdown(path) {
+		return parseMarkdown(string(data))
+	}
+	var t Transcript
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("解析对话记录失败: %w", err)
+	}
+	return &t, nil
+}
+
+func isMarkdown(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".md" || ext == ".markdown"
+}
+
+// Markdown 将对话记录格式化为Markdown
+// 参数以列表形式写在开头，每条消息是一个二级标题，思考过程放在引用块中
+func (t *Transcript) Markdown() string {
+	var b strings.Builder
+	b.WriteString("# 对话记录\n\n")
+	fmt.Fprintf(&b, "- model: %s\n", t.Settings.Model)
+	if t.Settings.Temperature != 0 {
+		fmt.Fprintf(&b, "- temperature: %g\n", t.Settings.Temperature)
+	}
+	if t.Settings.TopP != 0 {
+		fmt.Fprintf(&b, "- top_p: %g\n", t.Settings.TopP)
+	}
+	if t.Settings.MaxTokens != 0 {
+		fmt.Fprintf(&b, "- max_tokens: %d\n", t.Settings.MaxTokens)
+	}
+	if t.Settings.System != "" {
+		fmt.Fprintf(&b, "\n## system\n\n%s\n", t.Settings.System)
+	}
+	for _, turn := range t.Turns {
+		fmt.Fprintf(&b, "\n## %s\n\n", turn.Role)
+		if turn.Reasoning != "" {
+			for _, line := range strings.Split(turn.Reasoning, "\n") {
+				fmt.Fprintf(&b, "> %s\n", line)
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString(turn.Content)
+		b.WriteString("\n")
+		if turn.Usage != nil {
+			fmt.Fprintf(&b, "\n<!-- model: %s, prompt_tokens: %d, completion_tokens: %d, cost: %g -->\n",
+				turn.Model, turn.Usage.PromptTokens, turn.Usage.CompletionTokens, turn.Cost)
+		}
+	}
+	return b.String()
+}
+
+// 解析Markdown格式的对话记录，与Markdown方法的输出对应
+func parseMarkdown(text string) (*Transcript, error) {
+	t := &Transcript{}
+	var current *Turn
+	var body []string
+	var quoted []string
+
+	flush := func() {
+		if current == nil {
+			return
+		}
+		current.Content = strings.TrimSpace(strings.Join(body, "\n"))
+		current.Reasoning = strings.TrimSpace(strings.Join(quoted, "\n"))
+		if current.Role == "system" {
+			t.Settings.System = current.Content
+		} else {
+			t.Turns = append(t.Turns, *current)
+		}
+		current, body, quoted = nil, nil, nil
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	scanner.Buffer(make([]byte, 64*1024), 16<<20)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "## "):
+			flush()
+			role := strings.TrimSpace(strings.TrimPrefix(line, "## "))
+			switch role {
+			case "system", "user", "assistant":
+			default:
+				return nil, fmt.Errorf("第%d行: 未知的消息角色%q", lineNo, role)
+			}
+			current = &Turn{Role: role}
+		case current == nil:
+			if err := parseSetting(&t.Settings, line); err != nil {
+				return nil, fmt.Errorf("第%d行: %w", lineNo, err)
+			}
+		case strings.HasPrefix(line, "<!-- ") && strings.HasSuffix(line, " -->"):
+			parseTurnMeta(current, strings.TrimSuffix(strings.TrimPrefix(line, "<!-- "), " -->"))
+		case len(body) == 0 && strings.TrimSpace(line) == "":
+			// 标题、思考过程与正文之间的空行
+		case len(body) == 0 && current.Role == "assistant" && (line == ">" || strings.HasPrefix(line, "> ")):
+			quoted = append(quoted, strings.TrimPrefix(strings.TrimPrefix(line, ">"), " "))
+		default:
+			body = append(body, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("读取对话记录失败: %w", err)
+	}
+	flush()
+	return t, nil
+}
+
+// 解析开头的"- key: value"参数行
+func parseSetting(s *Settings, line string) error {
+	if !strings.HasPrefix(line, "- ") {
+		return nil
+	}
+	key, value, ok := strings.Cut(strings.TrimPrefix(line, "- "), ":")
+	if !ok {
+		return nil
+	}
+	value = strings.TrimSpace(value)
+	var err error
+	switch strings.TrimSpace(key) {
+	case "model":
+		s.Model = value
+	case "temperature":
+		s.Temperature, err = strconv.ParseFloat(value, 64)
+	case "top_p":
+		s.TopP, err = strconv.ParseFloat(value, 64)
+	case "max_tokens":
+		s.MaxTokens, err = strconv.Atoi(value)
+	}
+	if err != nil {
+		return fmt.Errorf("参数%s无效: %w", key, err)
+	}
+	return nil
+}
+
+// 解析助手消息后的注释，格式为"key: value, key: value"，无法识别的字段忽略
+func parseTurnMeta(turn *Turn, meta string) {
+	usage := &siliconproxy.ChatCompletionUsage{}
+	for _, field := range strings.Split(meta, ", ") {
+		key, value, _ := strings.Cut(field, ": ")
+		switch key {
+		case "model":
+			turn.Model = value
+		case "prompt_tokens":
+			usage.PromptTokens, _ = strconv.Atoi(value)
+		case "completion_tokens":
+			usage.CompletionTokens, _ = strconv.Atoi(value)
+		case "cost":
+			turn.Cost, _ = strconv.ParseFloat(value, 64)
+		}
+	}
+	usage.TotalTokens = usage.PromptTokens + usage.CompletionTokens
+	turn.Usage = usage
+}
@@ -0,0 +1,364 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// DefaultGRPCAddr 是默认的gRPC监听地址
+const DefaultGRPCAddr = ":50051"
+
+// Config 表示服务器的完整配置
+// 优先级从低到高依次为：默认值、配置文件、环境变量、命令行参数
+type Config struct {
+	Upstream UpstreamConfig `yaml:"upstream" json:"upstream"`
+	Listen   ListenConfig   `yaml:"listen" json:"listen"`
+	Limits   LimitsConfig   `yaml:"limits" json:"limits"`
+	Shutdown ShutdownConfig `yaml:"shutdown" json:"shutdown"`
+	Admin    AdminConfig    `yaml:"admin" json:"admin"`
+
+	// 租户列表，为空时所有请求使用上游默认令牌
+	Tenants []TenantConfig `yaml:"tenants,omitempty" json:"tenants,omitempty"`
+	// 为true时拒绝未携带租户密钥的请求
+	RequireTenant bool `yaml:"require_tenant,omitempty" json:"require_tenant,omitempty"`
+
+	// 响应缓存配置，为空时不启用
+	Cache *CacheConfig `yaml:"cache,omitempty" json:"cache,omitempty"`
+	// 语义缓存配置，为空时不启用
+	SemanticCache *SemanticCacheConfig `yaml:"semantic_cache,omitempty" json:"semantic_cache,omitempty"`
+	// 文档检索配置，为空时不启用
+	Retrieval *RetrievalConfig `yaml:"retrieval,omitempty" json:"retrieval,omitempty"`
+	// 音色注册表文件路径，为空时不启用音色管理
+	VoiceRegistry string `yaml:"voice_registry,omitempty" json:"voice_registry,omitempty"`
+	// 模型目录配置，为空时不启用
+	ModelCatalog *ModelCatalogConfig `yaml:"model_catalog,omitempty" json:"model_catalog,omitempty"`
+	// 视频任务配置，为空时不启用视频任务接口
+	VideoJobs *VideoJobsConfig `yaml:"video_jobs,omitempty" json:"video_jobs,omitempty"`
+	// 提示词模板配置，为空时只提供内置模板
+	PromptTemplates *PromptTemplatesConfig `yaml:"prompt_templates,omitempty" json:"prompt_templates,omitempty"`
+	// 余额监控配置，为空时不启用
+	BalanceMonitor *BalanceMonitorConfig `yaml:"balance_monitor,omitempty" json:"balance_monitor,omitempty"`
+	// 上游健康探测配置，为空时健康状态不随上游变化
+	HealthProbe *HealthProbeConfig `yaml:"health_probe,omitempty" json:"health_probe,omitempty"`
+}
+
+// UpstreamConfig 表示上游API配置
+// 令牌不建议直接写在配置文件中，应使用SILICON_TOKEN环境变量或token_file
+type UpstreamConfig struct {
+	BaseURL   string `yaml:"base_url,omitempty" json:"base_url,omitempty"`
+	Token     string `yaml:"token,omitempty" json:"token,omitempty"`
+	TokenFile string `yaml:"token_file,omitempty" json:"token_file,omitempty"`
+	// 请求超时，包含流式响应的读取时间，0表示使用HTTP客户端默认值
+	TimeoutSeconds int `yaml:"timeout_seconds,omitempty" json:"timeout_seconds,omitempty"`
+}
+
+// ListenConfig 表示监听地址配置
+type ListenConfig struct {
+	// gRPC监听地址，如":50051"
+	GRPC string `yaml:"grpc,omitempty" json:"grpc,omitempty"`
+	// HTTP/JSON网关监听地址，如":8080"，为空时不启用
+	HTTP string `yaml:"http,omitempty" json:"http,omitempty"`
+	// 指标HTTP监听地址，如":9090"，指标位于/debug/vars，为空时不启用
+	Metrics string `yaml:"metrics,omitempty" json:"metrics,omitempty"`
+	// gRPC监听的TLS配置，为空时使用明文
+	TLS *TLSConfig `yaml:"tls,omitempty" json:"tls,omitempty"`
+}
+
+// TLSConfig 表示TLS配置，证书文件变化时自动重新加载
+type TLSConfig struct {
+	CertFile string `yaml:"cert_file" json:"cert_file"`
+	KeyFile  string `yaml:"key_file" json:"key_file"`
+	// 客户端CA，配置后启用双向TLS，客户端证书可用于识别租户
+	ClientCAFile      string `yaml:"client_ca_file,omitempty" json:"client_ca_file,omitempty"`
+	RequireClientCert bool   `yaml:"require_client_cert,omitempty" json:"require_client_cert,omitempty"`
+}
+
+// LimitsConfig 表示gRPC服务的资源限制，0表示使用gRPC默认值
+type LimitsConfig struct {
+	MaxRecvMsgBytes      int    `yaml:"max_recv_msg_bytes,omitempty" json:"max_recv_msg_bytes,omitempty"`
+	MaxSendMsgBytes      int    `yaml:"max_send_msg_bytes,omitempty" json:"max_send_msg_bytes,omitempty"`
+	MaxConcurrentStreams uint32 `yaml:"max_concurrent_streams,omitempty" json:"max_concurrent_streams,omitempty"`
+}
+
+// ShutdownConfig 表示优雅退出配置
+type ShutdownConfig struct {
+	// 等待进行中的请求完成的最长时间，超时后强制关闭连接
+	DrainTimeoutSeconds int `yaml:"drain_timeout_seconds,omitempty" json:"drain_timeout_seconds,omitempty"`
+}
+
+// AdminConfig 表示运维调试服务配置，默认全部关闭
+type AdminConfig struct {
+	Reflection bool `yaml:"reflection,omitempty" json:"reflection,omitempty"` // gRPC服务反射，供grpcurl等工具使用
+	Channelz   bool `yaml:"channelz,omitempty" json:"channelz,omitempty"`     // channelz连接状态查询
+}
+
+// HealthProbeConfig 表示上游健康探测配置
+type HealthProbeConfig struct {
+	IntervalSeconds  int `yaml:"interval_seconds,omitempty" json:"interval_seconds,omitempty"`
+	FailureThreshold int `yaml:"failure_threshold,omitempty" json:"failure_threshold,omitempty"`
+}
+
+// TenantConfig 表示一个租户
+// 客户端通过authorization: Bearer <api_key>或双向TLS的客户端证书标识租户
+// 未配置上游令牌时使用upstream中的默认令牌
+type TenantConfig struct {
+	Name       string `yaml:"name" json:"name"`
+	APIKey     string `yaml:"api_key,omitempty" json:"api_key,omitempty"`
+	APIKeyFile string `yaml:"api_key_file,omitempty" json:"api_key_file,omitempty"`
+	// 客户端证书主题，匹配CN或完整DN（如"CN=team-a,O=example"）
+	CertSubjects      []string `yaml:"cert_subjects,omitempty" json:"cert_subjects,omitempty"`
+	UpstreamToken     string   `yaml:"upstream_token,omitempty" json:"upstream_token,omitempty"`
+	UpstreamTokenFile string   `yaml:"upstream_token_file,omitempty" json:"upstream_token_file,omitempty"`
+}
+
+// CacheConfig 表示响应缓存配置
+type CacheConfig struct {
+	Backend    string `yaml:"backend" json:"backend"` // memory 或 disk
+	Capacity   int    `yaml:"capacity,omitempty" json:"capacity,omitempty"`
+	Dir        string `yaml:"dir,omitempty" json:"dir,omitempty"`
+	TTLSeconds int    `yaml:"ttl_seconds,omitempty" json:"ttl_seconds,omitempty"`
+}
+
+// SemanticCacheConfig 表示语义缓存配置
+type SemanticCacheConfig struct {
+	EmbeddingModel string  `yaml:"embedding_model" json:"embedding_model"`
+	Threshold      float64 `yaml:"threshold,omitempty" json:"threshold,omitempty"`
+	TTLSeconds     int     `yaml:"ttl_seconds,omitempty" json:"ttl_seconds,omitempty"`
+	MaxEntries     int     `yaml:"max_entries,omitempty" json:"max_entries,omitempty"` // 为0时使用默认值
+}
+
+// RetrievalConfig 表示文档检索配置
+type RetrievalConfig struct {
+	Dir            string `yaml:"dir" json:"dir"`
+	EmbeddingModel string `yaml:"embedding_model" json:"embedding_model"`
+	RerankModel    string `yaml:"rerank_model,omitempty" json:"rerank_model,omitempty"`
+	IndexType      string `yaml:"index_type,omitempty" json:"index_type,omitempty"` // flat 或 hnsw
+	ChunkSize      int    `yaml:"chunk_size,omitempty" json:"chunk_size,omitempty"`
+	ChunkOverlap   int    `yaml:"chunk_overlap,omitempty" json:"chunk_overlap,omitempty"`
+}
+
+// ModelCatalogConfig 表示模型目录配置
+type ModelCatalogConfig struct {
+	CapabilitiesFile       string `yaml:"capabilities_file,omitempty" json:"capabilities_file,omitempty"`
+	RefreshIntervalSeconds int    `yaml:"refresh_interval_seconds,omitempty" json:"refresh_interval_seconds,omitempty"`
+}
+
+// VideoJobsConfig 表示视频任务配置
+type VideoJobsConfig struct {
+	// 任务状态目录，重启后恢复未结束的任务
+	Dir string `yaml:"dir" json:"dir"`
+	// 非空时在任务成功后把视频下载到该目录
+	DownloadDir      string `yaml:"download_dir,omitempty" json:"download_dir,omitempty"`
+	MaxDownloadBytes int64  `yaml:"max_download_bytes,omitempty" json:"max_download_bytes,omitempty"`
+	// 首次轮询间隔，之后按1.5倍退避，默认5秒
+	PollIntervalSeconds int `yaml:"poll_interval_seconds,omitempty" json:"poll_interval_seconds,omitempty"`
+	// 任务超时时间，默认30分钟
+	TimeoutSeconds int `yaml:"timeout_seconds,omitempty" json:"timeout_seconds,omitempty"`
+	// 允许的webhook主机名，为空时拒绝带webhook的任务，解析到内网地址的主机同样会被拒绝
+	WebhookAllowedHosts []string `yaml:"webhook_allowed_hosts,omitempty" json:"webhook_allowed_hosts,omitempty"`
+}
+
+// PromptTemplatesConfig 表示提示词模板配置
+type PromptTemplatesConfig struct {
+	// 模板目录，其中每个YAML或JSON文件定义一个模板版本
+	Dir string `yaml:"dir,omitempty" json:"dir,omitempty"`
+	// 固定版本，键为模板名称，未指定版本的请求使用固定版本而不是最新版本
+	Pins map[string]string `yaml:"pins,omitempty" json:"pins,omitempty"`
+}
+
+// Settings 返回模板目录和固定版本，p为nil时均为空
+func (p *PromptTemplatesConfig) Settings() (dir string, pins map[string]string) {
+	if p == nil {
+		return "", nil
+	}
+	return p.Dir, p.Pins
+}
+
+// BalanceMonitorConfig 表示余额监控配置
+type BalanceMonitorConfig struct {
+	Keys            []BalanceKeyConfig `yaml:"keys,omitempty" json:"keys,omitempty"` // 为空时监控上游默认令牌
+	IntervalSeconds int                `yaml:"interval_seconds,omitempty" json:"interval_seconds,omitempty"`
+	Threshold       float64            `yaml:"threshold" json:"threshold"`
+	Webhook         string             `yaml:"webhook,omitempty" json:"webhook,omitempty"`
+}
+
+// BalanceKeyConfig 表示被监控的密钥
+type BalanceKeyConfig struct {
+	Name      string `yaml:"name" json:"name"`
+	Token     string `yaml:"token,omitempty" json:"token,omitempty"`
+	TokenFile string `yaml:"token_file,omitempty" json:"token_file,omitempty"`
+}
+
+// Default 返回默认配置
+func Default() *Config {
+	return &Config{
+		Upstream: UpstreamConfig{
+			BaseURL: "https://api.siliconflow.cn/v1",
+		},
+		Listen:   ListenConfig{GRPC: DefaultGRPCAddr},
+		Shutdown: ShutdownConfig{DrainTimeoutSeconds: 30},
+	}
+}
+
+// Validate 检查配置，返回包含全部问题的错误
+// 应在解析密钥文件之后调用
+func (c *Config) Validate() error {
+	var errs []error
+	add := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	if u, err := url.Parse(c.Upstream.BaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		add("upstream.base_url必须是http或https地址: %q", c.Upstream.BaseURL)
+	}
+	if c.Upstream.Token == "" {
+		add("未配置上游令牌，请设置SILICON_TOKEN、SILICON_TOKEN_FILE或upstream.token_file")
+	}
+	if c.Upstream.TimeoutSeconds < 0 {
+		add("upstream.timeout_seconds不能为负数")
+	}
+
+	if _, _, err := net.SplitHostPort(c.Listen.GRPC); err != nil {
+		add("listen.grpc地址无效: %q", c.Listen.GRPC)
+	}
+	if c.Listen.HTTP != "" {
+		if _, _, err := net.SplitHostPort(c.Listen.HTTP); err != nil {
+			add("listen.http地址无效: %q", c.Listen.HTTP)
+		}
+	}
+	if c.Listen.Metrics != "" {
+		if _, _, err := net.SplitHostPort(c.Listen.Metrics); err != nil {
+			add("listen.metrics地址无效: %q", c.Listen.Metrics)
+		}
+	}
+	if c.Shutdown.DrainTimeoutSeconds < 0 {
+		add("shutdown.drain_timeout_seconds不能为负数")
+	}
+	if c.Limits.MaxRecvMsgBytes < 0 || c.Limits.MaxSendMsgBytes < 0 {
+		add("limits中的消息大小不能为负数")
+	}
+
+	if t := c.Listen.TLS; t != nil {
+		if t.CertFile == "" || t.KeyFile == "" {
+			add("listen.tls必须同时配置cert_file和key_file")
+		}
+		if t.RequireClientCert && t.ClientCAFile == "" {
+			add("启用require_client_cert时必须配置listen.tls.client_ca_file")
+		}
+	}
+	mtls := c.Listen.TLS != nil && c.Listen.TLS.ClientCAFile != ""
+
+	names := make(map[string]bool)
+	keys := make(map[string]bool)
+	subjects := make(map[string]bool)
+	for i, t := range c.Tenants {
+		if t.Name == "" {
+			add("tenants[%d]缺少name", i)
+		} else if names[t.Name] {
+			add("租户名称重复: %s", t.Name)
+		}
+		names[t.Name] = true
+		if t.APIKey == "" && len(t.CertSubjects) == 0 {
+			add("租户%s缺少api_key、api_key_file或cert_subjects", t.Name)
+		}
+		if t.APIKey != "" {
+			if keys[t.APIKey] {
+				add("租户%s的api_key与其他租户重复", t.Name)
+			}
+			keys[t.APIKey] = true
+		}
+		if len(t.CertSubjects) > 0 && !mtls {
+			add("租户%s配置了cert_subjects，但未配置listen.tls.client_ca_file", t.Name)
+		}
+		for _, subject := range t.CertSubjects {
+			if subjects[subject] {
+				add("证书主题%s对应多个租户", subject)
+			}
+			subjects[subject] = true
+		}
+	}
+	if c.RequireTenant && len(c.Tenants) == 0 {
+		add("启用require_tenant时必须配置租户")
+	}
+
+	if cc := c.Cache; cc != nil {
+		switch cc.Backend {
+		case "", "memory":
+		case "disk":
+			if cc.Dir == "" {
+				add("磁盘缓存必须配置cache.dir")
+			}
+		default:
+			add("不支持的缓存类型: %s", cc.Backend)
+		}
+	}
+	if sc := c.SemanticCache; sc != nil {
+		if sc.EmbeddingModel == "" {
+			add("semantic_cache.embedding_model不能为空")
+		}
+		if sc.Threshold < 0 || sc.Threshold > 1 {
+			add("semantic_cache.threshold必须在0到1之间")
+		}
+		if sc.MaxEntries < 0 {
+			add("semantic_cache.max_entries不能为负数")
+		}
+	}
+	if rc := c.Retrieval; rc != nil {
+		if rc.Dir == "" || rc.EmbeddingModel == "" {
+			add("retrieval.dir和retrieval.embedding_model不能为空")
+		}
+		switch rc.IndexType {
+		case "", "flat", "hnsw":
+		default:
+			add("不支持的索引类型: %s", rc.IndexType)
+		}
+		if rc.ChunkOverlap < 0 || (rc.ChunkSize > 0 && rc.ChunkOverlap >= rc.ChunkSize) {
+			add("retrieval.chunk_overlap必须小于chunk_size")
+		}
+	}
+	if vj := c.VideoJobs; vj != nil {
+		if vj.Dir == "" {
+			add("video_jobs.dir不能为空")
+		}
+		if vj.MaxDownloadBytes < 0 || vj.PollIntervalSeconds < 0 || vj.TimeoutSeconds < 0 {
+			add("video_jobs中的参数不能为负数")
+		}
+		for i, host := range vj.WebhookAllowedHosts {
+			if strings.TrimSpace(host) == "" || strings.ContainsAny(host, "/:") {
+				add("video_jobs.webhook_allowed_hosts[%d]必须是主机名: %q", i, host)
+			}
+		}
+	}
+	if pt := c.PromptTemplates; pt != nil {
+		for name, version := range pt.Pins {
+			if version == "" {
+				add("prompt_templates.pins中模板%s的版本不能为空", name)
+			}
+		}
+	}
+	if hp := c.HealthProbe; hp != nil && (hp.IntervalSeconds < 0 || hp.FailureThreshold < 0) {
+		add("health_probe中的参数不能为负数")
+	}
+	if bm := c.BalanceMonitor; bm != nil {
+		if bm.Threshold < 0 {
+			add("balance_monitor.threshold不能为负数")
+		}
+		for i, k := range bm.Keys {
+			if k.Name == "" || k.Token == "" {
+				add("balance_monitor.keys[%d]缺少name或令牌", i)
+			}
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	return errors.New("配置无效:\n  " + strings.Join(msgs, "\n  "))
+}
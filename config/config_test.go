@@ -0,0 +1,288 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// 清除可能影响测试的环境变量
+func clearEnv(t *testing.T) {
+	t.Helper()
+	for _, name := range []string{EnvConfig, EnvBaseURL, EnvToken, EnvTokenFile, EnvGRPCAddr, EnvMetricsAddr} {
+		t.Setenv(name, "")
+	}
+}
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	tokenFile := writeFile(t, dir, "token", "  file-token\n")
+	yamlFile := writeFile(t, dir, "server.yaml", `
+upstream:
+  base_url: https://yaml.example.com/v1
+  token: yaml-token
+listen:
+  grpc: ":6000"
+`)
+	jsonFile := writeFile(t, dir, "server.json", `{"upstream": {"token": "json-token"}, "listen": {"grpc": ":7000"}}`)
+	unknownFile := writeFile(t, dir, "unknown.yaml", "upstream:\n  tokn: x\n")
+	legacyFile := writeFile(t, dir, "server.conf", `{"url": "https://legacy.example.com/v1/models", "token": "legacy-token", "grpc_port": 50052}`)
+	mixedFile := writeFile(t, dir, "mixed.conf", `{"token": "legacy-token", "listen": {"metrics": ":9100"}}`)
+	unknownLegacyFile := writeFile(t, dir, "unknown.conf", `{"token": "legacy-token", "grpc_prot": 1}`)
+	emptyFile := writeFile(t, dir, "empty.yaml", "")
+
+	tests := []struct {
+		name     string
+		args     []string
+		env      map[string]string
+		wantErr  string
+		check    func(c *Config) bool
+		describe string
+	}{
+		{
+			name:     "YAML文件",
+			args:     []string{"-config", yamlFile},
+			check:    func(c *Config) bool { return c.Upstream.Token == "yaml-token" && c.Listen.GRPC == ":6000" },
+			describe: "使用文件中的令牌和地址",
+		},
+		{
+			name: "JSON文件并保留默认值",
+			args: []string{"-config", jsonFile},
+			check: func(c *Config) bool {
+				return c.Listen.GRPC == ":7000" && c.Upstream.BaseURL == "https://api.siliconflow.cn/v1"
+			},
+			describe: "未配置的字段使用默认值",
+		},
+		{
+			name: "旧版conf文件",
+			args: []string{"-config", legacyFile},
+			check: func(c *Config) bool {
+				return c.Upstream.Token == "legacy-token" && c.Listen.GRPC == ":50052" &&
+					c.Upstream.BaseURL == "https://legacy.example.com/v1"
+			},
+			describe: "旧版字段映射到新配置项",
+		},
+		{
+			name:     "旧版字段与新字段混用",
+			args:     []string{"-config", mixedFile},
+			check:    func(c *Config) bool { return c.Upstream.Token == "legacy-token" && c.Listen.Metrics == ":9100" },
+			describe: "同时读取旧版和新版字段",
+		},
+		{
+			name:    "旧版文件中的未知字段",
+			args:    []string{"-config", unknownLegacyFile},
+			wantErr: "grpc_prot",
+		},
+		{
+			name:     "环境变量覆盖文件",
+			args:     []string{"-config", yamlFile},
+			env:      map[string]string{EnvToken: "env-token", EnvGRPCAddr: ":6100"},
+			check:    func(c *Config) bool { return c.Upstream.Token == "env-token" && c.Listen.GRPC == ":6100" },
+			describe: "使用环境变量中的令牌和地址",
+		},
+		{
+			name:     "命令行参数覆盖环境变量",
+			args:     []string{"-config", yamlFile, "-grpc-addr", ":6200", "-token-file", tokenFile},
+			env:      map[string]string{EnvToken: "env-token", EnvGRPCAddr: ":6100"},
+			check:    func(c *Config) bool { return c.Upstream.Token == "file-token" && c.Listen.GRPC == ":6200" },
+			describe: "使用参数中的地址和令牌文件，令牌去掉空白",
+		},
+		{
+			name:     "环境变量指定配置文件",
+			env:      map[string]string{EnvConfig: jsonFile},
+			check:    func(c *Config) bool { return c.Upstream.Token == "json-token" },
+			describe: "读取SILICON_CONFIG指定的文件",
+		},
+		{
+			// 测试在包目录中运行，其中没有conf/server.yaml
+			name:     "默认配置文件不存在时跳过",
+			env:      map[string]string{EnvToken: "env-token"},
+			check:    func(c *Config) bool { return c.Listen.GRPC == DefaultGRPCAddr },
+			describe: "使用默认地址",
+		},
+		{
+			name:     "空文件",
+			args:     []string{"-config", emptyFile},
+			env:      map[string]string{EnvToken: "env-token"},
+			check:    func(c *Config) bool { return c.Listen.GRPC == DefaultGRPCAddr },
+			describe: "使用默认地址",
+		},
+		{
+			name:    "指定的配置文件不存在",
+			args:    []string{"-config", filepath.Join(dir, "missing.yaml")},
+			wantErr: "读取配置文件失败",
+		},
+		{
+			name:    "未知字段",
+			args:    []string{"-config", unknownFile},
+			wantErr: "tokn",
+		},
+		{
+			name:    "令牌文件不存在",
+			args:    []string{"-config", yamlFile, "-token-file", filepath.Join(dir, "missing")},
+			wantErr: "读取密钥文件失败",
+		},
+		{
+			name:    "校验失败",
+			args:    []string{"-config", emptyFile},
+			wantErr: "未配置上游令牌",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t)
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			l, err := NewLoader("test", tt.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			c, err := l.Load()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("错误为%v，期望包含%q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tt.check(c) {
+				t.Fatalf("期望%s，实际配置 %+v", tt.describe, c)
+			}
+		})
+	}
+}
+
+func TestNewLoaderRejectsArgs(t *testing.T) {
+	if _, err := NewLoader("test", []string{"extra"}); err == nil {
+		t.Fatal("多余的参数应返回错误")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := func() *Config {
+		c := Default()
+		c.Upstream.Token = "t"
+		return c
+	}
+
+	tests := []struct {
+		name   string
+		modify func(c *Config)
+		want   []string // 错误信息应包含的全部片段，为空表示有效
+	}{
+		{name: "默认配置加令牌", modify: func(c *Config) {}},
+		{
+			name:   "上游地址无效",
+			modify: func(c *Config) { c.Upstream.BaseURL = "ftp://x" },
+			want:   []string{"upstream.base_url"},
+		},
+		{
+			name:   "监听地址无效",
+			modify: func(c *Config) { c.Listen.GRPC = "50051"; c.Listen.HTTP = "x" },
+			want:   []string{"listen.grpc", "listen.http"},
+		},
+		{
+			name:   "报告全部问题",
+			modify: func(c *Config) { c.Upstream.Token = ""; c.Shutdown.DrainTimeoutSeconds = -1 },
+			want:   []string{"未配置上游令牌", "drain_timeout_seconds"},
+		},
+		{
+			name: "TLS缺少文件",
+			modify: func(c *Config) {
+				c.Listen.TLS = &TLSConfig{CertFile: "c", RequireClientCert: true}
+			},
+			want: []string{"cert_file和key_file", "client_ca_file"},
+		},
+		{
+			name: "租户重复",
+			modify: func(c *Config) {
+				c.Tenants = []TenantConfig{{Name: "a", APIKey: "k"}, {Name: "a", APIKey: "k"}, {Name: "b"}}
+			},
+			want: []string{"租户名称重复", "api_key与其他租户重复", "租户b缺少"},
+		},
+		{
+			name: "证书主题需要双向TLS",
+			modify: func(c *Config) {
+				c.Tenants = []TenantConfig{{Name: "a", CertSubjects: []string{"CN=a"}}}
+			},
+			want: []string{"cert_subjects"},
+		},
+		{
+			name:   "require_tenant没有租户",
+			modify: func(c *Config) { c.RequireTenant = true },
+			want:   []string{"require_tenant"},
+		},
+		{
+			name:   "磁盘缓存缺少目录",
+			modify: func(c *Config) { c.Cache = &CacheConfig{Backend: "disk"} },
+			want:   []string{"cache.dir"},
+		},
+		{
+			name:   "不支持的缓存类型",
+			modify: func(c *Config) { c.Cache = &CacheConfig{Backend: "redis"} },
+			want:   []string{"redis"},
+		},
+		{
+			name: "语义缓存参数",
+			modify: func(c *Config) {
+				c.SemanticCache = &SemanticCacheConfig{Threshold: 2, MaxEntries: -1}
+			},
+			want: []string{"embedding_model", "threshold", "max_entries"},
+		},
+		{
+			name: "检索参数",
+			modify: func(c *Config) {
+				c.Retrieval = &RetrievalConfig{Dir: "d", EmbeddingModel: "m", IndexType: "ivf", ChunkSize: 100, ChunkOverlap: 100}
+			},
+			want: []string{"ivf", "chunk_overlap"},
+		},
+		{
+			name:   "视频任务缺少目录",
+			modify: func(c *Config) { c.VideoJobs = &VideoJobsConfig{TimeoutSeconds: -1} },
+			want:   []string{"video_jobs.dir", "video_jobs中的参数"},
+		},
+		{
+			name: "余额监控密钥",
+			modify: func(c *Config) {
+				c.BalanceMonitor = &BalanceMonitorConfig{Threshold: -1, Keys: []BalanceKeyConfig{{Name: "a"}}}
+			},
+			want: []string{"threshold", "keys[0]"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := valid()
+			tt.modify(c)
+			err := c.Validate()
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Fatalf("期望有效，错误为%v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("期望无效")
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("错误信息%q不包含%q", err, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+)
+
+// 旧版conf/server.conf的字段，只有url、token和grpc_port三项
+type legacyConfig struct {
+	URL      *string
+	Token    *string
+	GRPCPort *int
+}
+
+// 旧版字段到新配置项的对应关系，用于提示迁移
+var legacyKeys = map[string]string{
+	"url":       "upstream.base_url",
+	"token":     "upstream.token",
+	"grpc_port": "listen.grpc",
+}
+
+// 按JSON解析配置，兼容旧版的顶层url、token和grpc_port字段并打印弃用警告
+// 旧版字段可以与新字段混用，其余未知字段仍视为错误
+func decodeJSON(path string, data []byte, c *Config) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	var legacy legacyConfig
+	var found []string
+	for key, target := range map[string]any{"url": &legacy.URL, "token": &legacy.Token, "grpc_port": &legacy.GRPCPort} {
+		raw, ok := fields[key]
+		if !ok {
+			continue
+		}
+		if err := json.Unmarshal(raw, target); err != nil {
+			return fmt.Errorf("旧版字段%s: %w", key, err)
+		}
+		delete(fields, key)
+		found = append(found, key)
+	}
+	sort.Strings(found)
+
+	if len(found) > 0 {
+		rest, err := json.Marshal(fields)
+		if err != nil {
+			return err
+		}
+		data = rest
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(c); err != nil {
+		return err
+	}
+	if len(found) == 0 {
+		return nil
+	}
+
+	// 旧版url指向/models接口，新配置使用API基础URL
+	if legacy.URL != nil && *legacy.URL != "" {
+		c.Upstream.BaseURL = strings.TrimSuffix(strings.TrimRight(*legacy.URL, "/"), "/models")
+	}
+	if legacy.Token != nil && *legacy.Token != "" {
+		c.Upstream.Token = *legacy.Token
+	}
+	if legacy.GRPCPort != nil && *legacy.GRPCPort != 0 {
+		c.Listen.GRPC = fmt.Sprintf(":%d", *legacy.GRPCPort)
+	}
+	for _, key := range found {
+		log.Printf("配置文件%s使用了已弃用的字段%s，请改为%s", path, key, legacyKeys[key])
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultPath 是未指定--config时尝试读取的配置文件，文件不存在时跳过
+const DefaultPath = "conf/server.yaml"
+
+// 环境变量名
+const (
+	EnvConfig      = "SILICON_CONFIG"
+	EnvBaseURL     = "SILICON_BASE_URL"
+	EnvToken       = "SILICON_TOKEN"
+	EnvTokenFile   = "SILICON_TOKEN_FILE"
+	EnvGRPCAddr    = "SILICON_GRPC_ADDR"
+	EnvMetricsAddr = "SILICON_METRICS_ADDR"
+)
+
+// Loader 负责合并各来源的配置
+// 命令行参数只解析一次，Load可以重复调用以重新读取配置文件和环境变量
+type Loader struct {
+	path     string
+	explicit bool // 配置文件是否由用户指定，指定的文件不存在时报错
+	resolved bool
+
+	baseURL     string
+	tokenFile   string
+	grpcAddr    string
+	metricsAddr string
+}
+
+// NewLoader 解析命令行参数，args不包含程序名
+// 使用-h时返回flag.ErrHelp
+func NewLoader(name string, args []string) (*Loader, error) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	l := BindFlags(fs)
+	fs.StringVar(&l.grpcAddr, "grpc-addr", "", "gRPC监听地址")
+	fs.StringVar(&l.metricsAddr, "metrics-addr", "", "指标HTTP监听地址")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("未知参数: %s", strings.Join(fs.Args(), " "))
+	}
+	return l, nil
+}
+
+// BindFlags 在fs上注册配置文件和上游相关的参数，供命令行客户端等复用
+// 返回的Loader在fs解析完成后使用
+func BindFlags(fs *flag.FlagSet) *Loader {
+	l := &Loader{}
+	fs.StringVar(&l.path, "config", "", "配置文件路径，支持YAML和JSON，默认"+DefaultPath)
+	fs.StringVar(&l.baseURL, "base-url", "", "上游API基础URL")
+	fs.StringVar(&l.tokenFile, "token-file", "", "上游令牌文件路径")
+	return l
+}
+
+// 确定配置文件路径：命令行参数 > 环境变量 > 默认路径
+func (l *Loader) resolvePath() {
+	if l.resolved {
+		return
+	}
+	l.resolved = true
+	switch {
+	case l.path != "":
+		l.explicit = true
+	case os.Getenv(EnvConfig) != "":
+		l.path = os.Getenv(EnvConfig)
+		l.explicit = true
+	default:
+		l.path = DefaultPath
+	}
+}
+
+// Path 返回配置文件路径
+func (l *Loader) Path() string {
+	l.resolvePath()
+	return l.path
+}
+
+// Load 依次合并默认值、配置文件、环境变量和命令行参数，读取密钥文件并校验
+func (l *Loader) Load() (*Config, error) {
+	c := Default()
+
+	l.resolvePath()
+	if err := decodeFile(l.path, c); err != nil {
+		if l.explicit || !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	applyEnv(c)
+
+	if l.baseURL != "" {
+		c.Upstream.BaseURL = l.baseURL
+	}
+	if l.tokenFile != "" {
+		c.Upstream.Token = ""
+		c.Upstream.TokenFile = l.tokenFile
+	}
+	if l.grpcAddr != "" {
+		c.Listen.GRPC = l.grpcAddr
+	}
+	if l.metricsAddr != "" {
+		c.Listen.Metrics = l.metricsAddr
+	}
+
+	if err := c.resolveSecrets(); err != nil {
+		return nil, err
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// 读取配置文件，.json和.conf按JSON解析，其余按YAML解析
+// 未知字段视为错误，避免拼写错误被静默忽略；JSON文件兼容旧版server.conf的字段
+func decodeFile(path string, c *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("读取配置文件失败: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".conf":
+		err = decodeJSON(path, data, c)
+	default:
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(c)
+		if errors.Is(err, io.EOF) {
+			err = nil // 空文件
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("解析配置文件%s失败: %w", path, err)
+	}
+	return nil
+}
+
+// 应用环境变量
+func applyEnv(c *Config) {
+	if v := os.Getenv(EnvBaseURL); v != "" {
+		c.Upstream.BaseURL = v
+	}
+	if v := os.Getenv(EnvTokenFile); v != "" {
+		c.Upstream.Token = ""
+		c.Upstream.TokenFile = v
+	}
+	if v := os.Getenv(EnvToken); v != "" {
+		c.Upstream.Token = v
+		c.Upstream.TokenFile = ""
+	}
+	if v := os.Getenv(EnvGRPCAddr); v != "" {
+		c.Listen.GRPC = v
+	}
+	if v := os.Getenv(EnvMetricsAddr); v != "" {
+		c.Listen.Metrics = v
+	}
+}
+
+// 读取密钥文件，配置了文件时以文件内容为准
+func (c *Config) resolveSecrets() error {
+	if err := readSecret(&c.Upstream.Token, c.Upstream.TokenFile); err != nil {
+		return err
+	}
+	for i := range c.Tenants {
+		t := &c.Tenants[i]
+		if err := readSecret(&t.APIKey, t.APIKeyFile); err != nil {
+			return err
+		}
+		if err := readSecret(&t.UpstreamToken, t.UpstreamTokenFile); err != nil {
+			return err
+		}
+	}
+	if bm := c.BalanceMonitor; bm != nil {
+		for i := range bm.Keys {
+			if err := readSecret(&bm.Keys[i].Token, bm.Keys[i].TokenFile); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// 读取单个密钥文件，去掉首尾空白
+func readSecret(value *string, path string) error {
+	if path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("读取密钥文件失败: %w", err)
+	}
+	*value = strings.TrimSpace(string(data))
+	return nil
+}
+
+// SecretFiles 返回配置引用的全部密钥文件
+func (c *Config) SecretFiles() []string {
+	var files []string
+	add := func(path string) {
+		if path != "" {
+			files = append(files, path)
+		}
+	}
+	add(c.Upstream.TokenFile)
+	for _, t := range c.Tenants {
+		add(t.APIKeyFile)
+		add(t.UpstreamTokenFile)
+	}
+	if bm := c.BalanceMonitor; bm != nil {
+		for _, k := range bm.Keys {
+			add(k.TokenFile)
+		}
+	}
+	return files
+}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"crypto/sha256"
+	"os"
+	"sync"
+	"time"
+)
+
+// DefaultWatchInterval 是默认的文件检查间隔
+const DefaultWatchInterval = 2 * time.Second
+
+// Watcher 定期检查一组文件，内容变化时调用回调
+// 按内容而不是修改时间判断，能正确处理通过符号链接替换的挂载密钥
+type Watcher struct {
+	interval time.Duration
+	onChange func()
+
+	mu     sync.Mutex
+	hashes map[string][32]byte
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewWatcher 创建文件监视器，interval为0时使用默认间隔
+func NewWatcher(interval time.Duration, onChange func()) *Watcher {
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+	return &Watcher{
+		interval: interval,
+		onChange: onChange,
+		hashes:   make(map[string][32]byte),
+		stop:     make(chan struct{}),
+	}
+}
+
+// SetPaths 设置要监视的文件，以文件当前内容作为基准
+func (w *Watcher) SetPaths(paths []string) {
+	hashes := make(map[string][32]byte, len(paths))
+	for _, p := range paths {
+		hashes[p] = fileHash(p)
+	}
+	w.mu.Lock()
+	w.hashes = hashes
+	w.mu.Unlock()
+}
+
+// Start 在后台开始检查
+func (w *Watcher) Start() {
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-w.stop:
+				return
+			case <-ticker.C:
+				if w.changed() {
+					w.onChange()
+				}
+			}
+		}
+	}()
+}
+
+// Stop 停止检查并等待后台goroutine退出
+func (w *Watcher) Stop() {
+	close(w.stop)
+	w.wg.Wait()
+}
+
+// 检查文件是否有变化，并更新基准
+func (w *Watcher) changed() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	changed := false
+	for p, old := range w.hashes {
+		if h := fileHash(p); h != old {
+			w.hashes[p] = h
+			changed = true
+		}
+	}
+	return changed
+}
+
+// 计算文件内容哈希，读取失败时返回零值
+func fileHash(path string) [32]byte {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return [32]byte{}
+	}
+	return sha256.Sum256(data)
+}
@@ -0,0 +1,70 @@
+package eval
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Case 表示评测数据集中的一条用例
+// 期望字段按评分器使用：Expected用于exact_match、embedding_similarity和llm_judge，
+// Pattern用于regex，Schema用于json_schema，Rubric用于llm_judge；为空时使用评分器配置中的默认值
+type Case struct {
+	ID        string            `json:"id"`
+	Input     string            `json:"input"`               // 用户消息，使用模板时作为模板变量
+	Variables map[string]string `json:"variables,omitempty"` // 额外的模板变量
+	Expected  string            `json:"expected,omitempty"`
+	Pattern   string            `json:"pattern,omitempty"`
+	Schema    json.RawMessage   `json:"schema,omitempty"`
+	Rubric    string            `json:"rubric,omitempty"`
+	Tags      []string          `json:"tags,omitempty"`
+}
+
+// LoadDataset 读取JSONL格式的数据集，空行和以#开头的行被忽略
+// 用例没有id时使用行号
+func LoadDataset(path string) ([]Case, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取数据集失败: %w", err)
+	}
+	defer f.Close()
+
+	var cases []Case
+	ids := make(map[string]bool)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16<<20)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		var c Case
+		dec := json.NewDecoder(strings.NewReader(line))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&c); err != nil {
+			return nil, fmt.Errorf("数据集%s第%d行: %w", path, lineNo, err)
+		}
+		if c.ID == "" {
+			c.ID = strconv.Itoa(lineNo)
+		}
+		if ids[c.ID] {
+			return nil, fmt.Errorf("数据集%s第%d行: 用例id %s重复", path, lineNo, c.ID)
+		}
+		ids[c.ID] = true
+		if c.Input == "" && len(c.Variables) == 0 {
+			return nil, fmt.Errorf("数据集%s第%d行: 缺少input或variables", path, lineNo)
+		}
+		cases = append(cases, c)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("读取数据集失败: %w", err)
+	}
+	if len(cases) == 0 {
+		return nil, errors.New("数据集为空")
+	}
+	return cases, nil
+}
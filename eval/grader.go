@@ -0,0 +1,323 @@
+package eval
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/kriswu/go_deepseek/siliconproxy"
+	"github.com/kriswu/go_deepseek/vectorindex"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// 评分器类型
+const (
+	GraderExactMatch = "exact_match"
+	GraderRegex      = "regex"
+	GraderJSONSchema = "json_schema"
+	GraderEmbedding  = "embedding_similarity"
+	GraderJudge      = "llm_judge"
+)
+
+// 默认通过阈值
+const (
+	DefaultSimilarityThreshold = 0.8
+	DefaultJudgeThreshold      = 0.7
+)
+
+// Sample 表示一次模型输出
+type Sample struct {
+	Prompt string // 发送给模型的最后一条用户消息
+	Output string
+}
+
+// Score 表示一个评分器的结果
+type Score struct {
+	Grader string  `json:"grader"`
+	Pass   bool    `json:"pass"`
+	Score  float64 `json:"score"` // 0到1
+	Detail string  `json:"detail,omitempty"`
+}
+
+// Grader 对模型输出评分
+type Grader interface {
+	// Name 返回报告中的评分器名称
+	Name() string
+	// Grade 对输出评分，用例和评分器配置都没有所需的期望值时返回nil，不计入统计
+	Grade(ctx context.Context, c *Case, s Sample) (*Score, error)
+}
+
+// NewGrader 根据配置创建评分器，embedding_similarity和llm_judge通过sp调用上游
+func NewGrader(spec GraderSpec, sp *siliconproxy.SiliconProxy) (Grader, error) {
+	name := spec.DisplayName()
+	switch spec.Type {
+	case GraderExactMatch:
+		return &exactMatchGrader{name: name, ignoreCase: spec.IgnoreCase}, nil
+	case GraderRegex:
+		g := &regexGrader{name: name}
+		if spec.Pattern != "" {
+			re, err := regexp.Compile(spec.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("评分器%s的正则表达式无效: %w", name, err)
+			}
+			g.pattern = re
+		}
+		return g, nil
+	case GraderJSONSchema:
+		g := &jsonSchemaGrader{name: name}
+		var schema []byte
+		switch {
+		case spec.SchemaFile != "":
+			data, err := os.ReadFile(spec.SchemaFile)
+			if err != nil {
+				return nil, fmt.Errorf("读取评分器%s的schema失败: %w", name, err)
+			}
+			schema = data
+		case spec.Schema != nil:
+			data, err := json.Marshal(spec.Schema)
+			if err != nil {
+				return nil, fmt.Errorf("评分器%s的schema无效: %w", name, err)
+			}
+			schema = data
+		}
+		if schema != nil {
+			compiled, err := compileSchema(schema)
+			if err != nil {
+				return nil, fmt.Errorf("评分器%s的schema无效: %w", name, err)
+			}
+			g.schema = compiled
+		}
+		return g, nil
+	case GraderEmbedding:
+		threshold := spec.Threshold
+		if threshold == 0 {
+			threshold = DefaultSimilarityThreshold
+		}
+		return &embeddingGrader{name: name, sp: sp, model: spec.Model, threshold: threshold}, nil
+	case GraderJudge:
+		threshold := spec.Threshold
+		if threshold == 0 {
+			threshold = DefaultJudgeThreshold
+		}
+		return &judgeGrader{name: name, sp: sp, model: spec.Model, rubric: spec.Rubric, threshold: threshold}, nil
+	default:
+		return nil, fmt.Errorf("不支持的评分器类型%q", spec.Type)
+	}
+}
+
+func passScore(name string, pass bool, detail string) *Score {
+	s := &Score{Grader: name, Pass: pass, Detail: detail}
+	if pass {
+		s.Score = 1
+	}
+	return s
+}
+
+// 去掉首尾空白后与期望输出完全一致
+type exactMatchGrader struct {
+	name       string
+	ignoreCase bool
+}
+
+func (g *exactMatchGrader) Name() string { return g.name }
+
+func (g *exactMatchGrader) Grade(ctx context.Context, c *Case, s Sample) (*Score, error) {
+	if c.Expected == "" {
+		return nil, nil
+	}
+	got, want := strings.TrimSpace(s.Output), strings.TrimSpace(c.Expected)
+	if g.ignoreCase {
+		return passScore(g.name, strings.EqualFold(got, want), ""), nil
+	}
+	return passScore(g.name, got == want, ""), nil
+}
+
+// 输出中包含正则表达式的匹配
+type regexGrader struct {
+	name    string
+	pattern *regexp.Regexp // 用例没有pattern时使用
+
+	cache sync.Map // 用例的表达式到*regexp.Regexp
+}
+
+func (g *regexGrader) Name() string { return g.name }
+
+func (g *regexGrader) Grade(ctx context.Context, c *Case, s Sample) (*Score, error) {
+	re := g.pattern
+	if c.Pattern != "" {
+		if cached, ok := g.cache.Load(c.Pattern); ok {
+			re = cached.(*regexp.Regexp)
+		} else {
+			compiled, err := regexp.Compile(c.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("用例%s的正则表达式无效: %w", c.ID, err)
+			}
+			g.cache.Store(c.Pattern, compiled)
+			re = compiled
+		}
+	}
+	if re == nil {
+		return nil, nil
+	}
+	return passScore(g.name, re.MatchString(s.Output), ""), nil
+}
+
+// 输出是符合schema的JSON，允许包裹在```json代码块中
+type jsonSchemaGrader struct {
+	name   string
+	schema *jsonschema.Schema // 用例没有schema时使用
+}
+
+func (g *jsonSchemaGrader) Name() string { return g.name }
+
+func (g *jsonSchemaGrader) Grade(ctx context.Context, c *Case, s Sample) (*Score, error) {
+	schema := g.schema
+	if len(c.Schema) > 0 {
+		compiled, err := compileSchema(c.Schema)
+		if err != nil {
+			return nil, fmt.Errorf("用例%s的schema无效: %w", c.ID, err)
+		}
+		schema = compiled
+	}
+	if schema == nil {
+		return nil, nil
+	}
+
+	var v any
+	if err := json.Unmarshal([]byte(stripCodeFence(s.Output)), &v); err != nil {
+		return passScore(g.name, false, "输出不是有效的JSON"), nil
+	}
+	if err := schema.Validate(v); err != nil {
+		var ve *jsonschema.ValidationError
+		if errors.As(err, &ve) {
+			return passScore(g.name, false, ve.Error()), nil
+		}
+		return nil, err
+	}
+	return passScore(g.name, true, ""), nil
+}
+
+func compileSchema(schema []byte) (*jsonschema.Schema, error) {
+	return jsonschema.CompileString("schema.json", string(schema))
+}
+
+// 去掉Markdown代码块标记
+func stripCodeFence(s string) string {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "```") {
+		return s
+	}
+	s = strings.TrimPrefix(s, "```")
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		s = s[i+1:] // 去掉语言标记
+	}
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(s), "```"))
+}
+
+// 输出与期望输出的向量余弦相似度
+type embeddingGrader struct {
+	name      string
+	sp        *siliconproxy.SiliconProxy
+	model     string
+	threshold float64
+}
+
+func (g *embeddingGrader) Name() string { return g.name }
+
+func (g *embeddingGrader) Grade(ctx context.Context, c *Case, s Sample) (*Score, error) {
+	if c.Expected == "" {
+		return nil, nil
+	}
+	if strings.TrimSpace(s.Output) == "" {
+		return passScore(g.name, false, "输出为空"), nil
+	}
+	resp, err := g.sp.CreateEmbedding(ctx, &siliconproxy.EmbeddingRequest{
+		Model: g.model,
+		Input: []string{s.Output, c.Expected},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("计算向量失败: %w", err)
+	}
+	vecs := make([][]float64, 2)
+	for _, d := range resp.Data {
+		if d.Index >= 0 && d.Index < len(vecs) {
+			vecs[d.Index] = d.Embedding
+		}
+	}
+	if vecs[0] == nil || vecs[1] == nil || len(vecs[0]) != len(vecs[1]) {
+		return nil, errors.New("向量响应不完整")
+	}
+	sim := vectorindex.Cosine(vectorindex.FromFloat64(vecs[0]), vectorindex.FromFloat64(vecs[1]))
+	return &Score{Grader: g.name, Pass: sim >= g.threshold, Score: sim}, nil
+}
+
+// 由评审模型按评分标准给出0到10分
+type judgeGrader struct {
+	name      string
+	sp        *siliconproxy.SiliconProxy
+	model     string
+	rubric    string // 用例没有rubric时使用
+	threshold float64
+}
+
+const judgeSystemPrompt = `你是严格、公正的评测员。请根据评分标准评价模型回答，参考答案（如果有）仅作为判断依据。
+给出0到10的整数分数，10分表示完全满足评分标准。只输出JSON，格式为{"score": 分数, "reason": "简短理由"}。`
+
+func (g *judgeGrader) Name() string { return g.name }
+
+func (g *judgeGrader) Grade(ctx context.Context, c *Case, s Sample) (*Score, error) {
+	rubric := c.Rubric
+	if rubric == "" {
+		rubric = g.rubric
+	}
+	if rubric == "" && c.Expected == "" {
+		return nil, nil
+	}
+	if rubric == "" {
+		rubric = "回答应与参考答案在事实和结论上一致"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "评分标准:\n%s\n\n问题:\n%s\n\n", rubric, s.Prompt)
+	if c.Expected != "" {
+		fmt.Fprintf(&b, "参考答案:\n%s\n\n", c.Expected)
+	}
+	fmt.Fprintf(&b, "模型回答:\n%s", s.Output)
+
+	resp, err := g.sp.CreateChatCompletion(ctx, &siliconproxy.ChatCompletionRequest{
+		Model: g.model,
+		Messages: []siliconproxy.ChatCompletionMessage{
+			{Role: "system", Content: judgeSystemPrompt},
+			{Role: "user", Content: b.String()},
+		},
+		ResponseFormat: &siliconproxy.ResponseFormat{Type: "json_object"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("调用评审模型失败: %w", err)
+	}
+	if len(resp.Choices) == 0 {
+		return nil, errors.New("评审模型没有返回结果")
+	}
+
+	var verdict struct {
+		Score  float64 `json:"score"`
+		Reason string  `json:"reason"`
+	}
+	content := stripCodeFence(resp.Choices[0].Message.Content)
+	if i, j := strings.IndexByte(content, '{'), strings.LastIndexByte(content, '}'); i >= 0 && j > i {
+		content = content[i : j+1]
+	}
+	if err := json.Unmarshal([]byte(content), &verdict); err != nil {
+		return nil, fmt.Errorf("解析评审结果失败: %w", err)
+	}
+	if verdict.Score < 0 || verdict.Score > 10 {
+		return nil, fmt.Errorf("评审分数%v超出0到10的范围", verdict.Score)
+	}
+	score := verdict.Score / 10
+	return &Score{Grader: g.name, Pass: score >= g.threshold, Score: score, Detail: verdict.Reason}, nil
+}
@@ -0,0 +1,229 @@
+package eval
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kriswu/go_deepseek/siliconproxy"
+)
+
+func TestLocalGraders(t *testing.T) {
+	schema := map[string]any{
+		"type":     "object",
+		"required": []any{"name"},
+		"properties": map[string]any{
+			"name": map[string]any{"type": "string"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		spec     GraderSpec
+		c        Case
+		output   string
+		wantNil  bool // 不适用时不评分
+		wantPass bool
+	}{
+		{name: "完全一致去掉空白", spec: GraderSpec{Type: GraderExactMatch}, c: Case{Expected: "Paris"}, output: " Paris\n", wantPass: true},
+		{name: "大小写不同", spec: GraderSpec{Type: GraderExactMatch}, c: Case{Expected: "Paris"}, output: "paris"},
+		{name: "忽略大小写", spec: GraderSpec{Type: GraderExactMatch, IgnoreCase: true}, c: Case{Expected: "Paris"}, output: "paris", wantPass: true},
+		{name: "没有期望输出", spec: GraderSpec{Type: GraderExactMatch}, output: "x", wantNil: true},
+
+		{name: "默认表达式匹配", spec: GraderSpec{Type: GraderRegex, Pattern: `\d{4}`}, output: "in 1998", wantPass: true},
+		{name: "默认表达式不匹配", spec: GraderSpec{Type: GraderRegex, Pattern: `\d{4}`}, output: "long ago"},
+		{name: "用例表达式优先", spec: GraderSpec{Type: GraderRegex, Pattern: `\d{4}`}, c: Case{Pattern: `^yes`}, output: "yes 1998", wantPass: true},
+		{name: "没有表达式", spec: GraderSpec{Type: GraderRegex}, output: "x", wantNil: true},
+
+		{name: "符合schema", spec: GraderSpec{Type: GraderJSONSchema, Schema: schema}, output: `{"name":"a"}`, wantPass: true},
+		{name: "代码块中的JSON", spec: GraderSpec{Type: GraderJSONSchema, Schema: schema}, output: "```json\n{\"name\":\"a\"}\n```", wantPass: true},
+		{name: "缺少必填字段", spec: GraderSpec{Type: GraderJSONSchema, Schema: schema}, output: `{"age":1}`},
+		{name: "不是JSON", spec: GraderSpec{Type: GraderJSONSchema, Schema: schema}, output: "name: a"},
+		{name: "用例schema优先", spec: GraderSpec{Type: GraderJSONSchema, Schema: schema}, c: Case{Schema: json.RawMessage(`{"type":"array"}`)}, output: `[1]`, wantPass: true},
+		{name: "没有schema", spec: GraderSpec{Type: GraderJSONSchema}, output: "{}", wantNil: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewGrader(tt.spec, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			score, err := g.Grade(context.Background(), &tt.c, Sample{Output: tt.output})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantNil {
+				if score != nil {
+					t.Fatalf("期望不评分，得到%+v", score)
+				}
+				return
+			}
+			if score == nil || score.Pass != tt.wantPass {
+				t.Fatalf("评分%+v，期望通过: %v", score, tt.wantPass)
+			}
+			if score.Grader != tt.spec.DisplayName() {
+				t.Fatalf("评分器名称%q", score.Grader)
+			}
+			if (score.Score == 1) != tt.wantPass {
+				t.Fatalf("分数%v与结果不一致", score.Score)
+			}
+		})
+	}
+}
+
+func TestNewGraderErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		spec GraderSpec
+	}{
+		{name: "无效的正则表达式", spec: GraderSpec{Type: GraderRegex, Pattern: "("}},
+		{name: "无效的schema", spec: GraderSpec{Type: GraderJSONSchema, Schema: map[string]any{"type": 1}}},
+		{name: "schema文件不存在", spec: GraderSpec{Type: GraderJSONSchema, SchemaFile: "missing.json"}},
+		{name: "未知类型", spec: GraderSpec{Type: "bleu"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewGrader(tt.spec, nil); err == nil {
+				t.Fatal("期望返回错误")
+			}
+		})
+	}
+
+	// 用例中的无效表达式在评分时报错
+	g, _ := NewGrader(GraderSpec{Type: GraderRegex}, nil)
+	if _, err := g.Grade(context.Background(), &Case{ID: "c1", Pattern: "("}, Sample{}); err == nil || !strings.Contains(err.Error(), "c1") {
+		t.Fatalf("错误为%v", err)
+	}
+}
+
+func TestStripCodeFence(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{`{"a":1}`, `{"a":1}`},
+		{"```json\n{\"a\":1}\n```", `{"a":1}`},
+		{"```\n{\"a\":1}\n```  ", `{"a":1}`},
+		{"  plain  ", "plain"},
+	}
+	for _, tt := range tests {
+		if got := stripCodeFence(tt.in); got != tt.want {
+			t.Errorf("stripCodeFence(%q) = %q，期望%q", tt.in, got, tt.want)
+		}
+	}
+}
+
+// 模拟上游：向量由文本中是否包含cat决定，评审模型返回judge的内容
+func newGraderUpstream(t *testing.T, judge string) *siliconproxy.SiliconProxy {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case siliconproxy.EmbeddingsPath:
+			var req struct {
+				Input []string `json:"input"`
+			}
+			json.NewDecoder(r.Body).Decode(&req)
+			data := make([]map[string]any, len(req.Input))
+			for i, text := range req.Input {
+				vec := []float64{0, 1}
+				if strings.Contains(text, "cat") {
+					vec = []float64{1, 0}
+				}
+				data[i] = map[string]any{"index": i, "embedding": vec}
+			}
+			json.NewEncoder(w).Encode(map[string]any{"data": data})
+		case siliconproxy.ChatCompletionsPath:
+			json.NewEncoder(w).Encode(map[string]any{
+				"choices": []map[string]any{{"message": map[string]string{"role": "assistant", "content": judge}}},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	sp := siliconproxy.NewSiliconProxy("token")
+	sp.SetBaseURL(srv.URL)
+	return sp
+}
+
+func TestEmbeddingGrader(t *testing.T) {
+	tests := []struct {
+		name      string
+		output    string
+		expected  string
+		wantNil   bool
+		wantPass  bool
+		wantScore float64
+	}{
+		{name: "语义相同", output: "a cat", expected: "the cat", wantPass: true, wantScore: 1},
+		{name: "语义不同", output: "a dog", expected: "the cat", wantScore: 0},
+		{name: "输出为空", output: " ", expected: "the cat", wantScore: 0},
+		{name: "没有期望输出", output: "a cat", wantNil: true},
+	}
+	g, err := NewGrader(GraderSpec{Type: GraderEmbedding, Model: "e"}, newGraderUpstream(t, ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, err := g.Grade(context.Background(), &Case{Expected: tt.expected}, Sample{Output: tt.output})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantNil {
+				if score != nil {
+					t.Fatalf("期望不评分，得到%+v", score)
+				}
+				return
+			}
+			if score.Pass != tt.wantPass || score.Score != tt.wantScore {
+				t.Fatalf("评分%+v，期望通过%v分数%v", score, tt.wantPass, tt.wantScore)
+			}
+		})
+	}
+}
+
+func TestJudgeGrader(t *testing.T) {
+	tests := []struct {
+		name      string
+		judge     string
+		c         Case
+		wantNil   bool
+		wantErr   bool
+		wantPass  bool
+		wantScore float64
+	}{
+		{name: "通过", judge: `{"score": 8, "reason": "基本正确"}`, c: Case{Rubric: "准确"}, wantPass: true, wantScore: 0.8},
+		{name: "低于阈值", judge: `{"score": 5, "reason": "遗漏要点"}`, c: Case{Expected: "x"}, wantScore: 0.5},
+		{name: "代码块和多余文字", judge: "结果如下：\n```json\n{\"score\": 10, \"reason\": \"完美\"}\n```", c: Case{Expected: "x"}, wantPass: true, wantScore: 1},
+		{name: "分数超出范围", judge: `{"score": 11}`, c: Case{Expected: "x"}, wantErr: true},
+		{name: "不是JSON", judge: "很好", c: Case{Expected: "x"}, wantErr: true},
+		{name: "没有评分标准和期望输出", judge: `{"score": 10}`, wantNil: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewGrader(GraderSpec{Type: GraderJudge, Model: "judge"}, newGraderUpstream(t, tt.judge))
+			if err != nil {
+				t.Fatal(err)
+			}
+			score, err := g.Grade(context.Background(), &tt.c, Sample{Prompt: "q", Output: "a"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("错误为%v，期望出错: %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if tt.wantNil {
+				if score != nil {
+					t.Fatalf("期望不评分，得到%+v", score)
+				}
+				return
+			}
+			if score.Pass != tt.wantPass || score.Score != tt.wantScore {
+				t.Fatalf("评分%+v，期望通过%v分数%v", score, tt.wantPass, tt.wantScore)
+			}
+		})
+	}
+}
@@ -0,0 +1,281 @@
+package eval
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// GraderSummary 表示一个评分器在一组结果上的统计
+type GraderSummary struct {
+	Grader    string  `json:"grader"`
+	Graded    int     `json:"graded"` // 参与评分的用例数
+	Passed    int     `json:"passed"`
+	PassRate  float64 `json:"pass_rate"`
+	MeanScore float64 `json:"mean_score"`
+}
+
+// Summary 表示一个模型和提示词方案组合的统计
+type Summary struct {
+	Model   string          `json:"model"`
+	Prompt  string          `json:"prompt"`
+	Cases   int             `json:"cases"`
+	Errors  int             `json:"errors"`
+	Passed  int             `json:"passed"` // 所有评分器都通过的用例数
+	Graders []GraderSummary `json:"graders"`
+
+	AvgLatency time.Duration `json:"avg_latency_ns"`
+	P50Latency time.Duration `json:"p50_latency_ns"`
+	P95Latency time.Duration `json:"p95_latency_ns"`
+
+	PromptTokens     int     `json:"prompt_tokens"`
+	CompletionTokens int     `json:"completion_tokens"`
+	Cost             float64 `json:"cost"`
+	Priced           bool    `json:"priced"`
+}
+
+// PassRate 返回所有评分器都通过的用例比例
+func (s *Summary) PassRate() float64 {
+	if s.Cases == 0 {
+		return 0
+	}
+	return float64(s.Passed) / float64(s.Cases)
+}
+
+// Report 表示评测报告
+type Report struct {
+	Models    []string  `json:"models"`
+	Prompts   []string  `json:"prompts"`
+	Graders   []string  `json:"graders"`
+	Summaries []Summary `json:"summaries"`
+	Results   []*Result `json:"results"`
+}
+
+func newReport(suite *Suite, graders []string, results []*Result) *Report {
+	report := &Report{Models: suite.Models, Graders: graders, Results: results}
+	for _, p := range suite.Prompts {
+		report.Prompts = append(report.Prompts, p.Name)
+	}
+
+	for _, model := range suite.Models {
+		for _, prompt := range report.Prompts {
+			var group []*Result
+			for _, res := range results {
+				if res.Model == model && res.Prompt == prompt {
+					group = append(group, res)
+				}
+			}
+			report.Summaries = append(report.Summaries, summarize(model, prompt, graders, group))
+		}
+	}
+	return report
+}
+
+func summarize(model, prompt string, graders []string, results []*Result) Summary {
+	s := Summary{Model: model, Prompt: prompt, Cases: len(results), Priced: len(results) > 0}
+
+	byGrader := make(map[string]*GraderSummary, len(graders))
+	for _, name := range graders {
+		s.Graders = append(s.Graders, GraderSummary{Grader: name})
+	}
+	for i := range s.Graders {
+		byGrader[s.Graders[i].Grader] = &s.Graders[i]
+	}
+
+	var latencies []time.Duration
+	var total time.Duration
+	for _, res := range results {
+		if res.Error != "" {
+			s.Errors++
+		}
+		if res.Passed() {
+			s.Passed++
+		}
+		if res.Latency > 0 {
+			latencies = append(latencies, res.Latency)
+			total += res.Latency
+		}
+		s.PromptTokens += res.Usage.PromptTokens
+		s.CompletionTokens += res.Usage.CompletionTokens
+		s.Cost += res.Cost
+		if res.Error == "" && !res.Priced {
+			s.Priced = false
+		}
+		for _, score := range res.Scores {
+			g := byGrader[score.Grader]
+			if g == nil {
+				continue
+			}
+			g.Graded++
+			g.MeanScore += score.Score
+			if score.Pass {
+				g.Passed++
+			}
+		}
+	}
+	for i := range s.Graders {
+		g := &s.Graders[i]
+		if g.Graded > 0 {
+			g.PassRate = float64(g.Passed) / float64(g.Graded)
+			g.MeanScore /= float64(g.Graded)
+		}
+	}
+
+	if len(latencies) > 0 {
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+		s.AvgLatency = total / time.Duration(len(latencies))
+		s.P50Latency = percentile(latencies, 0.5)
+		s.P95Latency = percentile(latencies, 0.95)
+	}
+	return s
+}
+
+// 最近秩法计算百分位数，latencies必须已排序
+func percentile(latencies []time.Duration, p float64) time.Duration {
+	i := int(float64(len(latencies))*p+0.5) - 1
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(latencies) {
+		i = len(latencies) - 1
+	}
+	return latencies[i]
+}
+
+// 报告表格的列
+func (r *Report) header() []string {
+	header := []string{"MODEL", "PROMPT", "PASS"}
+	for _, g := range r.Graders {
+		header = append(header, strings.ToUpper(g))
+	}
+	return append(header, "ERRORS", "AVG", "P50", "P95", "TOKENS(IN/OUT)", "COST")
+}
+
+func (r *Report) rows() [][]string {
+	rows := make([][]string, 0, len(r.Summaries))
+	for _, s := range r.Summaries {
+		row := []string{s.Model, s.Prompt, fmt.Sprintf("%d/%d %s", s.Passed, s.Cases, percent(s.PassRate()))}
+		for _, g := range s.Graders {
+			if g.Graded == 0 {
+				row = append(row, "-")
+				continue
+			}
+			row = append(row, fmt.Sprintf("%s (%.2f)", percent(g.PassRate), g.MeanScore))
+		}
+		cost := "-"
+		if s.Priced {
+			cost = fmt.Sprintf("¥%.6f", s.Cost)
+		}
+		row = append(row,
+			fmt.Sprint(s.Errors),
+			formatLatency(s.AvgLatency),
+			formatLatency(s.P50Latency),
+			formatLatency(s.P95Latency),
+			fmt.Sprintf("%d/%d", s.PromptTokens, s.CompletionTokens),
+			cost,
+		)
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func percent(v float64) string {
+	return fmt.Sprintf("%.1f%%", v*100)
+}
+
+func formatLatency(d time.Duration) string {
+	if d == 0 {
+		return "-"
+	}
+	return d.Round(time.Millisecond).String()
+}
+
+// WriteTable 输出各模型和提示词方案的对比表格
+func (r *Report) WriteTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(r.header(), "\t"))
+	for _, row := range r.rows() {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// WriteJSON 输出包含每个用例结果的完整报告
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(r)
+}
+
+// WriteMarkdown 输出Markdown格式的报告，包括对比表格和未通过的用例
+func (r *Report) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("# 评测报告\n\n")
+	fmt.Fprintf(&b, "- 模型: %s\n", strings.Join(r.Models, ", "))
+	fmt.Fprintf(&b, "- 提示词方案: %s\n", strings.Join(r.Prompts, ", "))
+	fmt.Fprintf(&b, "- 评分器: %s\n\n", strings.Join(r.Graders, ", "))
+
+	header := r.header()
+	b.WriteString("| " + strings.Join(header, " | ") + " |\n")
+	b.WriteString("|" + strings.Repeat(" --- |", len(header)) + "\n")
+	for _, row := range r.rows() {
+		for i := range row {
+			row[i] = markdownCell(row[i])
+		}
+		b.WriteString("| " + strings.Join(row, " | ") + " |\n")
+	}
+
+	var failed []*Result
+	for _, res := range r.Results {
+		if !res.Passed() {
+			failed = append(failed, res)
+		}
+	}
+	if len(failed) > 0 {
+		b.WriteString("\n## 未通过的用例\n\n")
+		b.WriteString("| MODEL | PROMPT | CASE | 结果 | 输出 |\n| --- | --- | --- | --- | --- |\n")
+		for _, res := range failed {
+			detail := res.Error
+			if detail == "" {
+				var parts []string
+				for _, s := range res.Scores {
+					part := fmt.Sprintf("%s=%.2f", s.Grader, s.Score)
+					if s.Detail != "" {
+						part += " " + s.Detail
+					}
+					if !s.Pass {
+						parts = append(parts, part)
+					}
+				}
+				if len(parts) == 0 {
+					parts = append(parts, "没有适用的评分器")
+				}
+				detail = strings.Join(parts, "; ")
+			}
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s |\n",
+				markdownCell(res.Model), markdownCell(res.Prompt), markdownCell(res.CaseID),
+				markdownCell(detail), markdownCell(truncate(res.Output, 200)))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return strings.ReplaceAll(s, "\n", " ")
+}
+
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n]) + "…"
+}
@@ -0,0 +1,258 @@
+package eval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kriswu/go_deepseek/modelcatalog"
+	"github.com/kriswu/go_deepseek/prompttemplate"
+	"github.com/kriswu/go_deepseek/siliconproxy"
+)
+
+// Result 表示一个用例在某个模型和提示词方案下的结果
+type Result struct {
+	Model   string                           `json:"model"`
+	Prompt  string                           `json:"prompt"`
+	CaseID  string                           `json:"case_id"`
+	Output  string                           `json:"output,omitempty"`
+	Scores  []Score                          `json:"scores,omitempty"`
+	Latency time.Duration                    `json:"latency_ns"`
+	Usage   siliconproxy.ChatCompletionUsage `json:"usage"`
+	Cost    float64                          `json:"cost"`
+	Priced  bool                             `json:"priced"` // 能力文件中有该模型的价格
+	Error   string                           `json:"error,omitempty"`
+}
+
+// Passed 判断所有评分器都通过，调用失败或没有评分时返回false
+func (r *Result) Passed() bool {
+	if r.Error != "" || len(r.Scores) == 0 {
+		return false
+	}
+	for _, s := range r.Scores {
+		if !s.Pass {
+			return false
+		}
+	}
+	return true
+}
+
+// Runner 在多个模型和提示词方案上并发运行评测套件
+type Runner struct {
+	sp      *siliconproxy.SiliconProxy
+	suite   *Suite
+	graders []Grader
+	prompts *prompttemplate.Registry
+	prices  map[string]modelcatalog.Capabilities
+
+	// Progress 在每个结果完成后调用，可以为nil
+	Progress func(done, total int, r *Result)
+}
+
+// NewRunner 创建评分器并加载提示词模板和价格
+func NewRunner(sp *siliconproxy.SiliconProxy, suite *Suite) (*Runner, error) {
+	r := &Runner{sp: sp, suite: suite}
+	for _, spec := range suite.Graders {
+		g, err := NewGrader(spec, sp)
+		if err != nil {
+			return nil, err
+		}
+		r.graders = append(r.graders, g)
+	}
+
+	for _, p := range suite.Prompts {
+		if p.Template == "" {
+			continue
+		}
+		if r.prompts == nil {
+			prompts, err := prompttemplate.Load(suite.TemplateDir, nil)
+			if err != nil {
+				return nil, err
+			}
+			r.prompts = prompts
+		}
+		if _, err := r.prompts.Get(p.Template, p.Version); err != nil {
+			return nil, fmt.Errorf("提示词方案%s: %w", p.Name, err)
+		}
+	}
+
+	if suite.CapabilitiesFile != "" {
+		prices, err := modelcatalog.LoadCapabilities(suite.CapabilitiesFile)
+		if err != nil {
+			return nil, err
+		}
+		r.prices = prices
+	}
+	return r, nil
+}
+
+type job struct {
+	model  string
+	prompt Prompt
+	c      *Case
+}
+
+// Run 运行全部用例，单个用例失败记录在结果中而不中止评测
+// ctx取消后不再发起新请求，已完成的结果仍然返回
+func (r *Runner) Run(ctx context.Context, cases []Case) (*Report, error) {
+	var jobs []job
+	for _, model := range r.suite.Models {
+		for _, p := range r.suite.Prompts {
+			for i := range cases {
+				jobs = append(jobs, job{model: model, prompt: p, c: &cases[i]})
+			}
+		}
+	}
+
+	concurrency := r.suite.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+
+	results := make([]*Result, len(jobs))
+	queue := make(chan int)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	done := 0
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				res := r.runOne(ctx, jobs[i])
+				results[i] = res
+				if r.Progress != nil {
+					mu.Lock()
+					done++
+					r.Progress(done, len(jobs), res)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+feed:
+	for i := range jobs {
+		select {
+		case queue <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(queue)
+	wg.Wait()
+
+	completed := make([]*Result, 0, len(results))
+	for _, res := range results {
+		if res != nil {
+			completed = append(completed, res)
+		}
+	}
+	report := newReport(r.suite, r.graderNames(), completed)
+	if err := ctx.Err(); err != nil {
+		return report, fmt.Errorf("评测被中断，已完成%d/%d: %w", len(completed), len(jobs), err)
+	}
+	return report, nil
+}
+
+func (r *Runner) graderNames() []string {
+	names := make([]string, len(r.graders))
+	for i, g := range r.graders {
+		names[i] = g.Name()
+	}
+	return names
+}
+
+// 运行单个用例并评分
+func (r *Runner) runOne(ctx context.Context, j job) *Result {
+	res := &Result{Model: j.model, Prompt: j.prompt.Name, CaseID: j.c.ID}
+
+	req, err := r.request(j)
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+
+	start := time.Now()
+	resp, err := r.sp.CreateChatCompletion(ctx, req)
+	res.Latency = time.Since(start)
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	if len(resp.Choices) == 0 {
+		res.Error = "上游没有返回结果"
+		return res
+	}
+	res.Output = resp.Choices[0].Message.Content
+	res.Usage = resp.Usage
+	if caps, ok := r.prices[j.model]; ok && (caps.InputPrice != 0 || caps.OutputPrice != 0) {
+		res.Priced = true
+		res.Cost = (float64(res.Usage.PromptTokens)*caps.InputPrice + float64(res.Usage.CompletionTokens)*caps.OutputPrice) / 1e6
+	}
+
+	sample := Sample{Output: res.Output}
+	if n := len(req.Messages); n > 0 {
+		sample.Prompt = req.Messages[n-1].Content
+	}
+	var gradeErrs []error
+	for _, g := range r.graders {
+		score, err := g.Grade(ctx, j.c, sample)
+		if err != nil {
+			gradeErrs = append(gradeErrs, fmt.Errorf("%s: %w", g.Name(), err))
+			continue
+		}
+		if score != nil {
+			res.Scores = append(res.Scores, *score)
+		}
+	}
+	if len(gradeErrs) > 0 {
+		res.Error = "评分失败: " + errors.Join(gradeErrs...).Error()
+	}
+	return res
+}
+
+// 根据提示词方案构造请求，评测模型覆盖模板中的模型
+func (r *Runner) request(j job) (*siliconproxy.ChatCompletionRequest, error) {
+	var req *siliconproxy.ChatCompletionRequest
+	if j.prompt.Template != "" {
+		tmpl, err := r.prompts.Get(j.prompt.Template, j.prompt.Version)
+		if err != nil {
+			return nil, err
+		}
+		vars := make(map[string]string, len(j.c.Variables)+1)
+		for k, v := range j.c.Variables {
+			vars[k] = v
+		}
+		if j.c.Input != "" {
+			name := j.prompt.InputVariable
+			if name == "" {
+				name = "input"
+			}
+			vars[name] = j.c.Input
+		}
+		if req, err = tmpl.Request(vars); err != nil {
+			return nil, err
+		}
+	} else {
+		if j.c.Input == "" {
+			return nil, errors.New("用例没有input，variables只能用于模板")
+		}
+		req = &siliconproxy.ChatCompletionRequest{}
+		if j.prompt.System != "" {
+			req.Messages = append(req.Messages, siliconproxy.ChatCompletionMessage{Role: "system", Content: j.prompt.System})
+		}
+		req.Messages = append(req.Messages, siliconproxy.ChatCompletionMessage{Role: "user", Content: j.c.Input})
+	}
+
+	req.Model = j.model
+	req.Stream = false
+	if r.suite.Temperature != nil {
+		req.Temperature = r.suite.Temperature
+	}
+	if r.suite.MaxTokens != 0 {
+		req.MaxTokens = r.suite.MaxTokens
+	}
+	return req, nil
+}
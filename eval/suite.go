@@ -0,0 +1,171 @@
+package eval
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 默认并发数
+const DefaultConcurrency = 4
+
+// Prompt 表示一种提示词方案，可以是内联系统提示词或提示词模板
+type Prompt struct {
+	Name   string `yaml:"name"`
+	System string `yaml:"system,omitempty"` // 内联系统提示词，用例的input作为用户消息
+
+	// 提示词模板，模板中的模型会被评测模型替换
+	Template string `yaml:"template,omitempty"`
+	Version  string `yaml:"version,omitempty"` // 为空时使用固定版本或最新版本
+	// 用例input对应的模板变量名，默认为input
+	InputVariable string `yaml:"input_variable,omitempty"`
+}
+
+// GraderSpec 表示评分器配置，各字段的含义取决于Type
+type GraderSpec struct {
+	// exact_match、regex、json_schema、embedding_similarity或llm_judge
+	Type string `yaml:"type"`
+	// 报告中的名称，默认为Type
+	Name string `yaml:"name,omitempty"`
+
+	IgnoreCase bool   `yaml:"ignore_case,omitempty"` // exact_match
+	Pattern    string `yaml:"pattern,omitempty"`     // regex的默认表达式
+	Schema     any    `yaml:"schema,omitempty"`      // json_schema的默认schema
+	SchemaFile string `yaml:"schema_file,omitempty"` // 从文件读取默认schema，相对于套件文件
+	Model      string `yaml:"model,omitempty"`       // embedding_similarity的向量模型或llm_judge的评审模型
+	Rubric     string `yaml:"rubric,omitempty"`      // llm_judge的默认评分标准
+	// 通过阈值，embedding_similarity默认0.8，llm_judge默认0.7（满分1）
+	Threshold float64 `yaml:"threshold,omitempty"`
+}
+
+// DisplayName 返回报告中的评分器名称
+func (g *GraderSpec) DisplayName() string {
+	if g.Name != "" {
+		return g.Name
+	}
+	return g.Type
+}
+
+// Suite 表示评测套件，在多个模型和提示词方案上运行同一数据集
+type Suite struct {
+	Dataset string       `yaml:"dataset"` // 相对路径相对于套件文件
+	Models  []string     `yaml:"models"`
+	Prompts []Prompt     `yaml:"prompts,omitempty"` // 为空时直接发送用例input
+	Graders []GraderSpec `yaml:"graders"`
+
+	// 对话参数，零值表示使用模板或模型默认值；温度未设置时使用默认值，可以显式设置为0
+	Temperature *float64 `yaml:"temperature,omitempty"`
+	MaxTokens   int      `yaml:"max_tokens,omitempty"`
+
+	Concurrency int `yaml:"concurrency,omitempty"`
+	// 模型价格来源，格式同模型目录的能力文件，用于计算费用
+	CapabilitiesFile string `yaml:"capabilities_file,omitempty"`
+	// 额外的提示词模板目录，内置模板始终可用
+	TemplateDir string `yaml:"template_dir,omitempty"`
+}
+
+// LoadSuite 读取YAML格式的评测套件，文件中的相对路径转换为相对于套件文件的路径
+func LoadSuite(path string) (*Suite, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取评测套件失败: %w", err)
+	}
+	var s Suite
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&s); err != nil {
+		return nil, fmt.Errorf("解析评测套件%s失败: %w", path, err)
+	}
+
+	dir := filepath.Dir(path)
+	resolve := func(p *string) {
+		if *p != "" && !filepath.IsAbs(*p) {
+			*p = filepath.Join(dir, *p)
+		}
+	}
+	resolve(&s.Dataset)
+	resolve(&s.CapabilitiesFile)
+	resolve(&s.TemplateDir)
+	for i := range s.Graders {
+		resolve(&s.Graders[i].SchemaFile)
+	}
+
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Validate 检查套件配置，并为未命名的默认提示词方案补充名称
+func (s *Suite) Validate() error {
+	var errs []error
+	add := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	if s.Dataset == "" {
+		add("缺少dataset")
+	}
+	if len(s.Models) == 0 {
+		add("至少需要一个模型")
+	}
+	if len(s.Graders) == 0 {
+		add("至少需要一个评分器")
+	}
+	if s.Concurrency < 0 {
+		add("concurrency不能为负数")
+	}
+
+	prompts := make(map[string]bool)
+	for i, p := range s.Prompts {
+		if p.Name == "" {
+			add("prompts[%d]缺少name", i)
+		} else if prompts[p.Name] {
+			add("提示词方案名称重复: %s", p.Name)
+		}
+		prompts[p.Name] = true
+		if p.System != "" && p.Template != "" {
+			add("提示词方案%s不能同时配置system和template", p.Name)
+		}
+	}
+
+	graders := make(map[string]bool)
+	for i, g := range s.Graders {
+		switch g.Type {
+		case GraderExactMatch, GraderRegex, GraderJSONSchema:
+		case GraderEmbedding, GraderJudge:
+			if g.Model == "" {
+				add("graders[%d]: %s需要配置model", i, g.Type)
+			}
+		default:
+			add("graders[%d]: 不支持的评分器类型%q", i, g.Type)
+		}
+		if g.Schema != nil && g.SchemaFile != "" {
+			add("graders[%d]: schema和schema_file只能配置一个", i)
+		}
+		if g.Threshold < 0 || g.Threshold > 1 {
+			add("graders[%d]: threshold必须在0到1之间", i)
+		}
+		if graders[g.DisplayName()] {
+			add("评分器名称重复: %s，请使用name区分", g.DisplayName())
+		}
+		graders[g.DisplayName()] = true
+	}
+
+	if len(errs) > 0 {
+		msgs := make([]string, len(errs))
+		for i, err := range errs {
+			msgs[i] = err.Error()
+		}
+		return errors.New("评测套件无效:\n  " + strings.Join(msgs, "\n  "))
+	}
+	if len(s.Prompts) == 0 {
+		s.Prompts = []Prompt{{Name: "default"}}
+	}
+	return nil
+}
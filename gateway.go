@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/kriswu/go_deepseek/grpc"
+	"github.com/kriswu/go_deepseek/proto"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// HTTP/JSON网关
+// 网关通过内存连接转发到一个独立的gRPC服务实例，该实例不使用TLS，但与对外实例共用服务和拦截器，
+// 租户通过Authorization请求头识别，客户端证书不会传递到内部实例
+type gateway struct {
+	httpServer *http.Server
+	internal   *grpclib.Server
+	conn       *grpclib.ClientConn
+}
+
+// 启动HTTP/JSON网关，tlsConf为nil时使用明文HTTP
+// 服务端流式接口以换行分隔的JSON返回，每行形如{"result": {...}}
+func startGateway(addr string, server *grpc.SiliconServer, opts []grpclib.ServerOption, tlsConf *tls.Config) (*gateway, error) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("监听HTTP端口失败: %w", err)
+	}
+	if tlsConf != nil {
+		lis = tls.NewListener(lis, tlsConf)
+	}
+
+	memLis := bufconn.Listen(1 << 20)
+	internal := grpclib.NewServer(opts...)
+	proto.RegisterSiliconServiceServer(internal, server)
+	go internal.Serve(memLis)
+
+	conn, err := grpclib.NewClient("passthrough:///gateway",
+		grpclib.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return memLis.DialContext(ctx)
+		}),
+		grpclib.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		lis.Close()
+		internal.Stop()
+		return nil, fmt.Errorf("连接内部gRPC服务失败: %w", err)
+	}
+
+	// 字段名与proto保持一致，与OpenAPI文档相同
+	gwMux := runtime.NewServeMux(runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+		MarshalOptions:   protojson.MarshalOptions{UseProtoNames: true},
+		UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+	}))
+	if err := proto.RegisterSiliconServiceHandler(context.Background(), gwMux, conn); err != nil {
+		lis.Close()
+		conn.Close()
+		internal.Stop()
+		return nil, fmt.Errorf("注册网关失败: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/v1/", gwMux)
+	mux.HandleFunc("/openapi.json", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(proto.OpenAPI)
+	})
+
+	g := &gateway{
+		httpServer: &http.Server{Handler: mux},
+		internal:   internal,
+		conn:       conn,
+	}
+	go func() {
+		if err := g.httpServer.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("HTTP网关退出: %v", err)
+		}
+	}()
+	return g, nil
+}
+
+// Shutdown 停止接收新请求并等待进行中的请求完成，ctx到期后强制关闭
+func (g *gateway) Shutdown(ctx context.Context) error {
+	err := g.httpServer.Shutdown(ctx)
+	if err != nil {
+		g.httpServer.Close()
+		g.internal.Stop()
+	} else {
+		g.internal.GracefulStop()
+	}
+	g.conn.Close()
+	return err
+}
@@ -5,8 +5,12 @@ go 1.23.0
 toolchain go1.24.1
 
 require (
+	github.com/grpc-ecosystem/grpc-gateway/v2 v2.25.1
+	github.com/santhosh-tekuri/jsonschema/v5 v5.3.1
+	google.golang.org/genproto/googleapis/api v0.0.0-20250115164207-1a7da9e5054f
 	google.golang.org/grpc v1.71.1
 	google.golang.org/protobuf v1.36.4
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -0,0 +1,219 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/kriswu/go_deepseek/proto"
+	"github.com/kriswu/go_deepseek/siliconproxy"
+	"github.com/kriswu/go_deepseek/voiceregistry"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CreateTranscription 接收音频分片并返回转写文本
+// 分片边接收边上传到上游，不在内存中缓存整个音频
+func (s *SiliconServer) CreateTranscription(stream proto.SiliconService_CreateTranscriptionServer) error {
+	first, err := stream.Recv()
+	if errors.Is(err, io.EOF) {
+		return status.Error(codes.InvalidArgument, "未收到音频数据")
+	}
+	if err != nil {
+		return err
+	}
+	if first.Model == "" {
+		return status.Error(codes.InvalidArgument, "第一个分片必须指定模型")
+	}
+
+	// 上游请求在单独的goroutine中读取管道，stream.Recv只在处理函数中调用
+	// 返回前等待上游请求结束，不会有goroutine在处理函数返回后继续使用stream
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	pr, pw := io.Pipe()
+	type upstreamResult struct {
+		resp *siliconproxy.TranscriptionResponse
+		err  error
+	}
+	done := make(chan upstreamResult, 1)
+	go func() {
+		resp, err := s.proxy(ctx).CreateTranscription(ctx, &siliconproxy.TranscriptionRequest{
+			Model:    first.Model,
+			Reader:   pr,
+			FileName: first.FileName,
+		})
+		// 上游不再读取后写入立即失败，处理函数随之停止接收
+		pr.Close()
+		done <- upstreamResult{resp, err}
+	}()
+
+	recvErr := pumpChunks(pw, first, stream)
+	if recvErr != nil {
+		pw.CloseWithError(recvErr)
+		cancel()
+	} else {
+		pw.Close()
+	}
+	result := <-done
+	if recvErr != nil {
+		return recvErr
+	}
+	if result.err != nil {
+		return fmt.Errorf("语音转文本失败: %w", result.err)
+	}
+
+	return stream.SendAndClose(&proto.TranscriptionResponse{Text: result.resp.Text})
+}
+
+// 把音频分片写入管道直到客户端发送完毕，返回接收错误
+// 上游请求结束导致写入失败时停止接收并返回nil，由上游结果决定响应
+func pumpChunks(pw *io.PipeWriter, first *proto.TranscriptionChunk, stream proto.SiliconService_CreateTranscriptionServer) error {
+	if _, err := pw.Write(first.Data); err != nil {
+		return nil
+	}
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := pw.Write(chunk.Data); err != nil {
+			return nil
+		}
+	}
+}
+
+// ListVoices 查询音色
+func (s *SiliconServer) ListVoices(ctx context.Context, req *proto.ListVoicesRequest) (*proto.ListVoicesResponse, error) {
+	if s.voices == nil {
+		return nil, status.Error(codes.FailedPrecondition, "未启用音色管理")
+	}
+
+	voices := s.voiceRegistry(ctx)
+	if req.Sync {
+		if _, _, err := voices.Sync(ctx); err != nil {
+			return nil, fmt.Errorf("同步音色失败: %w", err)
+		}
+	}
+
+	response := &proto.ListVoicesResponse{}
+	for _, v := range voices.List(voiceregistry.Filter{
+		OwnerTeam: req.OwnerTeam,
+		Language:  req.Language,
+		Model:     req.Model,
+		Query:     req.Query,
+	}) {
+		response.Voices = append(response.Voices, toProtoVoice(&v))
+	}
+
+	return response, nil
+}
+
+// GetVoice 按名称或URI获取音色
+func (s *SiliconServer) GetVoice(ctx context.Context, req *proto.GetVoiceRequest) (*proto.Voice, error) {
+	if s.voices == nil {
+		return nil, status.Error(codes.FailedPrecondition, "未启用音色管理")
+	}
+
+	voice, err := s.voiceRegistry(ctx).Get(req.Name)
+	if errors.Is(err, voiceregistry.ErrVoiceNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return toProtoVoice(voice), nil
+}
+
+// UploadVoice 通过音色注册表上传音色，内容相同的音频返回已有音色
+func (s *SiliconServer) UploadVoice(ctx context.Context, req *proto.UploadVoiceRequest) (*proto.UploadVoiceResponse, error) {
+	if s.voices == nil {
+		return nil, status.Error(codes.FailedPrecondition, "未启用音色管理")
+	}
+	if req.Model == "" || req.Name == "" || len(req.Audio) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "model、name和audio不能为空")
+	}
+
+	voice, duplicate, err := s.voiceRegistry(ctx).Upload(ctx, &voiceregistry.UploadRequest{
+		Model:    req.Model,
+		Name:     req.Name,
+		Text:     req.Text,
+		Audio:    req.Audio,
+		FileName: req.FileName,
+		Metadata: voiceregistry.Metadata{
+			OwnerTeam:        req.OwnerTeam,
+			Language:         req.Language,
+			SampleTranscript: req.SampleTranscript,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("上传音色失败: %w", err)
+	}
+
+	return &proto.UploadVoiceResponse{Voice: toProtoVoice(voice), Duplicate: duplicate}, nil
+}
+
+// SetVoiceMetadata 按名称或URI更新音色元数据
+func (s *SiliconServer) SetVoiceMetadata(ctx context.Context, req *proto.SetVoiceMetadataRequest) (*proto.Voice, error) {
+	if s.voices == nil {
+		return nil, status.Error(codes.FailedPrecondition, "未启用音色管理")
+	}
+
+	voices := s.voiceRegistry(ctx)
+	voice, err := voices.Get(req.Name)
+	if errors.Is(err, voiceregistry.ErrVoiceNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	voice.Metadata = voiceregistry.Metadata{
+		OwnerTeam:        req.OwnerTeam,
+		Language:         req.Language,
+		SampleTranscript: req.SampleTranscript,
+	}
+	err = voices.SetMetadata(voice.URI, voice.Metadata)
+	if errors.Is(err, voiceregistry.ErrVoiceNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("更新音色元数据失败: %w", err)
+	}
+
+	return toProtoVoice(voice), nil
+}
+
+// DeleteVoice 按名称或URI删除音色
+func (s *SiliconServer) DeleteVoice(ctx context.Context, req *proto.DeleteVoiceRequest) (*proto.Voice, error) {
+	if s.voices == nil {
+		return nil, status.Error(codes.FailedPrecondition, "未启用音色管理")
+	}
+
+	voice, err := s.voiceRegistry(ctx).Delete(ctx, req.Name)
+	if errors.Is(err, voiceregistry.ErrVoiceNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("删除音色失败: %w", err)
+	}
+
+	return toProtoVoice(voice), nil
+}
+
+func toProtoVoice(v *voiceregistry.Voice) *proto.Voice {
+	return &proto.Voice{
+		Uri:              v.URI,
+		Model:            v.Model,
+		Name:             v.Name,
+		Text:             v.Text,
+		OwnerTeam:        v.Metadata.OwnerTeam,
+		Language:         v.Metadata.Language,
+		SampleTranscript: v.Metadata.SampleTranscript,
+		CreatedAt:        v.CreatedAt.Unix(),
+	}
+}
@@ -0,0 +1,119 @@
+package grpc
+
+import (
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kriswu/go_deepseek/proto"
+	"github.com/kriswu/go_deepseek/siliconproxy"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// 启动使用upstream作为上游的服务，返回客户端
+func newTestClient(t *testing.T, upstream http.Handler) proto.SiliconServiceClient {
+	t.Helper()
+	srv := httptest.NewServer(upstream)
+	t.Cleanup(srv.Close)
+	sp := siliconproxy.NewSiliconProxy("token")
+	sp.SetBaseURL(srv.URL)
+
+	lis := bufconn.Listen(1 << 20)
+	s := grpclib.NewServer()
+	proto.RegisterSiliconServiceServer(s, NewSiliconServer(sp))
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpclib.NewClient("passthrough:///bufnet",
+		grpclib.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpclib.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return proto.NewSiliconServiceClient(conn)
+}
+
+func TestCreateTranscription(t *testing.T) {
+	tests := []struct {
+		name     string
+		chunks   []*proto.TranscriptionChunk
+		upstream http.HandlerFunc
+		want     string
+		code     codes.Code
+	}{
+		{
+			name: "多个分片拼接后上传",
+			chunks: []*proto.TranscriptionChunk{
+				{Model: "m", FileName: "a.wav", Data: []byte("hello ")},
+				{Data: []byte("world")},
+			},
+			upstream: func(w http.ResponseWriter, r *http.Request) {
+				_, params, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+				mr := multipart.NewReader(r.Body, params["boundary"])
+				for {
+					part, err := mr.NextPart()
+					if err != nil {
+						http.Error(w, "缺少file", http.StatusBadRequest)
+						return
+					}
+					if part.FormName() == "file" {
+						data, _ := io.ReadAll(part)
+						w.Write([]byte(`{"text":"` + string(data) + `"}`))
+						return
+					}
+				}
+			},
+			want: "hello world",
+		},
+		{
+			name:   "第一个分片缺少模型",
+			chunks: []*proto.TranscriptionChunk{{Data: []byte("x")}},
+			code:   codes.InvalidArgument,
+		},
+		{
+			name:   "上游失败",
+			chunks: []*proto.TranscriptionChunk{{Model: "m", Data: []byte("x")}, {Data: []byte("y")}},
+			upstream: func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, `{"message":"bad audio"}`, http.StatusBadRequest)
+			},
+			code: codes.Unknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upstream := tt.upstream
+			if upstream == nil {
+				upstream = func(w http.ResponseWriter, r *http.Request) { t.Error("不应请求上游") }
+			}
+			client := newTestClient(t, upstream)
+
+			stream, err := client.CreateTranscription(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, chunk := range tt.chunks {
+				if err := stream.Send(chunk); err != nil {
+					break // 服务端已返回，错误由CloseAndRecv给出
+				}
+			}
+			resp, err := stream.CloseAndRecv()
+			if status.Code(err) != tt.code {
+				t.Fatalf("错误为%v，期望状态码%v", err, tt.code)
+			}
+			if err == nil && resp.Text != tt.want {
+				t.Fatalf("转写结果为%q，期望%q", resp.Text, tt.want)
+			}
+		})
+	}
+}
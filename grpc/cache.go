@@ -0,0 +1,49 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/kriswu/go_deepseek/proto"
+	"github.com/kriswu/go_deepseek/siliconproxy"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// InvalidateSemanticCache 删除匹配的语义缓存条目
+// 租户请求只能删除自己的条目，忽略请求中的租户字段
+func (s *SiliconServer) InvalidateSemanticCache(ctx context.Context, req *proto.InvalidateSemanticCacheRequest) (*proto.InvalidateSemanticCacheResponse, error) {
+	if s.semanticCache == nil {
+		return nil, status.Error(codes.FailedPrecondition, "未启用语义缓存")
+	}
+
+	scope := siliconproxy.SemanticScope{
+		Model:        req.Model,
+		SystemPrompt: req.SystemPrompt,
+		Tenant:       req.Tenant,
+	}
+	if name := tenantName(ctx); name != "" {
+		scope.Tenant = name
+	}
+	removed := s.semanticCache.Invalidate(scope)
+	return &proto.InvalidateSemanticCacheResponse{Removed: int64(removed)}, nil
+}
+
+// GetSemanticCacheStats 查询语义缓存统计信息，统计覆盖全部租户
+func (s *SiliconServer) GetSemanticCacheStats(ctx context.Context, req *proto.Empty) (*proto.SemanticCacheStats, error) {
+	if s.semanticCache == nil {
+		return nil, status.Error(codes.FailedPrecondition, "未启用语义缓存")
+	}
+
+	stats := s.semanticCache.Stats()
+	return &proto.SemanticCacheStats{
+		Lookups:         stats.Lookups,
+		Hits:            stats.Hits,
+		Misses:          stats.Misses,
+		HitRate:         stats.HitRate,
+		Entries:         int64(stats.Entries),
+		Evictions:       stats.Evictions,
+		SavedTokens:     stats.SavedTokens,
+		EmbeddingTokens: stats.EmbeddingTokens,
+		EmbeddingErrors: stats.EmbeddingErrors,
+	}, nil
+}
@@ -0,0 +1,69 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kriswu/go_deepseek/proto"
+	"github.com/kriswu/go_deepseek/siliconproxy"
+)
+
+func TestInvalidateSemanticCacheScopedToTenant(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case siliconproxy.EmbeddingsPath:
+			json.NewEncoder(w).Encode(map[string]any{
+				"data": []map[string]any{{"index": 0, "embedding": []float64{1, 0}}},
+			})
+		case siliconproxy.ChatCompletionsPath:
+			json.NewEncoder(w).Encode(map[string]any{
+				"choices": []map[string]any{{"message": map[string]string{"role": "assistant", "content": "ok"}}},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer upstream.Close()
+
+	sp := siliconproxy.NewSiliconProxy("token")
+	sp.SetBaseURL(upstream.URL)
+	s := NewSiliconServer(sp)
+	s.SetSemanticCache(siliconproxy.NewSemanticCache(sp, siliconproxy.SemanticCacheConfig{EmbeddingModel: "e"}))
+	s.SetTenants([]Tenant{{Name: "team-a", APIKey: "key-a"}, {Name: "team-b", APIKey: "key-b"}}, true)
+
+	tenantCtx := func(key string) context.Context {
+		ctx, err := s.authenticate(withAuthorization(context.Background(), "Bearer "+key))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ctx
+	}
+	for _, key := range []string{"key-a", "key-b"} {
+		req := &siliconproxy.ChatCompletionRequest{
+			Model:    "m",
+			Messages: []siliconproxy.ChatCompletionMessage{{Role: "user", Content: "hello"}},
+		}
+		if _, err := s.complete(tenantCtx(key), req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 租户指定其他租户时只删除自己的条目
+	resp, err := s.InvalidateSemanticCache(tenantCtx("key-a"), &proto.InvalidateSemanticCacheRequest{Tenant: "team-b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Removed != 1 {
+		t.Fatalf("删除%d条，期望1条", resp.Removed)
+	}
+	stats, err := s.GetSemanticCacheStats(tenantCtx("key-b"), &proto.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Entries != 1 || stats.Lookups != 2 {
+		t.Fatalf("统计信息 %+v", stats)
+	}
+}
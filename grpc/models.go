@@ -0,0 +1,52 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kriswu/go_deepseek/modelcatalog"
+	"github.com/kriswu/go_deepseek/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListModels 按类型和能力查询模型目录
+func (s *SiliconServer) ListModels(ctx context.Context, req *proto.ListModelsRequest) (*proto.ListModelsResponse, error) {
+	if s.catalog == nil {
+		return nil, status.Error(codes.FailedPrecondition, "未启用模型目录")
+	}
+	if req.Refresh {
+		if err := s.catalog.Refresh(ctx); err != nil {
+			return nil, fmt.Errorf("刷新模型目录失败: %w", err)
+		}
+	}
+
+	entries, err := s.catalog.List(ctx, modelcatalog.Filter{
+		Type:             req.Type,
+		SubType:          req.SubType,
+		SupportsTools:    req.SupportsTools,
+		SupportsVision:   req.SupportsVision,
+		SupportsJSON:     req.SupportsJson,
+		MinContextLength: int(req.MinContextLength),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("查询模型目录失败: %w", err)
+	}
+
+	response := &proto.ListModelsResponse{}
+	for _, e := range entries {
+		response.Models = append(response.Models, &proto.ModelInfo{
+			Id:             e.ID,
+			OwnedBy:        e.OwnedBy,
+			Type:           e.Type,
+			SubTypes:       e.SubTypes,
+			ContextLength:  int32(e.ContextLength),
+			SupportsTools:  e.SupportsTools,
+			SupportsVision: e.SupportsVision,
+			SupportsJson:   e.SupportsJSON,
+			InputPrice:     e.InputPrice,
+			OutputPrice:    e.OutputPrice,
+		})
+	}
+	return response, nil
+}
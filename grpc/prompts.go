@@ -0,0 +1,98 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+
+	"github.com/kriswu/go_deepseek/prompttemplate"
+	"github.com/kriswu/go_deepseek/proto"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// PromptTemplateHeader 是RenderAndComplete响应头中的模板版本，格式为名称@版本
+const PromptTemplateHeader = "x-prompt-template"
+
+// 按模板版本统计的调用次数，键为名称@版本
+var promptRequestsMetric = expvar.NewMap("silicon_prompt_template_requests")
+
+// RenderAndComplete 渲染提示词模板并发起对话，响应中附带实际使用的模板版本
+func (s *SiliconServer) RenderAndComplete(ctx context.Context, req *proto.RenderAndCompleteRequest) (*proto.RenderAndCompleteResponse, error) {
+	if s.prompts == nil {
+		return nil, status.Error(codes.FailedPrecondition, "未启用提示词模板")
+	}
+	if req.Template == "" {
+		return nil, status.Error(codes.InvalidArgument, "缺少模板名称")
+	}
+
+	tmpl, err := s.prompts.Get(req.Template, req.Version)
+	if errors.Is(err, prompttemplate.ErrTemplateNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	chatReq, err := tmpl.Request(req.Variables)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if req.Model != "" {
+		chatReq.Model = req.Model
+	}
+	if chatReq.Model == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "模板%s@%s未指定模型，请在请求中提供model", tmpl.Name, tmpl.Version)
+	}
+
+	tag := tmpl.Name + "@" + tmpl.Version
+	promptRequestsMetric.Add(tag, 1)
+	// 失败时也带上模板版本，便于按版本分析错误
+	grpclib.SetHeader(ctx, metadata.Pairs(PromptTemplateHeader, tag))
+
+	completion, err := s.complete(ctx, chatReq)
+	if err != nil {
+		return nil, fmt.Errorf("模板%s: %w", tag, err)
+	}
+	return &proto.RenderAndCompleteResponse{
+		Completion: completion,
+		Template:   tmpl.Name,
+		Version:    tmpl.Version,
+	}, nil
+}
+
+// ListPromptTemplates 查询提示词模板及其版本
+func (s *SiliconServer) ListPromptTemplates(ctx context.Context, req *proto.ListPromptTemplatesRequest) (*proto.ListPromptTemplatesResponse, error) {
+	if s.prompts == nil {
+		return nil, status.Error(codes.FailedPrecondition, "未启用提示词模板")
+	}
+
+	response := &proto.ListPromptTemplatesResponse{}
+	for _, summary := range s.prompts.List() {
+		if req.Name != "" && summary.Name != req.Name {
+			continue
+		}
+		t := &proto.PromptTemplate{
+			Name:           summary.Name,
+			Pinned:         summary.Pinned,
+			DefaultVersion: summary.Default().Version,
+		}
+		for _, v := range summary.Versions {
+			t.Versions = append(t.Versions, &proto.PromptTemplateVersion{
+				Version:     v.Version,
+				Description: v.Description,
+				Metadata:    v.Metadata,
+				Model:       v.Model,
+				Variables:   v.VariableNames(),
+				Source:      v.Source,
+			})
+		}
+		response.Templates = append(response.Templates, t)
+	}
+	if req.Name != "" && len(response.Templates) == 0 {
+		return nil, status.Errorf(codes.NotFound, "%v: %s", prompttemplate.ErrTemplateNotFound, req.Name)
+	}
+	return response, nil
+}
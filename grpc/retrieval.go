@@ -0,0 +1,175 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/kriswu/go_deepseek/proto"
+	"github.com/kriswu/go_deepseek/retrieval"
+	"github.com/kriswu/go_deepseek/siliconproxy"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// IndexDocuments 索引文档到集合
+func (s *SiliconServer) IndexDocuments(ctx context.Context, req *proto.IndexDocumentsRequest) (*proto.IndexDocumentsResponse, error) {
+	if s.store == nil {
+		return nil, status.Error(codes.FailedPrecondition, "未启用文档检索")
+	}
+
+	docs := make([]retrieval.Document, len(req.Documents))
+	for i, doc := range req.Documents {
+		docs[i] = retrieval.Document{
+			ID:       doc.Id,
+			Text:     doc.Text,
+			Metadata: doc.Metadata,
+		}
+	}
+
+	stats, err := s.store.IndexDocuments(ctx, req.Collection, docs)
+	if err != nil {
+		return nil, fmt.Errorf("索引文档失败: %w", err)
+	}
+
+	return &proto.IndexDocumentsResponse{
+		Documents:       int32(stats.Documents),
+		Chunks:          int32(stats.Chunks),
+		EmbeddingTokens: int32(stats.EmbeddingTokens),
+	}, nil
+}
+
+// Search 在集合中检索文本块
+func (s *SiliconServer) Search(ctx context.Context, req *proto.SearchRequest) (*proto.SearchResponse, error) {
+	if s.store == nil {
+		return nil, status.Error(codes.FailedPrecondition, "未启用文档检索")
+	}
+
+	results, err := s.store.Search(ctx, &retrieval.SearchRequest{
+		Collection:  req.Collection,
+		Query:       req.Query,
+		TopK:        int(req.TopK),
+		Rerank:      req.Rerank,
+		RerankModel: req.RerankModel,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("检索失败: %w", err)
+	}
+
+	response := &proto.SearchResponse{}
+	for _, r := range results {
+		response.Hits = append(response.Hits, &proto.SearchHit{
+			DocumentId: r.Chunk.DocumentID,
+			ChunkIndex: int32(r.Chunk.Index),
+			Text:       r.Chunk.Text,
+			Score:      r.Score,
+			Start:      int32(r.Chunk.Start),
+			End:        int32(r.Chunk.End),
+			Metadata:   r.Chunk.Metadata,
+		})
+	}
+
+	return response, nil
+}
+
+// ChatWithContext 检索相关文本块后流式返回回答
+// 第一条消息携带引用列表，最后一条消息的done为true并携带用量
+func (s *SiliconServer) ChatWithContext(req *proto.ChatWithContextRequest, stream proto.SiliconService_ChatWithContextServer) error {
+	if s.store == nil {
+		return status.Error(codes.FailedPrecondition, "未启用文档检索")
+	}
+
+	history := make([]siliconproxy.ChatCompletionMessage, len(req.History))
+	for i, msg := range req.History {
+		history[i] = siliconproxy.ChatCompletionMessage{
+			Role:    msg.Role,
+			Content: msg.Content,
+		}
+	}
+
+	var temperature *float64
+	if req.Temperature != nil {
+		temperature = siliconproxy.Float64(float64(*req.Temperature))
+	}
+	chatReq, citations, err := s.store.BuildContextChat(stream.Context(), &retrieval.ContextChatRequest{
+		Collection:     req.Collection,
+		Model:          req.Model,
+		Query:          req.Query,
+		History:        history,
+		TopK:           int(req.TopK),
+		RerankModel:    req.RerankModel,
+		PromptTemplate: req.PromptTemplate,
+		ContextTokens:  int(req.ContextTokens),
+		Temperature:    temperature,
+		MaxTokens:      int(req.MaxTokens),
+	})
+	if err != nil {
+		return fmt.Errorf("构造对话失败: %w", err)
+	}
+
+	first := &proto.ChatWithContextResponse{}
+	for _, c := range citations {
+		first.Citations = append(first.Citations, &proto.Citation{
+			Index:      int32(c.Index),
+			DocumentId: c.DocumentID,
+			ChunkIndex: int32(c.ChunkIndex),
+			Start:      int32(c.Start),
+			End:        int32(c.End),
+			Score:      c.Score,
+		})
+	}
+	if err := stream.Send(first); err != nil {
+		return err
+	}
+
+	chatStream, err := s.proxy(stream.Context()).CreateChatCompletionStream(stream.Context(), chatReq)
+	if err != nil {
+		return fmt.Errorf("创建对话失败: %w", err)
+	}
+	defer chatStream.Close()
+
+	// 客户端断开时关闭上游连接，使阻塞中的Recv尽快返回
+	go func() {
+		<-stream.Context().Done()
+		chatStream.Close()
+	}()
+
+	last := &proto.ChatWithContextResponse{Done: true}
+	for {
+		chunk, err := chatStream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			if ctxErr := stream.Context().Err(); ctxErr != nil {
+				return status.FromContextError(ctxErr).Err()
+			}
+			return fmt.Errorf("读取对话失败: %w", err)
+		}
+
+		if chunk.Usage != nil {
+			last.Usage = &proto.Usage{
+				PromptTokens:     int32(chunk.Usage.PromptTokens),
+				CompletionTokens: int32(chunk.Usage.CompletionTokens),
+				TotalTokens:      int32(chunk.Usage.TotalTokens),
+			}
+		}
+		for _, choice := range chunk.Choices {
+			if choice.FinishReason != "" {
+				last.FinishReason = choice.FinishReason
+			}
+			if choice.Delta.Content == "" && choice.Delta.ReasoningContent == "" {
+				continue
+			}
+			if err := stream.Send(&proto.ChatWithContextResponse{
+				Delta:          choice.Delta.Content,
+				ReasoningDelta: choice.Delta.ReasoningContent,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return stream.Send(last)
+}
@@ -3,15 +3,41 @@ package grpc
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 
+	"github.com/kriswu/go_deepseek/modelcatalog"
+	"github.com/kriswu/go_deepseek/prompttemplate"
 	"github.com/kriswu/go_deepseek/proto"
+	"github.com/kriswu/go_deepseek/retrieval"
 	"github.com/kriswu/go_deepseek/siliconproxy"
+	"github.com/kriswu/go_deepseek/videojob"
+	"github.com/kriswu/go_deepseek/voiceregistry"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
+// CacheHeader 是响应头中的缓存状态，取值为hit、miss或semantic_hit
+// 只在请求经过缓存时设置，通过HTTP网关访问时为Grpc-Metadata-X-Cache
+const CacheHeader = "x-cache"
+
 // SiliconServer 实现gRPC服务接口
 type SiliconServer struct {
 	proto.UnimplementedSiliconServiceServer
 	sp *siliconproxy.SiliconProxy
+	// 语义缓存，为nil时不启用
+	semanticCache *siliconproxy.SemanticCache
+	// 文档检索存储，为nil时检索相关接口不可用
+	store *retrieval.Store
+	// 音色注册表，为nil时音色相关接口不可用
+	voices *voiceregistry.Registry
+	// 模型目录，为nil时ListModels不可用
+	catalog *modelcatalog.Catalog
+	// 视频任务管理器，为nil时视频任务接口不可用
+	videos *videojob.Manager
+	// 提示词模板，为nil时模板相关接口不可用
+	prompts *prompttemplate.Registry
+	// 租户表，为nil时不区分租户
+	tenants atomic.Pointer[tenantTable]
 }
 
 // NewSiliconServer 创建新的服务实例
@@ -19,9 +45,39 @@ func NewSiliconServer(sp *siliconproxy.SiliconProxy) *SiliconServer {
 	return &SiliconServer{sp: sp}
 }
 
+// SetSemanticCache 为聊天对话启用语义缓存
+func (s *SiliconServer) SetSemanticCache(c *siliconproxy.SemanticCache) {
+	s.semanticCache = c
+}
+
+// SetRetrievalStore 启用文档索引与检索接口
+func (s *SiliconServer) SetRetrievalStore(store *retrieval.Store) {
+	s.store = store
+}
+
+// SetVoiceRegistry 启用音色管理接口
+func (s *SiliconServer) SetVoiceRegistry(voices *voiceregistry.Registry) {
+	s.voices = voices
+}
+
+// SetModelCatalog 启用模型目录接口
+func (s *SiliconServer) SetModelCatalog(catalog *modelcatalog.Catalog) {
+	s.catalog = catalog
+}
+
+// SetVideoJobs 启用视频任务接口
+func (s *SiliconServer) SetVideoJobs(videos *videojob.Manager) {
+	s.videos = videos
+}
+
+// SetPromptRegistry 启用提示词模板接口
+func (s *SiliconServer) SetPromptRegistry(prompts *prompttemplate.Registry) {
+	s.prompts = prompts
+}
+
 // GetModelList 获取模型列表
 func (s *SiliconServer) GetModelList(ctx context.Context, _ *proto.Empty) (*proto.GetModelListResponse, error) {
-	models, err := s.sp.GetModelList()
+	models, err := s.proxy(ctx).GetModelList(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("获取模型列表失败: %w", err)
 	}
@@ -66,22 +122,37 @@ func (s *SiliconServer) CreateChatCompletion(ctx context.Context, req *proto.Cha
 		Stream:           req.Stream,
 		MaxTokens:        int(req.MaxTokens),
 		Stop:             req.Stop,
-		Temperature:      float64(req.Temperature),
 		TopP:             float64(req.TopP),
 		TopK:             int(req.TopK),
 		FrequencyPenalty: float64(req.FrequencyPenalty),
 		N:                int(req.N),
-		ResponseFormat: &siliconproxy.ResponseFormat{
+		Tools:            tools,
+	}
+	if req.Temperature != nil {
+		chatReq.Temperature = siliconproxy.Float64(float64(*req.Temperature))
+	}
+	if req.ResponseFormat != nil {
+		chatReq.ResponseFormat = &siliconproxy.ResponseFormat{
 			Type: req.ResponseFormat.Type,
-		},
-		Tools: tools,
+		}
 	}
 
-	// 调用API
-	chatResp, err := s.sp.CreateChatCompletion(chatReq)
+	return s.complete(ctx, chatReq)
+}
+
+// 调用上游对话接口并转换响应，启用语义缓存时先查询缓存
+func (s *SiliconServer) complete(ctx context.Context, chatReq *siliconproxy.ChatCompletionRequest) (*proto.ChatCompletionResponse, error) {
+	var chatResp *siliconproxy.ChatCompletionResponse
+	var err error
+	if c := s.semantic(ctx); c != nil {
+		chatResp, err = c.CreateChatCompletion(ctx, chatReq)
+	} else {
+		chatResp, err = s.proxy(ctx).CreateChatCompletion(ctx, chatReq)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("创建对话失败: %w", err)
 	}
+	setCacheHeader(ctx, chatResp.CacheStatus)
 
 	// 转换响应
 	choices := make([]*proto.Choice, len(chatResp.Choices))
@@ -110,3 +181,69 @@ func (s *SiliconServer) CreateChatCompletion(ctx context.Context, req *proto.Cha
 
 	return response, nil
 }
+
+// CreateEmbedding 创建文本嵌入
+func (s *SiliconServer) CreateEmbedding(ctx context.Context, req *proto.EmbeddingRequest) (*proto.EmbeddingResponse, error) {
+	resp, err := s.proxy(ctx).CreateEmbedding(ctx, &siliconproxy.EmbeddingRequest{
+		Model:      req.Model,
+		Input:      req.Input,
+		Dimensions: int(req.Dimensions),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("创建嵌入失败: %w", err)
+	}
+	setCacheHeader(ctx, resp.CacheStatus)
+
+	response := &proto.EmbeddingResponse{
+		Model: resp.Model,
+		Usage: &proto.Usage{
+			PromptTokens: int32(resp.Usage.PromptTokens),
+			TotalTokens:  int32(resp.Usage.TotalTokens),
+		},
+	}
+	for _, d := range resp.Data {
+		embedding := make([]float32, len(d.Embedding))
+		for i, v := range d.Embedding {
+			embedding[i] = float32(v)
+		}
+		response.Data = append(response.Data, &proto.Embedding{Index: int32(d.Index), Embedding: embedding})
+	}
+	return response, nil
+}
+
+// CreateRerank 文档重排序
+func (s *SiliconServer) CreateRerank(ctx context.Context, req *proto.RerankRequest) (*proto.RerankResponse, error) {
+	resp, err := s.proxy(ctx).CreateRerank(ctx, &siliconproxy.RerankRequest{
+		Model:     req.Model,
+		Query:     req.Query,
+		Documents: req.Documents,
+		TopN:      int(req.TopN),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("重排序失败: %w", err)
+	}
+	setCacheHeader(ctx, resp.CacheStatus)
+
+	response := &proto.RerankResponse{
+		Id:    resp.ID,
+		Model: resp.Model,
+		Usage: &proto.Usage{
+			PromptTokens: int32(resp.Usage.PromptTokens),
+			TotalTokens:  int32(resp.Usage.TotalTokens),
+		},
+	}
+	for _, r := range resp.Results {
+		response.Results = append(response.Results, &proto.RerankResult{
+			Index:          int32(r.Index),
+			RelevanceScore: r.RelevanceScore,
+		})
+	}
+	return response, nil
+}
+
+// 在响应头中报告缓存状态，未经过缓存时不设置
+func setCacheHeader(ctx context.Context, status string) {
+	if status != "" {
+		grpclib.SetHeader(ctx, metadata.Pairs(CacheHeader, status))
+	}
+}
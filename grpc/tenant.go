@@ -0,0 +1,194 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/kriswu/go_deepseek/siliconproxy"
+	"github.com/kriswu/go_deepseek/voiceregistry"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Tenant 表示一个租户
+// 客户端通过authorization: Bearer <APIKey>标识，也可以通过双向TLS的客户端证书主题标识
+type Tenant struct {
+	Name         string
+	APIKey       string
+	CertSubjects []string // 匹配客户端证书的CN或完整DN
+	// 租户使用的上游代理，为nil时使用服务默认代理
+	Proxy *siliconproxy.SiliconProxy
+}
+
+type tenantKey struct{}
+
+// 租户表，整体替换以保证请求看到一致的配置
+type tenantTable struct {
+	byKey     map[string]*Tenant
+	bySubject map[string]*Tenant
+	byName    map[string]*Tenant
+	require   bool
+}
+
+// SetTenants 配置租户，require为true时拒绝未携带租户密钥的请求
+// 可在运行中调用，新表原子替换旧表，已在处理的请求继续使用旧租户配置
+func (s *SiliconServer) SetTenants(tenants []Tenant, require bool) {
+	table := &tenantTable{
+		byKey:     make(map[string]*Tenant, len(tenants)),
+		bySubject: make(map[string]*Tenant),
+		byName:    make(map[string]*Tenant, len(tenants)),
+		require:   require,
+	}
+	for i := range tenants {
+		t := tenants[i]
+		if t.Proxy == nil {
+			t.Proxy = s.sp
+		}
+		// 租户间缓存互相隔离
+		t.Proxy = t.Proxy.WithTenant(t.Name)
+		table.byName[t.Name] = &t
+		if t.APIKey != "" {
+			table.byKey[t.APIKey] = &t
+		}
+		for _, subject := range t.CertSubjects {
+			table.bySubject[subject] = &t
+		}
+	}
+	s.tenants.Store(table)
+}
+
+// UnaryInterceptor 返回识别租户的一元拦截器
+func (s *SiliconServer) UnaryInterceptor() grpclib.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpclib.UnaryServerInfo, handler grpclib.UnaryHandler) (any, error) {
+		ctx, err := s.authenticate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor 返回识别租户的流式拦截器
+func (s *SiliconServer) StreamInterceptor() grpclib.StreamServerInterceptor {
+	return func(srv any, ss grpclib.ServerStream, _ *grpclib.StreamServerInfo, handler grpclib.StreamHandler) error {
+		ctx, err := s.authenticate(ss.Context())
+		if err != nil {
+			return err
+		}
+		return handler(srv, &tenantStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// 识别租户并写入上下文，authorization元数据优先于客户端证书
+func (s *SiliconServer) authenticate(ctx context.Context) (context.Context, error) {
+	var key string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("authorization"); len(values) > 0 {
+			key = strings.TrimSpace(strings.TrimPrefix(values[0], "Bearer "))
+		}
+	}
+	table := s.tenants.Load()
+	if table == nil || (len(table.byKey) == 0 && len(table.bySubject) == 0) {
+		// 未配置租户时忽略authorization
+		return ctx, nil
+	}
+
+	if key != "" {
+		t, ok := table.byKey[key]
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "租户密钥无效")
+		}
+		return context.WithValue(ctx, tenantKey{}, t), nil
+	}
+	if t := table.fromPeer(ctx); t != nil {
+		return context.WithValue(ctx, tenantKey{}, t), nil
+	}
+	if table.require {
+		return nil, status.Error(codes.Unauthenticated, "缺少租户密钥或客户端证书")
+	}
+	return ctx, nil
+}
+
+// 根据已校验的客户端证书查找租户
+func (table *tenantTable) fromPeer(ctx context.Context) *Tenant {
+	if len(table.bySubject) == 0 {
+		return nil
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	subject := info.State.VerifiedChains[0][0].Subject
+	if t, ok := table.bySubject[subject.String()]; ok {
+		return t
+	}
+	return table.bySubject[subject.CommonName]
+}
+
+// 返回请求所属租户，未识别租户时返回nil
+func tenantFromContext(ctx context.Context) *Tenant {
+	t, _ := ctx.Value(tenantKey{}).(*Tenant)
+	return t
+}
+
+// 返回请求所属租户的名称，未识别租户时返回空字符串
+func tenantName(ctx context.Context) string {
+	if t := tenantFromContext(ctx); t != nil {
+		return t.Name
+	}
+	return ""
+}
+
+// TenantProxy 返回指定租户当前使用的上游代理，租户不存在时返回nil
+func (s *SiliconServer) TenantProxy(name string) *siliconproxy.SiliconProxy {
+	table := s.tenants.Load()
+	if table == nil {
+		return nil
+	}
+	if t, ok := table.byName[name]; ok {
+		return t.Proxy
+	}
+	return nil
+}
+
+// 返回请求应使用的上游代理
+func (s *SiliconServer) proxy(ctx context.Context) *siliconproxy.SiliconProxy {
+	if t := tenantFromContext(ctx); t != nil {
+		return t.Proxy
+	}
+	return s.sp
+}
+
+// 返回请求应使用的语义缓存，未启用时返回nil
+func (s *SiliconServer) semantic(ctx context.Context) *siliconproxy.SemanticCache {
+	if s.semanticCache == nil {
+		return nil
+	}
+	if t := tenantFromContext(ctx); t != nil {
+		return s.semanticCache.WithProxy(t.Proxy)
+	}
+	return s.semanticCache
+}
+
+// 返回请求所属租户的音色注册表视图，调用前需确认已启用音色管理
+func (s *SiliconServer) voiceRegistry(ctx context.Context) *voiceregistry.Registry {
+	return s.voices.WithTenant(tenantName(ctx), s.proxy(ctx))
+}
+
+// 替换上下文的服务端流
+type tenantStream struct {
+	grpclib.ServerStream
+	ctx context.Context
+}
+
+func (ts *tenantStream) Context() context.Context {
+	return ts.ctx
+}
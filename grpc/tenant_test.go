@@ -0,0 +1,154 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/kriswu/go_deepseek/siliconproxy"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// 构造携带已校验客户端证书的上下文
+func withClientCert(ctx context.Context, subject pkix.Name, verified bool) context.Context {
+	cert := &x509.Certificate{Subject: subject}
+	state := tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+	if verified {
+		state.VerifiedChains = [][]*x509.Certificate{{cert}}
+	}
+	return peer.NewContext(ctx, &peer.Peer{AuthInfo: credentials.TLSInfo{State: state}})
+}
+
+func withAuthorization(ctx context.Context, value string) context.Context {
+	return metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", value))
+}
+
+func TestAuthenticate(t *testing.T) {
+	tenants := []Tenant{
+		{Name: "team-a", APIKey: "key-a"},
+		{Name: "team-b", CertSubjects: []string{"team-b"}},
+		{Name: "team-c", APIKey: "key-c", CertSubjects: []string{"CN=team-c,O=example"}},
+	}
+	teamB := pkix.Name{CommonName: "team-b"}
+	teamC := pkix.Name{CommonName: "team-c", Organization: []string{"example"}}
+
+	tests := []struct {
+		name       string
+		tenants    []Tenant
+		require    bool
+		ctx        context.Context
+		wantTenant string // 为空表示不属于任何租户
+		wantCode   codes.Code
+	}{
+		{
+			name: "未配置租户时忽略密钥",
+			ctx:  withAuthorization(context.Background(), "Bearer anything"),
+		},
+		{
+			name:       "Bearer密钥",
+			tenants:    tenants,
+			ctx:        withAuthorization(context.Background(), "Bearer key-a"),
+			wantTenant: "team-a",
+		},
+		{
+			name:       "不带Bearer前缀的密钥",
+			tenants:    tenants,
+			ctx:        withAuthorization(context.Background(), " key-a "),
+			wantTenant: "team-a",
+		},
+		{
+			name:     "密钥无效",
+			tenants:  tenants,
+			ctx:      withAuthorization(context.Background(), "Bearer wrong"),
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "密钥无效时不回退到证书",
+			tenants:  tenants,
+			ctx:      withAuthorization(withClientCert(context.Background(), teamB, true), "Bearer wrong"),
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:       "证书CN",
+			tenants:    tenants,
+			ctx:        withClientCert(context.Background(), teamB, true),
+			wantTenant: "team-b",
+		},
+		{
+			name:       "证书完整DN",
+			tenants:    tenants,
+			ctx:        withClientCert(context.Background(), teamC, true),
+			wantTenant: "team-c",
+		},
+		{
+			name:       "密钥优先于证书",
+			tenants:    tenants,
+			ctx:        withAuthorization(withClientCert(context.Background(), teamB, true), "Bearer key-c"),
+			wantTenant: "team-c",
+		},
+		{
+			name:    "未校验的证书不识别租户",
+			tenants: tenants,
+			ctx:     withClientCert(context.Background(), teamB, false),
+		},
+		{
+			name:    "匿名请求",
+			tenants: tenants,
+			ctx:     context.Background(),
+		},
+		{
+			name:     "要求租户时拒绝匿名请求",
+			tenants:  tenants,
+			require:  true,
+			ctx:      withClientCert(context.Background(), teamB, false),
+			wantCode: codes.Unauthenticated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSiliconServer(siliconproxy.NewSiliconProxy("default"))
+			s.SetTenants(tt.tenants, tt.require)
+
+			ctx, err := s.authenticate(tt.ctx)
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("错误为%v，期望状态码%v", err, tt.wantCode)
+			}
+			if err != nil {
+				return
+			}
+			var got string
+			if tenant := tenantFromContext(ctx); tenant != nil {
+				got = tenant.Name
+				if s.proxy(ctx) != tenant.Proxy {
+					t.Fatal("租户请求没有使用租户的代理")
+				}
+			} else if s.proxy(ctx) != s.sp {
+				t.Fatal("匿名请求没有使用默认代理")
+			}
+			if got != tt.wantTenant {
+				t.Fatalf("租户为%q，期望%q", got, tt.wantTenant)
+			}
+		})
+	}
+}
+
+func TestSetTenantsReplacesTable(t *testing.T) {
+	s := NewSiliconServer(siliconproxy.NewSiliconProxy("default"))
+	s.SetTenants([]Tenant{{Name: "old", APIKey: "key"}}, false)
+	s.SetTenants([]Tenant{{Name: "new", APIKey: "key"}}, false)
+
+	ctx, err := s.authenticate(withAuthorization(context.Background(), "Bearer key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tenant := tenantFromContext(ctx); tenant == nil || tenant.Name != "new" {
+		t.Fatalf("租户为%+v，期望new", tenant)
+	}
+}
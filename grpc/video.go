@@ -0,0 +1,76 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kriswu/go_deepseek/proto"
+	"github.com/kriswu/go_deepseek/siliconproxy"
+	"github.com/kriswu/go_deepseek/videojob"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SubmitVideo 提交视频生成任务，由任务管理器使用租户的上游代理轮询直到结束
+func (s *SiliconServer) SubmitVideo(ctx context.Context, req *proto.SubmitVideoRequest) (*proto.VideoJob, error) {
+	if s.videos == nil {
+		return nil, status.Error(codes.FailedPrecondition, "未启用视频任务")
+	}
+
+	videoReq := &siliconproxy.VideoSubmitRequest{
+		Model:          req.Model,
+		Prompt:         req.Prompt,
+		NegativePrompt: req.NegativePrompt,
+		ImageSize:      req.ImageSize,
+		Image:          req.Image,
+		Seed:           req.Seed,
+	}
+	if err := videoReq.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	job, err := s.videos.Submit(ctx, videoReq, videojob.SubmitOptions{
+		Webhook: req.Webhook,
+		Tenant:  tenantName(ctx),
+		Proxy:   s.proxy(ctx),
+	})
+	if errors.Is(err, videojob.ErrWebhookNotAllowed) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("提交视频任务失败: %w", err)
+	}
+	return toProtoVideoJob(job), nil
+}
+
+// GetVideoJob 查询视频任务状态
+func (s *SiliconServer) GetVideoJob(ctx context.Context, req *proto.GetVideoJobRequest) (*proto.VideoJob, error) {
+	if s.videos == nil {
+		return nil, status.Error(codes.FailedPrecondition, "未启用视频任务")
+	}
+
+	job, ok := s.videos.Get(req.Id)
+	// 其他租户的任务按不存在处理，不暴露任务是否存在
+	if !ok || job.Tenant != tenantName(ctx) {
+		return nil, status.Error(codes.NotFound, fmt.Errorf("%w: %s", videojob.ErrJobNotFound, req.Id).Error())
+	}
+	return toProtoVideoJob(&job), nil
+}
+
+func toProtoVideoJob(job *videojob.Job) *proto.VideoJob {
+	pj := &proto.VideoJob{
+		Id:          job.ID,
+		Model:       job.Request.Model,
+		Prompt:      job.Request.Prompt,
+		Status:      job.Status,
+		Reason:      job.Reason,
+		VideoUrl:    job.VideoURL,
+		LocalPath:   job.LocalPath,
+		SubmittedAt: job.SubmittedAt.Unix(),
+	}
+	if !job.CompletedAt.IsZero() {
+		pj.CompletedAt = job.CompletedAt.Unix()
+	}
+	return pj
+}
@@ -0,0 +1,127 @@
+package healthcheck
+
+import (
+	"context"
+	"expvar"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/kriswu/go_deepseek/siliconproxy"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// 默认探测参数
+const (
+	DefaultInterval         = 30 * time.Second
+	DefaultFailureThreshold = 3
+)
+
+// 通过expvar导出的指标
+var (
+	upstreamHealthyMetric = expvar.NewInt("silicon_upstream_healthy") // 1表示可用
+	probeFailuresMetric   = expvar.NewInt("silicon_upstream_probe_failures")
+	probeLatencyMsMetric  = expvar.NewInt("silicon_upstream_probe_latency_ms")
+)
+
+// Config 表示上游探测配置
+type Config struct {
+	Interval         time.Duration // 探测间隔，默认30秒
+	FailureThreshold int           // 连续失败多少次后标记为不可用，默认3次
+	Services         []string      // 除整体状态""外需要同步状态的服务名
+}
+
+// Prober 定期请求上游/models，根据结果更新gRPC健康状态
+// 连续失败达到阈值时置为NOT_SERVING，任意一次成功即恢复SERVING
+type Prober struct {
+	sp     *siliconproxy.SiliconProxy
+	health *health.Server
+	conf   Config
+
+	mu       sync.Mutex
+	failures int
+	healthy  bool
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// New 创建上游探测器，初始状态为SERVING
+func New(sp *siliconproxy.SiliconProxy, hs *health.Server, conf Config) *Prober {
+	if conf.Interval <= 0 {
+		conf.Interval = DefaultInterval
+	}
+	if conf.FailureThreshold <= 0 {
+		conf.FailureThreshold = DefaultFailureThreshold
+	}
+	p := &Prober{sp: sp, health: hs, conf: conf, healthy: true, stop: make(chan struct{})}
+	p.setStatus(healthpb.HealthCheckResponse_SERVING)
+	upstreamHealthyMetric.Set(1)
+	return p
+}
+
+// Start 在后台开始探测，立即执行第一次探测
+func (p *Prober) Start() {
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+
+		ticker := time.NewTicker(p.conf.Interval)
+		defer ticker.Stop()
+		for {
+			p.ProbeOnce()
+			select {
+			case <-p.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop 停止探测并等待后台goroutine退出
+func (p *Prober) Stop() {
+	close(p.stop)
+	p.wg.Wait()
+}
+
+// ProbeOnce 探测上游一次，返回上游当前是否可用
+func (p *Prober) ProbeOnce() bool {
+	start := time.Now()
+	_, err := p.sp.GetModelList(context.Background())
+	probeLatencyMsMetric.Set(time.Since(start).Milliseconds())
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err == nil {
+		p.failures = 0
+		if !p.healthy {
+			log.Printf("上游恢复可用")
+			p.healthy = true
+			p.setStatus(healthpb.HealthCheckResponse_SERVING)
+			upstreamHealthyMetric.Set(1)
+		}
+		return true
+	}
+
+	p.failures++
+	probeFailuresMetric.Add(1)
+	log.Printf("上游探测失败（连续%d次）: %v", p.failures, err)
+	if p.healthy && p.failures >= p.conf.FailureThreshold {
+		log.Printf("上游连续%d次探测失败，标记为不可用", p.failures)
+		p.healthy = false
+		p.setStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+		upstreamHealthyMetric.Set(0)
+	}
+	return p.healthy
+}
+
+// 更新整体状态和各服务状态
+func (p *Prober) setStatus(status healthpb.HealthCheckResponse_ServingStatus) {
+	p.health.SetServingStatus("", status)
+	for _, service := range p.conf.Services {
+		p.health.SetServingStatus(service, status)
+	}
+}
@@ -1,10 +1,13 @@
 package httpclient
 
 import (
-	"bytes"
+	"context"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -15,19 +18,55 @@ type Response struct {
 	Body       string
 }
 
+// StreamResponse 表示响应体未读取的HTTP响应，调用方负责关闭Body
+type StreamResponse struct {
+	StatusCode int
+	Headers    http.Header
+	Body       io.ReadCloser
+}
+
+// MultipartFile 表示multipart表单中的一个文件
+type MultipartFile struct {
+	FieldName string
+	FileName  string
+	Reader    io.Reader
+}
+
+// DefaultTimeout 是普通请求的总超时，也是流式请求等待响应头的超时
+const DefaultTimeout = 100 * time.Second
+
 // Client 是HTTP客户端
 type Client struct {
+	// 普通请求使用，受总超时限制
 	httpClient *http.Client
-	headers    map[string]string
+	// 流式响应和下载使用，没有总超时，只限制等待响应头的时间，
+	// 响应体读取时长由调用方的上下文控制，避免长回答或大文件被中途截断
+	streamClient *http.Client
+	headers      map[string]string
+
+	timeout   time.Duration
+	transport http.RoundTripper // 为nil时使用默认传输
+
+	// Close时取消，所有进行中的请求都会随之取消
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
 // ClientOption 定义客户端选项函数类型
 type ClientOption func(*Client)
 
-// WithTimeout 设置超时时间
+// WithTimeout 设置普通请求的总超时和流式请求等待响应头的超时
 func WithTimeout(timeout time.Duration) ClientOption {
 	return func(c *Client) {
-		c.httpClient.Timeout = timeout
+		c.timeout = timeout
+	}
+}
+
+// WithTransport 替换底层传输，用于录制回放或测试
+// 自定义传输需要自行限制等待响应头的时间
+func WithTransport(rt http.RoundTripper) ClientOption {
+	return func(c *Client) {
+		c.transport = rt
 	}
 }
 
@@ -41,20 +80,32 @@ func WithHeader(key, value string) ClientOption {
 // NewClient 创建新的HTTP客户端
 func NewClient(options ...ClientOption) *Client {
 	client := &Client{
-		httpClient: &http.Client{
-			Timeout: 100 * time.Second,
-		},
 		headers: make(map[string]string),
+		timeout: DefaultTimeout,
 	}
+	client.ctx, client.cancel = context.WithCancel(context.Background())
 
 	// 应用选项
 	for _, option := range options {
 		option(client)
 	}
 
+	client.httpClient = &http.Client{Timeout: client.timeout, Transport: client.transport}
+	streamTransport := client.transport
+	if streamTransport == nil {
+		t := http.DefaultTransport.(*http.Transport).Clone()
+		t.ResponseHeaderTimeout = client.timeout
+		streamTransport = t
+	}
+	client.streamClient = &http.Client{Transport: streamTransport}
 	return client
 }
 
+// Close 取消所有进行中的请求，之后发出的请求会立即失败
+func (c *Client) Close() {
+	c.cancel()
+}
+
 // 设置请求头
 func (c *Client) setHeaders(req *http.Request) {
 	for key, value := range c.headers {
@@ -78,106 +129,167 @@ func (c *Client) handleResponse(resp *http.Response) (*Response, error) {
 	}, nil
 }
 
-// Get 发送GET请求
-func (c *Client) Get(url string) (*Response, error) {
-	req, err := http.NewRequest("GET", url, nil)
+// 请求上下文，调用方取消或客户端Close时都会取消请求，请求结束后调用release释放
+func (c *Client) requestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	stop := context.AfterFunc(c.ctx, cancel)
+	return ctx, func() {
+		stop()
+		cancel()
+	}
+}
+
+// 创建请求，token为空时不设置Authorization
+func (c *Client) newRequest(ctx context.Context, method, url, contentType string, body io.Reader, token string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return nil, fmt.Errorf("创建请求失败: %w", err)
 	}
-
 	c.setHeaders(req)
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("发送请求失败: %w", err)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
 	}
-
-	return c.handleResponse(resp)
+	if token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	}
+	return req, nil
 }
 
-// GetWithAuth 发送带有Authorization的GET请求
-func (c *Client) GetWithAuth(url, token string) (*Response, error) {
-	req, err := http.NewRequest("GET", url, nil)
+// 发送请求并读取完整响应
+func (c *Client) do(ctx context.Context, method, url, contentType string, body io.Reader, token string) (*Response, error) {
+	ctx, release := c.requestContext(ctx)
+	defer release()
+
+	req, err := c.newRequest(ctx, method, url, contentType, body, token)
 	if err != nil {
-		return nil, fmt.Errorf("创建请求失败: %w", err)
+		return nil, err
 	}
-
-	c.setHeaders(req)
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("发送请求失败: %w", err)
 	}
-
 	return c.handleResponse(resp)
 }
 
-// Post 发送POST请求
-func (c *Client) Post(url, contentType, body string) (*Response, error) {
-	req, err := http.NewRequest("POST", url, bytes.NewBufferString(body))
+// 发送请求并返回未读取的响应体，关闭响应体时释放请求上下文
+func (c *Client) doStream(ctx context.Context, method, url, contentType string, body io.Reader, token string) (*StreamResponse, error) {
+	ctx, release := c.requestContext(ctx)
+
+	req, err := c.newRequest(ctx, method, url, contentType, body, token)
 	if err != nil {
-		return nil, fmt.Errorf("创建请求失败: %w", err)
+		release()
+		return nil, err
 	}
-
-	req.Header.Set("Content-Type", contentType)
-	c.setHeaders(req)
-
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.streamClient.Do(req)
 	if err != nil {
+		release()
 		return nil, fmt.Errorf("发送请求失败: %w", err)
 	}
+	return &StreamResponse{
+		StatusCode: resp.StatusCode,
+		Headers:    resp.Header,
+		Body:       &releasingBody{ReadCloser: resp.Body, release: release},
+	}, nil
+}
 
-	return c.handleResponse(resp)
+type releasingBody struct {
+	io.ReadCloser
+	release context.CancelFunc
 }
 
-// PostWithAuth 发送带有Authorization的POST请求
-func (c *Client) PostWithAuth(url, contentType, body, token string) (*Response, error) {
-	req, err := http.NewRequest("POST", url, bytes.NewBufferString(body))
-	if err != nil {
-		return nil, fmt.Errorf("创建请求失败: %w", err)
-	}
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
 
-	req.Header.Set("Content-Type", contentType)
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
-	c.setHeaders(req)
+// Get 发送GET请求
+func (c *Client) Get(ctx context.Context, url string) (*Response, error) {
+	return c.do(ctx, "GET", url, "", nil, "")
+}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("发送请求失败: %w", err)
-	}
+// GetStream 发送GET请求，返回未读取的响应体
+// 用于下载文件等需要限制或流式读取响应体的场景，读取时长由ctx控制
+func (c *Client) GetStream(ctx context.Context, url string) (*StreamResponse, error) {
+	return c.doStream(ctx, "GET", url, "", nil, "")
+}
 
-	return c.handleResponse(resp)
+// GetWithAuth 发送带有Authorization的GET请求
+func (c *Client) GetWithAuth(ctx context.Context, url, token string) (*Response, error) {
+	return c.do(ctx, "GET", url, "", nil, token)
 }
 
-// Put 发送PUT请求
-func (c *Client) Put(url, contentType, body string) (*Response, error) {
-	req, err := http.NewRequest("PUT", url, bytes.NewBufferString(body))
-	if err != nil {
-		return nil, fmt.Errorf("创建请求失败: %w", err)
-	}
+// Post 发送POST请求
+func (c *Client) Post(ctx context.Context, url, contentType, body string) (*Response, error) {
+	return c.do(ctx, "POST", url, contentType, strings.NewReader(body), "")
+}
 
-	req.Header.Set("Content-Type", contentType)
-	c.setHeaders(req)
+// PostWithAuth 发送带有Authorization的POST请求
+func (c *Client) PostWithAuth(ctx context.Context, url, contentType, body, token string) (*Response, error) {
+	return c.do(ctx, "POST", url, contentType, strings.NewReader(body), token)
+}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("发送请求失败: %w", err)
-	}
+// PostStreamWithAuth 发送带有Authorization的POST请求，返回未读取的响应体
+// 用于SSE等流式响应，读取时长由ctx控制
+func (c *Client) PostStreamWithAuth(ctx context.Context, url, contentType, body, token string) (*StreamResponse, error) {
+	return c.doStream(ctx, "POST", url, contentType, strings.NewReader(body), token)
+}
 
-	return c.handleResponse(resp)
+// PostReaderWithAuth 发送带有Authorization的POST请求，请求体从body流式读取
+func (c *Client) PostReaderWithAuth(ctx context.Context, url, contentType string, body io.Reader, token string) (*Response, error) {
+	return c.do(ctx, "POST", url, contentType, body, token)
 }
 
-// Delete 发送DELETE请求
-func (c *Client) Delete(url string) (*Response, error) {
-	req, err := http.NewRequest("DELETE", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("创建请求失败: %w", err)
+// PostMultipartWithAuth 以multipart/form-data发送带有Authorization的POST请求
+// 表单边写边发送，文件内容从Reader流式读取，不会整体读入内存
+func (c *Client) PostMultipartWithAuth(ctx context.Context, url string, fields map[string]string, files []MultipartFile, token string) (*Response, error) {
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+	go func() {
+		pw.CloseWithError(writeMultipart(writer, fields, files))
+	}()
+
+	resp, err := c.PostReaderWithAuth(ctx, url, writer.FormDataContentType(), pr, token)
+	// 请求提前失败时让写入方退出
+	pr.Close()
+	return resp, err
+}
+
+// 写入multipart表单，字段按名称排序以保证输出稳定
+func writeMultipart(writer *multipart.Writer, fields map[string]string, files []MultipartFile) error {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := writer.WriteField(key, fields[key]); err != nil {
+			return fmt.Errorf("添加%s字段失败: %w", key, err)
+		}
 	}
 
-	c.setHeaders(req)
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("发送请求失败: %w", err)
+	for _, file := range files {
+		part, err := writer.CreateFormFile(file.FieldName, file.FileName)
+		if err != nil {
+			return fmt.Errorf("创建表单文件失败: %w", err)
+		}
+		if _, err := io.Copy(part, file.Reader); err != nil {
+			return fmt.Errorf("复制文件内容失败: %w", err)
+		}
 	}
 
-	return c.handleResponse(resp)
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("关闭writer失败: %w", err)
+	}
+	return nil
+}
+
+// Put 发送PUT请求
+func (c *Client) Put(ctx context.Context, url, contentType, body string) (*Response, error) {
+	return c.do(ctx, "PUT", url, contentType, strings.NewReader(body), "")
+}
+
+// Delete 发送DELETE请求
+func (c *Client) Delete(ctx context.Context, url string) (*Response, error) {
+	return c.do(ctx, "DELETE", url, "", nil, "")
 }
@@ -1,70 +1,423 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
+	"crypto/tls"
+	"errors"
+	"expvar"
+	"flag"
 	"fmt"
-	"io/ioutil"
 	"log"
 	"net"
-	"path/filepath"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/kriswu/go_deepseek/balancemonitor"
+	"github.com/kriswu/go_deepseek/cache"
+	"github.com/kriswu/go_deepseek/cli"
+	"github.com/kriswu/go_deepseek/config"
 	"github.com/kriswu/go_deepseek/grpc"
+	"github.com/kriswu/go_deepseek/healthcheck"
+	"github.com/kriswu/go_deepseek/httpclient"
+	"github.com/kriswu/go_deepseek/modelcatalog"
+	"github.com/kriswu/go_deepseek/prompttemplate"
 	"github.com/kriswu/go_deepseek/proto"
+	"github.com/kriswu/go_deepseek/retrieval"
 	"github.com/kriswu/go_deepseek/siliconproxy"
+	"github.com/kriswu/go_deepseek/tlsconfig"
+	"github.com/kriswu/go_deepseek/videojob"
+	"github.com/kriswu/go_deepseek/voiceregistry"
 	grpclib "google.golang.org/grpc"
+	channelzservice "google.golang.org/grpc/channelz/service"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
-// 服务器配置结构体
-type ServerConfig struct {
-	URL      string `json:"url"`
-	Token    string `json:"token"`
-	GRPCPort int    `json:"grpc_port"`
+// 根据配置创建余额监控
+func newBalanceMonitor(conf *config.BalanceMonitorConfig, upstream config.UpstreamConfig) *balancemonitor.Monitor {
+	keys := []balancemonitor.Key{{Name: "default", Token: upstream.Token}}
+	if len(conf.Keys) > 0 {
+		keys = keys[:0]
+		for _, k := range conf.Keys {
+			keys = append(keys, balancemonitor.Key{Name: k.Name, Token: k.Token})
+		}
+	}
+
+	alerters := []balancemonitor.Alerter{balancemonitor.LogAlerter{}}
+	if conf.Webhook != "" {
+		alerters = append(alerters, balancemonitor.NewWebhookAlerter(conf.Webhook))
+	}
+
+	return balancemonitor.New(balancemonitor.Config{
+		Keys:      keys,
+		Interval:  time.Duration(conf.IntervalSeconds) * time.Second,
+		Threshold: conf.Threshold,
+		Alerters:  alerters,
+		BaseURL:   upstream.BaseURL,
+	})
 }
 
-// 加载服务器配置
-func loadServerConfig(configPath string) (*ServerConfig, error) {
-	absPath, err := filepath.Abs(configPath)
-	if err != nil {
-		return nil, fmt.Errorf("获取配置文件绝对路径失败: %w", err)
+// 根据配置创建缓存后端
+func newCache(conf *config.CacheConfig) (cache.Cache, error) {
+	switch conf.Backend {
+	case "", "memory":
+		return cache.NewMemoryCache(conf.Capacity), nil
+	case "disk":
+		return cache.NewDiskCache(conf.Dir)
+	default:
+		return nil, fmt.Errorf("不支持的缓存类型: %s", conf.Backend)
 	}
+}
 
-	data, err := ioutil.ReadFile(absPath)
-	if err != nil {
-		return nil, fmt.Errorf("读取配置文件失败: %w", err)
+// 根据配置创建上游代理
+func newProxy(conf *config.Config) (*siliconproxy.SiliconProxy, error) {
+	var options []httpclient.ClientOption
+	if conf.Upstream.TimeoutSeconds > 0 {
+		options = append(options, httpclient.WithTimeout(time.Duration(conf.Upstream.TimeoutSeconds)*time.Second))
+	}
+	sp := siliconproxy.NewSiliconProxy(conf.Upstream.Token, options...)
+	sp.SetBaseURL(conf.Upstream.BaseURL)
+	if conf.Cache != nil {
+		c, err := newCache(conf.Cache)
+		if err != nil {
+			return nil, fmt.Errorf("创建缓存失败: %w", err)
+		}
+		sp.EnableCache(c, time.Duration(conf.Cache.TTLSeconds)*time.Second)
 	}
+	return sp, nil
+}
 
-	var config ServerConfig
-	if err := json.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("解析配置文件失败: %w", err)
+// 根据配置创建租户列表
+func newTenants(conf *config.Config, sp *siliconproxy.SiliconProxy) []grpc.Tenant {
+	tenants := make([]grpc.Tenant, 0, len(conf.Tenants))
+	for _, t := range conf.Tenants {
+		tenant := grpc.Tenant{Name: t.Name, APIKey: t.APIKey, CertSubjects: t.CertSubjects}
+		if t.UpstreamToken != "" {
+			tenant.Proxy = sp.WithToken(t.UpstreamToken)
+		}
+		tenants = append(tenants, tenant)
 	}
+	return tenants
+}
 
-	return &config, nil
+// 根据配置加载TLS证书，返回的监视器在证书文件变化时重新加载证书
+func newTLSReloader(conf *config.TLSConfig) (*tlsconfig.Reloader, *config.Watcher, error) {
+	reloader, err := tlsconfig.New(tlsconfig.Config{
+		CertFile:          conf.CertFile,
+		KeyFile:           conf.KeyFile,
+		ClientCAFile:      conf.ClientCAFile,
+		RequireClientCert: conf.RequireClientCert,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	watcher := config.NewWatcher(0, func() {
+		// 证书和私钥可能先后写入，加载失败时等下一次变化再试
+		if err := reloader.Reload(); err != nil {
+			tlsReloadsMetric.Add("failure", 1)
+			log.Printf("重新加载TLS证书失败，继续使用原证书: %v", err)
+			return
+		}
+		tlsReloadsMetric.Add("success", 1)
+		log.Printf("TLS证书已重新加载")
+	})
+	watcher.SetPaths(reloader.Files())
+	return reloader, watcher, nil
+}
+
+// 根据配置创建gRPC服务器选项
+func serverOptions(limits config.LimitsConfig) []grpclib.ServerOption {
+	var opts []grpclib.ServerOption
+	if limits.MaxRecvMsgBytes > 0 {
+		opts = append(opts, grpclib.MaxRecvMsgSize(limits.MaxRecvMsgBytes))
+	}
+	if limits.MaxSendMsgBytes > 0 {
+		opts = append(opts, grpclib.MaxSendMsgSize(limits.MaxSendMsgBytes))
+	}
+	if limits.MaxConcurrentStreams > 0 {
+		opts = append(opts, grpclib.MaxConcurrentStreams(limits.MaxConcurrentStreams))
+	}
+	return opts
 }
 
 func main() {
+	// 命令行子命令直接调用上游API，不启动服务器
+	args := os.Args[1:]
+	if len(args) > 0 && cli.IsCommand(args[0]) {
+		if err := cli.Run(args, os.Stdin, os.Stdout, os.Stderr); err != nil {
+			if !errors.Is(err, flag.ErrHelp) {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", args[0], err)
+			}
+			os.Exit(1)
+		}
+		return
+	}
+	if len(args) > 0 && args[0] == "help" {
+		cli.Usage(os.Stdout)
+		return
+	}
+	if len(args) > 0 && args[0] == "serve" {
+		args = args[1:]
+	}
+
 	// 加载服务器配置
-	config, err := loadServerConfig("conf/server.conf")
+	loader, err := config.NewLoader(os.Args[0], args)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatalf("解析命令行参数失败: %v", err)
+	}
+	conf, err := loader.Load()
 	if err != nil {
 		log.Fatalf("加载配置失败: %v", err)
 	}
 
 	// 创建SiliconProxy实例
-	sp := siliconproxy.NewSiliconProxy(config.Token)
+	sp, err := newProxy(conf)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// 启动指标服务
+	var metricsServer *http.Server
+	if conf.Listen.Metrics != "" {
+		metricsServer = &http.Server{Addr: conf.Listen.Metrics}
+		go func() {
+			log.Printf("指标服务启动，监听地址: %s\n", conf.Listen.Metrics)
+			if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Printf("指标服务退出: %v", err)
+			}
+		}()
+	}
+
+	// 启动余额监控
+	var monitor *balancemonitor.Monitor
+	if conf.BalanceMonitor != nil {
+		monitor = newBalanceMonitor(conf.BalanceMonitor, conf.Upstream)
+		monitor.Start()
+	}
 
 	// 创建gRPC服务器
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", config.GRPCPort))
+	lis, err := net.Listen("tcp", conf.Listen.GRPC)
 	if err != nil {
 		log.Fatalf("监听端口失败: %v", err)
 	}
 
-	s := grpclib.NewServer()
-
 	// 注册服务
-	proto.RegisterSiliconServiceServer(s, grpc.NewSiliconServer(sp))
+	server := grpc.NewSiliconServer(sp)
+	if c := conf.SemanticCache; c != nil {
+		semanticCache := siliconproxy.NewSemanticCache(sp, siliconproxy.SemanticCacheConfig{
+			EmbeddingModel: c.EmbeddingModel,
+			Threshold:      c.Threshold,
+			TTL:            time.Duration(c.TTLSeconds) * time.Second,
+			MaxEntries:     c.MaxEntries,
+		})
+		expvar.Publish("silicon_semantic_cache", expvar.Func(func() any { return semanticCache.Stats() }))
+		server.SetSemanticCache(semanticCache)
+	}
+	if c := conf.Retrieval; c != nil {
+		store, err := retrieval.NewStore(sp, retrieval.Config{
+			Dir:            c.Dir,
+			EmbeddingModel: c.EmbeddingModel,
+			RerankModel:    c.RerankModel,
+			IndexType:      c.IndexType,
+			ChunkSize:      c.ChunkSize,
+			ChunkOverlap:   c.ChunkOverlap,
+		})
+		if err != nil {
+			log.Fatalf("创建检索存储失败: %v", err)
+		}
+		server.SetRetrievalStore(store)
+	}
+	if conf.VoiceRegistry != "" {
+		voices, err := voiceregistry.Open(sp, conf.VoiceRegistry)
+		if err != nil {
+			log.Fatalf("打开音色注册表失败: %v", err)
+		}
+		server.SetVoiceRegistry(voices)
+	}
+	var videos *videojob.Manager
+	if c := conf.VideoJobs; c != nil {
+		videos, err = videojob.NewManager(sp, videojob.Config{
+			Dir:          c.Dir,
+			DownloadDir:  c.DownloadDir,
+			MaxDownload:  c.MaxDownloadBytes,
+			PollInterval: time.Duration(c.PollIntervalSeconds) * time.Second,
+			Timeout:      time.Duration(c.TimeoutSeconds) * time.Second,
+			WebhookHosts: c.WebhookAllowedHosts,
+			TenantProxy:  server.TenantProxy,
+		})
+		if err != nil {
+			log.Fatalf("创建视频任务管理器失败: %v", err)
+		}
+		server.SetVideoJobs(videos)
+	}
+	var catalog *modelcatalog.Catalog
+	if c := conf.ModelCatalog; c != nil {
+		catalog, err = modelcatalog.New(sp, modelcatalog.Config{
+			CapabilitiesFile: c.CapabilitiesFile,
+			RefreshInterval:  time.Duration(c.RefreshIntervalSeconds) * time.Second,
+		})
+		if err != nil {
+			log.Fatalf("创建模型目录失败: %v", err)
+		}
+		server.SetModelCatalog(catalog)
+	}
+	prompts, err := prompttemplate.Load(conf.PromptTemplates.Settings())
+	if err != nil {
+		log.Fatalf("加载提示词模板失败: %v", err)
+	}
+	server.SetPromptRegistry(prompts)
+	server.SetTenants(newTenants(conf, sp), conf.RequireTenant)
+
+	opts := append(serverOptions(conf.Limits),
+		grpclib.ChainUnaryInterceptor(server.UnaryInterceptor()),
+		grpclib.ChainStreamInterceptor(server.StreamInterceptor()),
+	)
+	var tlsReloader *tlsconfig.Reloader
+	var tlsWatcher *config.Watcher
+	grpcOpts := append([]grpclib.ServerOption(nil), opts...)
+	if conf.Listen.TLS != nil {
+		tlsReloader, tlsWatcher, err = newTLSReloader(conf.Listen.TLS)
+		if err != nil {
+			log.Fatalf("加载TLS证书失败: %v", err)
+		}
+		tlsWatcher.Start()
+		grpcOpts = append(grpcOpts, grpclib.Creds(credentials.NewTLS(tlsReloader.TLSConfig())))
+	}
+	s := grpclib.NewServer(grpcOpts...)
+	proto.RegisterSiliconServiceServer(s, server)
+
+	// HTTP/JSON网关，与gRPC使用相同的证书
+	var gw *gateway
+	if conf.Listen.HTTP != "" {
+		var httpTLS *tls.Config
+		if tlsReloader != nil {
+			httpTLS = tlsReloader.TLSConfig("h2", "http/1.1")
+		}
+		gw, err = startGateway(conf.Listen.HTTP, server, opts, httpTLS)
+		if err != nil {
+			log.Fatalf("启动HTTP网关失败: %v", err)
+		}
+		log.Printf("HTTP网关启动，监听地址: %s\n", conf.Listen.HTTP)
+	}
+
+	// 健康检查，启用探测时随上游可用性变化，退出时置为NOT_SERVING
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(proto.SiliconService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(s, healthServer)
+	var prober *healthcheck.Prober
+	if c := conf.HealthProbe; c != nil {
+		prober = healthcheck.New(sp, healthServer, healthcheck.Config{
+			Interval:         time.Duration(c.IntervalSeconds) * time.Second,
+			FailureThreshold: c.FailureThreshold,
+			Services:         []string{proto.SiliconService_ServiceDesc.ServiceName},
+		})
+		prober.Start()
+	}
+
+	// 运维调试服务
+	if conf.Admin.Reflection {
+		reflection.Register(s)
+	}
+	if conf.Admin.Channelz {
+		channelzservice.RegisterChannelzServiceToServer(s)
+	}
+
+	// 配置文件或密钥文件变化、收到SIGHUP时重载配置
+	r := newReloader(loader, conf, sp, server, catalog, prompts, monitor)
+	r.watcher.Start()
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		for range hup {
+			r.reload("SIGHUP")
+		}
+	}()
 
 	// 启动服务
-	log.Printf("gRPC服务器启动，监听端口: %d\n", config.GRPCPort)
-	if err := s.Serve(lis); err != nil {
+	log.Printf("gRPC服务器启动，监听地址: %s，TLS: %v\n", conf.Listen.GRPC, conf.Listen.TLS != nil)
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- s.Serve(lis)
+	}()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGTERM, syscall.SIGINT)
+	select {
+	case err := <-serveErr:
 		log.Fatalf("服务启动失败: %v", err)
+	case sig := <-stop:
+		log.Printf("收到信号%v，开始优雅退出", sig)
+	}
+
+	// 标记为不可用并等待进行中的请求完成，网关与gRPC服务同时排空
+	healthServer.Shutdown()
+	drainTimeout := time.Duration(conf.Shutdown.DrainTimeoutSeconds) * time.Second
+	gwDone := make(chan struct{})
+	go func() {
+		defer close(gwDone)
+		if gw == nil {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+		defer cancel()
+		if err := gw.Shutdown(ctx); err != nil {
+			log.Printf("HTTP网关未能在%v内完成全部请求: %v", drainTimeout, err)
+		}
+	}()
+	if !drain(s, drainTimeout, sp.Close) {
+		log.Printf("%v内未完成全部请求，强制关闭连接", drainTimeout)
+	}
+	<-gwDone
+
+	// 取消仍在进行的上游请求，停止后台任务
+	// 磁盘缓存、音色注册表、检索集合和视频任务都在每次修改时同步写入临时文件再重命名，
+	// 没有需要刷新的缓冲数据，被中断的写入也不会留下不完整的文件
+	sp.Close()
+	r.watcher.Stop()
+	if tlsWatcher != nil {
+		tlsWatcher.Stop()
+	}
+	if prober != nil {
+		prober.Stop()
+	}
+	if videos != nil {
+		// 未结束的任务保存在任务目录中，下次启动时继续轮询
+		videos.Close()
+	}
+	r.stopMonitor()
+	if metricsServer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		metricsServer.Shutdown(ctx)
+		cancel()
+	}
+	log.Println("服务已退出")
+}
+
+// 停止接收新请求并等待进行中的请求完成
+// 超时后先调用cancel取消上游请求，使阻塞中的处理函数尽快返回，再强制关闭
+// 返回是否在超时前完成
+func drain(s *grpclib.Server, timeout time.Duration, cancel func()) bool {
+	done := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		cancel()
+		s.Stop()
+		<-done
+		return false
 	}
 }
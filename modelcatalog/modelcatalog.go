@@ -0,0 +1,221 @@
+package modelcatalog
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kriswu/go_deepseek/siliconproxy"
+)
+
+// 默认刷新间隔
+const DefaultRefreshInterval = time.Hour
+
+// 过期后刷新失败、继续使用旧列表的次数
+var staleServesMetric = expvar.NewInt("silicon_model_catalog_stale_serves")
+
+// 刷新时依次查询的类型组合，用于给模型打上类型标签
+var modelKinds = []siliconproxy.ModelListOptions{
+	{Type: "text", SubType: "chat"},
+	{Type: "text", SubType: "embedding"},
+	{Type: "text", SubType: "reranker"},
+	{Type: "image", SubType: "text-to-image"},
+	{Type: "image", SubType: "image-to-image"},
+	{Type: "audio", SubType: "speech-to-text"},
+	{Type: "audio", SubType: "text-to-speech"},
+	{Type: "video", SubType: "text-to-video"},
+	{Type: "video", SubType: "image-to-video"},
+}
+
+// Capabilities 表示模型能力与价格，来自本地能力文件
+type Capabilities struct {
+	ContextLength  int     `json:"context_length,omitempty"`
+	SupportsTools  bool    `json:"supports_tools,omitempty"`
+	SupportsVision bool    `json:"supports_vision,omitempty"`
+	SupportsJSON   bool    `json:"supports_json,omitempty"`
+	InputPrice     float64 `json:"input_price,omitempty"`  // 每百万输入token的价格（元）
+	OutputPrice    float64 `json:"output_price,omitempty"` // 每百万输出token的价格（元）
+}
+
+// Entry 表示目录中的一个模型
+type Entry struct {
+	ID       string
+	OwnedBy  string
+	Type     string
+	SubTypes []string // 同一模型可能属于多个子类型
+	Capabilities
+}
+
+// HasSubType 判断模型是否属于指定子类型
+func (e *Entry) HasSubType(subType string) bool {
+	for _, s := range e.SubTypes {
+		if s == subType {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter 表示目录查询条件，零值字段不参与过滤
+type Filter struct {
+	Type             string
+	SubType          string
+	SupportsTools    bool
+	SupportsVision   bool
+	SupportsJSON     bool
+	MinContextLength int
+}
+
+// Config 表示模型目录配置
+type Config struct {
+	CapabilitiesFile string        // 能力文件路径，JSON对象，键为模型ID
+	RefreshInterval  time.Duration // 缓存有效期，默认1小时
+}
+
+// Catalog 是带能力信息的模型目录，上游列表缓存到过期后再刷新
+type Catalog struct {
+	sp   *siliconproxy.SiliconProxy
+	conf Config
+
+	mu           sync.RWMutex
+	capabilities map[string]Capabilities
+	entries      []Entry
+	fetchedAt    time.Time
+}
+
+// New 创建模型目录并加载能力文件
+func New(sp *siliconproxy.SiliconProxy, conf Config) (*Catalog, error) {
+	if conf.RefreshInterval <= 0 {
+		conf.RefreshInterval = DefaultRefreshInterval
+	}
+	c := &Catalog{sp: sp, conf: conf, capabilities: map[string]Capabilities{}}
+	if conf.CapabilitiesFile != "" {
+		caps, err := LoadCapabilities(conf.CapabilitiesFile)
+		if err != nil {
+			return nil, err
+		}
+		c.capabilities = caps
+	}
+	return c, nil
+}
+
+// LoadCapabilities 读取能力文件
+func LoadCapabilities(path string) (map[string]Capabilities, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取模型能力文件失败: %w", err)
+	}
+	var caps map[string]Capabilities
+	if err := json.Unmarshal(data, &caps); err != nil {
+		return nil, fmt.Errorf("解析模型能力文件失败: %w", err)
+	}
+	return caps, nil
+}
+
+// List 返回符合条件的模型，缓存过期时先刷新
+// 刷新失败时继续使用过期的列表，只有从未成功刷新过时才返回错误
+func (c *Catalog) List(ctx context.Context, filter Filter) ([]Entry, error) {
+	c.mu.RLock()
+	populated := !c.fetchedAt.IsZero()
+	stale := time.Since(c.fetchedAt) > c.conf.RefreshInterval
+	c.mu.RUnlock()
+	if stale {
+		if err := c.Refresh(ctx); err != nil {
+			if !populated {
+				return nil, err
+			}
+			staleServesMetric.Add(1)
+			log.Printf("刷新模型目录失败，使用过期的模型列表: %v", err)
+		}
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var result []Entry
+	for _, e := range c.entries {
+		if filter.Type != "" && e.Type != filter.Type {
+			continue
+		}
+		if filter.SubType != "" && !e.HasSubType(filter.SubType) {
+			continue
+		}
+		if filter.SupportsTools && !e.SupportsTools {
+			continue
+		}
+		if filter.SupportsVision && !e.SupportsVision {
+			continue
+		}
+		if filter.SupportsJSON && !e.SupportsJSON {
+			continue
+		}
+		if filter.MinContextLength > 0 && e.ContextLength < filter.MinContextLength {
+			continue
+		}
+		result = append(result, e)
+	}
+	return result, nil
+}
+
+// Refresh 重新拉取上游模型列表，并按类型组合打上标签
+// 任何一次查询失败都视为刷新失败，保留当前模型列表，避免部分类型的模型从目录中消失
+func (c *Catalog) Refresh(ctx context.Context) error {
+	byID := make(map[string]*Entry)
+	for _, kind := range modelKinds {
+		list, err := c.sp.GetModelListWithOptions(ctx, kind)
+		if err != nil {
+			return fmt.Errorf("获取%s/%s模型列表失败: %w", kind.Type, kind.SubType, err)
+		}
+		for _, m := range list.Data {
+			e, ok := byID[m.ID]
+			if !ok {
+				e = &Entry{ID: m.ID, OwnedBy: m.OwnedBy, Type: kind.Type}
+				byID[m.ID] = e
+			}
+			if !e.HasSubType(kind.SubType) {
+				e.SubTypes = append(e.SubTypes, kind.SubType)
+			}
+		}
+	}
+
+	// 补充未被任何类型组合覆盖的模型
+	all, err := c.sp.GetModelList(ctx)
+	if err != nil {
+		return fmt.Errorf("获取模型列表失败: %w", err)
+	}
+	for _, m := range all.Data {
+		if _, ok := byID[m.ID]; !ok {
+			byID[m.ID] = &Entry{ID: m.ID, OwnedBy: m.OwnedBy}
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries := make([]Entry, 0, len(byID))
+	for _, e := range byID {
+		e.Capabilities = c.capabilities[e.ID]
+		entries = append(entries, *e)
+	}
+	sort.Slice(entries, func(a, b int) bool { return entries[a].ID < entries[b].ID })
+	c.entries = entries
+	c.fetchedAt = time.Now()
+	return nil
+}
+
+// SetCapabilities 替换能力信息，下次刷新前也会立即生效
+func (c *Catalog) SetCapabilities(caps map[string]Capabilities) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.capabilities = caps
+	for i := range c.entries {
+		c.entries[i].Capabilities = caps[c.entries[i].ID]
+	}
+}
@@ -0,0 +1,123 @@
+package modelcatalog
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kriswu/go_deepseek/siliconproxy"
+)
+
+// 模拟上游/models，down为true时返回503
+func newModelsUpstream(t *testing.T, down *atomic.Bool) *siliconproxy.SiliconProxy {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if down.Load() {
+			http.Error(w, `{"message":"unavailable"}`, http.StatusServiceUnavailable)
+			return
+		}
+		var data []map[string]string
+		switch r.URL.Query().Get("sub_type") {
+		case "chat", "":
+			data = append(data, map[string]string{"id": "chat-model", "object": "model", "owned_by": "x"})
+		case "embedding":
+			data = append(data, map[string]string{"id": "embed-model", "object": "model", "owned_by": "x"})
+		}
+		json.NewEncoder(w).Encode(map[string]any{"object": "list", "data": data})
+	}))
+	t.Cleanup(srv.Close)
+
+	sp := siliconproxy.NewSiliconProxy("token")
+	sp.SetBaseURL(srv.URL)
+	return sp
+}
+
+func TestListStaleCatalog(t *testing.T) {
+	tests := []struct {
+		name       string
+		populate   bool // 上游故障前先成功刷新一次
+		interval   time.Duration
+		wantErr    bool
+		wantModels int
+		wantStale  int64
+	}{
+		{name: "从未刷新成功时返回错误", wantErr: true},
+		{name: "过期后刷新失败使用旧列表", populate: true, interval: time.Nanosecond, wantModels: 2, wantStale: 1},
+		{name: "未过期时不刷新", populate: true, interval: time.Hour, wantModels: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var down atomic.Bool
+			c, err := New(newModelsUpstream(t, &down), Config{RefreshInterval: tt.interval})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.populate {
+				if err := c.Refresh(context.Background()); err != nil {
+					t.Fatal(err)
+				}
+			}
+			down.Store(true)
+
+			before := staleServesMetric.Value()
+			entries, err := c.List(context.Background(), Filter{})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("错误为%v，期望出错: %v", err, tt.wantErr)
+			}
+			if len(entries) != tt.wantModels {
+				t.Fatalf("返回%d个模型，期望%d个", len(entries), tt.wantModels)
+			}
+			if got := staleServesMetric.Value() - before; got != tt.wantStale {
+				t.Fatalf("过期使用次数增加%d，期望%d", got, tt.wantStale)
+			}
+		})
+	}
+}
+
+func TestRefreshPartialFailureKeepsCatalog(t *testing.T) {
+	var down atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		subType := r.URL.Query().Get("sub_type")
+		if down.Load() && subType == "embedding" {
+			http.Error(w, `{"message":"unavailable"}`, http.StatusServiceUnavailable)
+			return
+		}
+		var data []map[string]string
+		switch subType {
+		case "chat", "":
+			data = append(data, map[string]string{"id": "chat-model", "object": "model", "owned_by": "x"})
+		case "embedding":
+			data = append(data, map[string]string{"id": "embed-model", "object": "model", "owned_by": "x"})
+		}
+		json.NewEncoder(w).Encode(map[string]any{"object": "list", "data": data})
+	}))
+	defer srv.Close()
+	sp := siliconproxy.NewSiliconProxy("token")
+	sp.SetBaseURL(srv.URL)
+
+	c, err := New(sp, Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Refresh(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// 只有嵌入模型查询失败，刷新整体失败，嵌入模型不能从目录中消失
+	down.Store(true)
+	if err := c.Refresh(context.Background()); err == nil {
+		t.Fatal("部分类型查询失败时刷新应返回错误")
+	}
+	entries, err := c.List(context.Background(), Filter{SubType: "embedding"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].ID != "embed-model" {
+		t.Fatalf("嵌入模型为%+v，期望保留embed-model", entries)
+	}
+}
@@ -0,0 +1,227 @@
+package prompttemplate
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/kriswu/go_deepseek/siliconproxy"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrTemplateNotFound 表示模板或指定版本不存在
+var ErrTemplateNotFound = errors.New("提示词模板不存在")
+
+// 模板名称只允许字母、数字和._-，以便用作HTTP路径参数
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Variable 表示模板变量的声明
+type Variable struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+	Required    bool   `yaml:"required,omitempty"`
+	Default     string `yaml:"default,omitempty"`
+}
+
+// Message 表示模板中的一条消息，Content是text/template模板
+type Message struct {
+	Role    string `yaml:"role"`
+	Content string `yaml:"content"`
+}
+
+// Template 表示某个版本的提示词模板
+// 消息内容使用text/template语法，变量通过{{.name}}引用
+type Template struct {
+	Name        string            `yaml:"name"`
+	Version     string            `yaml:"version"`
+	Description string            `yaml:"description,omitempty"`
+	Metadata    map[string]string `yaml:"metadata,omitempty"` // 如owner、tags等，仅用于查询和分析
+
+	// 对话参数，零值表示使用模型默认值；温度未设置时使用默认值，可以显式设置为0
+	Model          string   `yaml:"model,omitempty"`
+	Temperature    *float64 `yaml:"temperature,omitempty"`
+	TopP           float64  `yaml:"top_p,omitempty"`
+	MaxTokens      int      `yaml:"max_tokens,omitempty"`
+	ResponseFormat string   `yaml:"response_format,omitempty"` // text或json_object
+
+	// 声明的变量，为空时不检查变量名
+	Variables []Variable `yaml:"variables,omitempty"`
+	Messages  []Message  `yaml:"messages"`
+
+	// 模板来源，文件路径或builtin:文件名
+	Source string `yaml:"-"`
+
+	parsed []*template.Template
+}
+
+// Parse 解析YAML或JSON格式的模板定义并编译消息模板
+func Parse(data []byte, source string) (*Template, error) {
+	var t Template
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&t); err != nil {
+		return nil, fmt.Errorf("解析提示词模板%s失败: %w", source, err)
+	}
+	t.Source = source
+	if err := t.compile(); err != nil {
+		return nil, fmt.Errorf("提示词模板%s无效: %w", source, err)
+	}
+	return &t, nil
+}
+
+// 校验模板定义并编译消息模板
+func (t *Template) compile() error {
+	if !namePattern.MatchString(t.Name) {
+		return fmt.Errorf("名称%q不合法", t.Name)
+	}
+	if t.Version == "" {
+		return errors.New("缺少version")
+	}
+	if len(t.Messages) == 0 {
+		return errors.New("至少需要一条消息")
+	}
+	seen := make(map[string]bool, len(t.Variables))
+	for _, v := range t.Variables {
+		if v.Name == "" {
+			return errors.New("变量名不能为空")
+		}
+		if seen[v.Name] {
+			return fmt.Errorf("变量%s重复声明", v.Name)
+		}
+		seen[v.Name] = true
+	}
+
+	t.parsed = make([]*template.Template, len(t.Messages))
+	for i, m := range t.Messages {
+		switch m.Role {
+		case "system", "user", "assistant":
+		default:
+			return fmt.Errorf("第%d条消息的角色%q不合法", i+1, m.Role)
+		}
+		tmpl, err := template.New(fmt.Sprintf("%s@%s#%d", t.Name, t.Version, i+1)).
+			Option("missingkey=error").Parse(m.Content)
+		if err != nil {
+			return fmt.Errorf("第%d条消息: %w", i+1, err)
+		}
+		t.parsed[i] = tmpl
+	}
+	return nil
+}
+
+// Render 使用变量渲染消息，未提供的变量使用默认值
+// 缺少必填变量或提供了未声明的变量时返回错误
+func (t *Template) Render(vars map[string]string) ([]siliconproxy.ChatCompletionMessage, error) {
+	data := make(map[string]any, len(t.Variables)+len(vars))
+	if len(t.Variables) > 0 {
+		declared := make(map[string]bool, len(t.Variables))
+		for _, v := range t.Variables {
+			declared[v.Name] = true
+			value, ok := vars[v.Name]
+			if !ok {
+				if v.Required {
+					return nil, fmt.Errorf("缺少必填变量%s", v.Name)
+				}
+				value = v.Default
+			}
+			data[v.Name] = value
+		}
+		var unknown []string
+		for name := range vars {
+			if !declared[name] {
+				unknown = append(unknown, name)
+			}
+		}
+		if len(unknown) > 0 {
+			sort.Strings(unknown)
+			return nil, fmt.Errorf("模板%s未声明变量: %s", t.Name, strings.Join(unknown, ", "))
+		}
+	} else {
+		for name, value := range vars {
+			data[name] = value
+		}
+	}
+
+	messages := make([]siliconproxy.ChatCompletionMessage, len(t.parsed))
+	for i, tmpl := range t.parsed {
+		var b strings.Builder
+		if err := tmpl.Execute(&b, data); err != nil {
+			return nil, fmt.Errorf("渲染提示词模板失败: %w", err)
+		}
+		messages[i] = siliconproxy.ChatCompletionMessage{Role: t.Messages[i].Role, Content: b.String()}
+	}
+	return messages, nil
+}
+
+// Request 渲染模板并构造聊天请求
+func (t *Template) Request(vars map[string]string) (*siliconproxy.ChatCompletionRequest, error) {
+	messages, err := t.Render(vars)
+	if err != nil {
+		return nil, err
+	}
+	req := &siliconproxy.ChatCompletionRequest{
+		Model:       t.Model,
+		Messages:    messages,
+		Temperature: t.Temperature,
+		TopP:        t.TopP,
+		MaxTokens:   t.MaxTokens,
+	}
+	if t.ResponseFormat != "" {
+		req.ResponseFormat = &siliconproxy.ResponseFormat{Type: t.ResponseFormat}
+	}
+	return req, nil
+}
+
+// VariableNames 返回声明的变量名
+func (t *Template) VariableNames() []string {
+	names := make([]string, len(t.Variables))
+	for i, v := range t.Variables {
+		names[i] = v.Name
+	}
+	return names
+}
+
+// CompareVersions 比较两个版本号，a小于、等于、大于b时分别返回-1、0、1
+// 版本号按"."分段比较，数字段按数值比较，其余按字符串比较，前缀v被忽略
+func CompareVersions(a, b string) int {
+	pa := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	pb := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var sa, sb string
+		if i < len(pa) {
+			sa = pa[i]
+		}
+		if i < len(pb) {
+			sb = pb[i]
+		}
+		if c := compareSegment(sa, sb); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+func compareSegment(a, b string) int {
+	if isDigits(a) && isDigits(b) {
+		a, b = strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+		if len(a) != len(b) {
+			if len(a) < len(b) {
+				return -1
+			}
+			return 1
+		}
+	}
+	return strings.Compare(a, b)
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
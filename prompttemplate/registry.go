@@ -0,0 +1,221 @@
+package prompttemplate
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// 内置模板，随程序一起发布
+//
+//go:embed builtin/*.yaml
+var builtinFS embed.FS
+
+// Summary 表示模板的版本概况
+type Summary struct {
+	Name     string
+	Versions []*Template // 按版本从低到高排序
+	Pinned   string      // 固定的版本，为空表示未固定
+}
+
+// Default 返回未指定版本时使用的模板：固定版本优先，否则为最新版本
+func (s *Summary) Default() *Template {
+	for _, t := range s.Versions {
+		if t.Version == s.Pinned {
+			return t
+		}
+	}
+	return s.Versions[len(s.Versions)-1]
+}
+
+// Registry 是提示词模板注册表，包含内置模板和模板目录中的文件
+// 目录中的模板与内置模板名称和版本相同时覆盖内置模板
+type Registry struct {
+	mu        sync.RWMutex
+	templates map[string][]*Template // 按版本从低到高排序
+	pins      map[string]string
+	files     []string
+}
+
+// Load 加载内置模板和dir中的模板并应用版本固定，dir为空时只加载内置模板
+func Load(dir string, pins map[string]string) (*Registry, error) {
+	r := &Registry{}
+	if err := r.load(dir, pins); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload 重新加载全部模板，失败时保留当前模板
+func (r *Registry) Reload(dir string, pins map[string]string) error {
+	next, err := Load(dir, pins)
+	if err != nil {
+		return err
+	}
+	r.Replace(next)
+	return nil
+}
+
+// Replace 用next中的模板替换当前模板，用于先加载校验、再与其他配置一起生效
+func (r *Registry) Replace(next *Registry) {
+	next.mu.RLock()
+	templates, pins, files := next.templates, next.pins, next.files
+	next.mu.RUnlock()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.templates = templates
+	r.pins = pins
+	r.files = files
+}
+
+// 加载全部模板到空的注册表
+func (r *Registry) load(dir string, pins map[string]string) error {
+	byKey := make(map[string]*Template)
+	if err := loadFS(builtinFS, "builtin", "builtin:", byKey); err != nil {
+		return err
+	}
+	var files []string
+	if dir != "" {
+		var err error
+		if files, err = loadDir(dir, byKey); err != nil {
+			return err
+		}
+	}
+
+	templates := make(map[string][]*Template)
+	for _, t := range byKey {
+		templates[t.Name] = append(templates[t.Name], t)
+	}
+	for _, versions := range templates {
+		sort.Slice(versions, func(a, b int) bool {
+			return CompareVersions(versions[a].Version, versions[b].Version) < 0
+		})
+	}
+	for name, version := range pins {
+		if !hasVersion(templates[name], version) {
+			return fmt.Errorf("固定的提示词模板版本%s@%s不存在", name, version)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.templates = templates
+	r.pins = pins
+	r.files = files
+	return nil
+}
+
+// 读取文件系统中的模板，key为名称@版本
+func loadFS(fsys fs.FS, root, prefix string, byKey map[string]*Template) error {
+	return fs.WalkDir(fsys, root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !isTemplateFile(p) {
+			return nil
+		}
+		data, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return fmt.Errorf("读取提示词模板失败: %w", err)
+		}
+		t, err := Parse(data, prefix+strings.TrimPrefix(p, root+"/"))
+		if err != nil {
+			return err
+		}
+		key := t.Name + "@" + t.Version
+		if prev, ok := byKey[key]; ok && !strings.HasPrefix(prev.Source, "builtin:") {
+			return fmt.Errorf("提示词模板%s在%s和%s中重复定义", key, prev.Source, t.Source)
+		}
+		byKey[key] = t
+		return nil
+	})
+}
+
+// 读取目录中的模板，返回读取的文件列表
+func loadDir(dir string, byKey map[string]*Template) ([]string, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, fmt.Errorf("读取提示词模板目录失败: %w", err)
+	}
+	if err := loadFS(os.DirFS(dir), ".", dir+string(filepath.Separator), byKey); err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, t := range byKey {
+		if !strings.HasPrefix(t.Source, "builtin:") {
+			files = append(files, t.Source)
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func isTemplateFile(p string) bool {
+	switch strings.ToLower(path.Ext(p)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
+
+func hasVersion(versions []*Template, version string) bool {
+	for _, t := range versions {
+		if t.Version == version {
+			return true
+		}
+	}
+	return false
+}
+
+// Get 返回指定版本的模板，version为空时返回固定版本，未固定时返回最新版本
+func (r *Registry) Get(name, version string) (*Template, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	versions := r.templates[name]
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrTemplateNotFound, name)
+	}
+	if version == "" {
+		version = r.pins[name]
+	}
+	if version == "" {
+		return versions[len(versions)-1], nil
+	}
+	for _, t := range versions {
+		if t.Version == version {
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s@%s", ErrTemplateNotFound, name, version)
+}
+
+// List 返回全部模板的版本概况，按名称排序
+func (r *Registry) List() []Summary {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]Summary, 0, len(r.templates))
+	for name, versions := range r.templates {
+		result = append(result, Summary{
+			Name:     name,
+			Versions: append([]*Template(nil), versions...),
+			Pinned:   r.pins[name],
+		})
+	}
+	sort.Slice(result, func(a, b int) bool { return result[a].Name < result[b].Name })
+	return result
+}
+
+// Files 返回从模板目录读取的文件，用于监视文件变化
+func (r *Registry) Files() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]string(nil), r.files...)
+}
@@ -0,0 +1,8 @@
+package proto
+
+import _ "embed"
+
+// OpenAPI 是由silicon.proto生成的OpenAPI v2文档，描述HTTP/JSON网关的接口
+//
+//go:embed silicon.swagger.json
+var OpenAPI []byte
@@ -7,6 +7,7 @@
 package proto
 
 import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -333,19 +334,20 @@ func (x *Tool) GetFunction() *FunctionObject {
 
 // 聊天请求
 type ChatCompletionRequest struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	Model            string                 `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
-	Messages         []*ChatMessage         `protobuf:"bytes,2,rep,name=messages,proto3" json:"messages,omitempty"`
-	Stream           bool                   `protobuf:"varint,3,opt,name=stream,proto3" json:"stream,omitempty"`
-	MaxTokens        int32                  `protobuf:"varint,4,opt,name=max_tokens,json=maxTokens,proto3" json:"max_tokens,omitempty"`
-	Stop             []string               `protobuf:"bytes,5,rep,name=stop,proto3" json:"stop,omitempty"`
-	Temperature      float32                `protobuf:"fixed32,6,opt,name=temperature,proto3" json:"temperature,omitempty"`
-	TopP             float32                `protobuf:"fixed32,7,opt,name=top_p,json=topP,proto3" json:"top_p,omitempty"`
-	TopK             int32                  `protobuf:"varint,8,opt,name=top_k,json=topK,proto3" json:"top_k,omitempty"`
-	FrequencyPenalty float32                `protobuf:"fixed32,9,opt,name=frequency_penalty,json=frequencyPenalty,proto3" json:"frequency_penalty,omitempty"`
-	N                int32                  `protobuf:"varint,10,opt,name=n,proto3" json:"n,omitempty"`
-	ResponseFormat   *ResponseFormat        `protobuf:"bytes,11,opt,name=response_format,json=responseFormat,proto3" json:"response_format,omitempty"`
-	Tools            []*Tool                `protobuf:"bytes,12,rep,name=tools,proto3" json:"tools,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Model     string                 `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	Messages  []*ChatMessage         `protobuf:"bytes,2,rep,name=messages,proto3" json:"messages,omitempty"`
+	Stream    bool                   `protobuf:"varint,3,opt,name=stream,proto3" json:"stream,omitempty"`
+	MaxTokens int32                  `protobuf:"varint,4,opt,name=max_tokens,json=maxTokens,proto3" json:"max_tokens,omitempty"`
+	Stop      []string               `protobuf:"bytes,5,rep,name=stop,proto3" json:"stop,omitempty"`
+	// 未设置时使用模型默认值，只有显式设置为0的非流式请求才会被缓存
+	Temperature      *float32        `protobuf:"fixed32,6,opt,name=temperature,proto3,oneof" json:"temperature,omitempty"`
+	TopP             float32         `protobuf:"fixed32,7,opt,name=top_p,json=topP,proto3" json:"top_p,omitempty"`
+	TopK             int32           `protobuf:"varint,8,opt,name=top_k,json=topK,proto3" json:"top_k,omitempty"`
+	FrequencyPenalty float32         `protobuf:"fixed32,9,opt,name=frequency_penalty,json=frequencyPenalty,proto3" json:"frequency_penalty,omitempty"`
+	N                int32           `protobuf:"varint,10,opt,name=n,proto3" json:"n,omitempty"`
+	ResponseFormat   *ResponseFormat `protobuf:"bytes,11,opt,name=response_format,json=responseFormat,proto3" json:"response_format,omitempty"`
+	Tools            []*Tool         `protobuf:"bytes,12,rep,name=tools,proto3" json:"tools,omitempty"`
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
@@ -416,8 +418,8 @@ func (x *ChatCompletionRequest) GetStop() []string {
 }
 
 func (x *ChatCompletionRequest) GetTemperature() float32 {
-	if x != nil {
-		return x.Temperature
+	if x != nil && x.Temperature != nil {
+		return *x.Temperature
 	}
 	return 0
 }
@@ -663,27 +665,30 @@ func (x *ChatCompletionResponse) GetUsage() *Usage {
 	return nil
 }
 
-// 空消息
-type Empty struct {
+// 嵌入请求
+type EmbeddingRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Model         string                 `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	Input         []string               `protobuf:"bytes,2,rep,name=input,proto3" json:"input,omitempty"`
+	Dimensions    int32                  `protobuf:"varint,3,opt,name=dimensions,proto3" json:"dimensions,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Empty) Reset() {
-	*x = Empty{}
+func (x *EmbeddingRequest) Reset() {
+	*x = EmbeddingRequest{}
 	mi := &file_proto_silicon_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Empty) String() string {
+func (x *EmbeddingRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Empty) ProtoMessage() {}
+func (*EmbeddingRequest) ProtoMessage() {}
 
-func (x *Empty) ProtoReflect() protoreflect.Message {
+func (x *EmbeddingRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_silicon_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -695,66 +700,3131 @@ func (x *Empty) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
-func (*Empty) Descriptor() ([]byte, []int) {
+// Deprecated: Use EmbeddingRequest.ProtoReflect.Descriptor instead.
+func (*EmbeddingRequest) Descriptor() ([]byte, []int) {
 	return file_proto_silicon_proto_rawDescGZIP(), []int{10}
 }
 
-var File_proto_silicon_proto protoreflect.FileDescriptor
+func (x *EmbeddingRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
 
-const file_proto_silicon_proto_rawDesc = "" +
-	"\n" +
-	"\x13proto/silicon.proto\x12\asilicon\"2\n" +
-	"\x05Model\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
-	"\bowned_by\x18\x02 \x01(\tR\aownedBy\":\n" +
-	"\x14GetModelListResponse\x12\"\n" +
-	"\x04data\x18\x01 \x03(\v2\x0e.silicon.ModelR\x04data\";\n" +
-	"\vChatMessage\x12\x12\n" +
-	"\x04role\x18\x01 \x01(\tR\x04role\x12\x18\n" +
-	"\acontent\x18\x02 \x01(\tR\acontent\"$\n" +
-	"\x0eResponseFormat\x12\x12\n" +
-	"\x04type\x18\x01 \x01(\tR\x04type\"^\n" +
-	"\x0eFunctionObject\x12 \n" +
-	"\vdescription\x18\x01 \x01(\tR\vdescription\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
-	"\x06strict\x18\x03 \x01(\bR\x06strict\"O\n" +
-	"\x04Tool\x12\x12\n" +
-	"\x04type\x18\x01 \x01(\tR\x04type\x123\n" +
-	"\bfunction\x18\x02 \x01(\v2\x17.silicon.FunctionObjectR\bfunction\"\x98\x03\n" +
-	"\x15ChatCompletionRequest\x12\x14\n" +
-	"\x05model\x18\x01 \x01(\tR\x05model\x120\n" +
-	"\bmessages\x18\x02 \x03(\v2\x14.silicon.ChatMessageR\bmessages\x12\x16\n" +
-	"\x06stream\x18\x03 \x01(\bR\x06stream\x12\x1d\n" +
-	"\n" +
-	"max_tokens\x18\x04 \x01(\x05R\tmaxTokens\x12\x12\n" +
-	"\x04stop\x18\x05 \x03(\tR\x04stop\x12 \n" +
-	"\vtemperature\x18\x06 \x01(\x02R\vtemperature\x12\x13\n" +
-	"\x05top_p\x18\a \x01(\x02R\x04topP\x12\x13\n" +
-	"\x05top_k\x18\b \x01(\x05R\x04topK\x12+\n" +
-	"\x11frequency_penalty\x18\t \x01(\x02R\x10frequencyPenalty\x12\f\n" +
-	"\x01n\x18\n" +
-	" \x01(\x05R\x01n\x12@\n" +
-	"\x0fresponse_format\x18\v \x01(\v2\x17.silicon.ResponseFormatR\x0eresponseFormat\x12#\n" +
-	"\x05tools\x18\f \x03(\v2\r.silicon.ToolR\x05tools\"N\n" +
-	"\x06Choice\x12.\n" +
-	"\amessage\x18\x01 \x01(\v2\x14.silicon.ChatMessageR\amessage\x12\x14\n" +
-	"\x05index\x18\x02 \x01(\x05R\x05index\"|\n" +
-	"\x05Usage\x12#\n" +
-	"\rprompt_tokens\x18\x01 \x01(\x05R\fpromptTokens\x12+\n" +
-	"\x11completion_tokens\x18\x02 \x01(\x05R\x10completionTokens\x12!\n" +
-	"\ftotal_tokens\x18\x03 \x01(\x05R\vtotalTokens\"\xc1\x01\n" +
-	"\x16ChatCompletionResponse\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
-	"\x06object\x18\x02 \x01(\tR\x06object\x12\x18\n" +
-	"\acreated\x18\x03 \x01(\x03R\acreated\x12\x14\n" +
-	"\x05model\x18\x04 \x01(\tR\x05model\x12)\n" +
-	"\achoices\x18\x05 \x03(\v2\x0f.silicon.ChoiceR\achoices\x12$\n" +
-	"\x05usage\x18\x06 \x01(\v2\x0e.silicon.UsageR\x05usage\"\a\n" +
-	"\x05Empty2\xac\x01\n" +
-	"\x0eSiliconService\x12?\n" +
-	"\fGetModelList\x12\x0e.silicon.Empty\x1a\x1d.silicon.GetModelListResponse\"\x00\x12Y\n" +
-	"\x14CreateChatCompletion\x12\x1e.silicon.ChatCompletionRequest\x1a\x1f.silicon.ChatCompletionResponse\"\x00B%Z#github.com/kriswu/go_deepseek/protob\x06proto3"
+func (x *EmbeddingRequest) GetInput() []string {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+func (x *EmbeddingRequest) GetDimensions() int32 {
+	if x != nil {
+		return x.Dimensions
+	}
+	return 0
+}
+
+// 单条文本的嵌入向量
+type Embedding struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Embedding     []float32              `protobuf:"fixed32,2,rep,packed,name=embedding,proto3" json:"embedding,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Embedding) Reset() {
+	*x = Embedding{}
+	mi := &file_proto_silicon_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Embedding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Embedding) ProtoMessage() {}
+
+func (x *Embedding) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_silicon_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Embedding.ProtoReflect.Descriptor instead.
+func (*Embedding) Descriptor() ([]byte, []int) {
+	return file_proto_silicon_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Embedding) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *Embedding) GetEmbedding() []float32 {
+	if x != nil {
+		return x.Embedding
+	}
+	return nil
+}
+
+// 嵌入响应
+type EmbeddingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Model         string                 `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	Data          []*Embedding           `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
+	Usage         *Usage                 `protobuf:"bytes,3,opt,name=usage,proto3" json:"usage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmbeddingResponse) Reset() {
+	*x = EmbeddingResponse{}
+	mi := &file_proto_silicon_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmbeddingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmbeddingResponse) ProtoMessage() {}
+
+func (x *EmbeddingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_silicon_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmbeddingResponse.ProtoReflect.Descriptor instead.
+func (*EmbeddingResponse) Descriptor() ([]byte, []int) {
+	return file_proto_silicon_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *EmbeddingResponse) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *EmbeddingResponse) GetData() []*Embedding {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *EmbeddingResponse) GetUsage() *Usage {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+// 重排序请求
+type RerankRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Model         string                 `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	Query         string                 `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	Documents     []string               `protobuf:"bytes,3,rep,name=documents,proto3" json:"documents,omitempty"`
+	TopN          int32                  `protobuf:"varint,4,opt,name=top_n,json=topN,proto3" json:"top_n,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RerankRequest) Reset() {
+	*x = RerankRequest{}
+	mi := &file_proto_silicon_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RerankRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RerankRequest) ProtoMessage() {}
+
+func (x *RerankRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_silicon_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RerankRequest.ProtoReflect.Descriptor instead.
+func (*RerankRequest) Descriptor() ([]byte, []int) {
+	return file_proto_silicon_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RerankRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *RerankRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *RerankRequest) GetDocuments() []string {
+	if x != nil {
+		return x.Documents
+	}
+	return nil
+}
+
+func (x *RerankRequest) GetTopN() int32 {
+	if x != nil {
+		return x.TopN
+	}
+	return 0
+}
+
+// 重排序结果
+type RerankResult struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Index          int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	RelevanceScore float64                `protobuf:"fixed64,2,opt,name=relevance_score,json=relevanceScore,proto3" json:"relevance_score,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RerankResult) Reset() {
+	*x = RerankResult{}
+	mi := &file_proto_silicon_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RerankResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RerankResult) ProtoMessage() {}
+
+func (x *RerankResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_silicon_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RerankResult.ProtoReflect.Descriptor instead.
+func (*RerankResult) Descriptor() ([]byte, []int) {
+	return file_proto_silicon_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RerankResult) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *RerankResult) GetRelevanceScore() float64 {
+	if x != nil {
+		return x.RelevanceScore
+	}
+	return 0
+}
+
+// 重排序响应
+type RerankResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Model         string                 `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
+	Results       []*RerankResult        `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`
+	Usage         *Usage                 `protobuf:"bytes,4,opt,name=usage,proto3" json:"usage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RerankResponse) Reset() {
+	*x = RerankResponse{}
+	mi := &file_proto_silicon_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RerankResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RerankResponse) ProtoMessage() {}
+
+func (x *RerankResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_silicon_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RerankResponse.ProtoReflect.Descriptor instead.
+func (*RerankResponse) Descriptor() ([]byte, []int) {
+	return file_proto_silicon_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RerankResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RerankResponse) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *RerankResponse) GetResults() []*RerankResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *RerankResponse) GetUsage() *Usage {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+// 待索引的文档
+type Document struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	Metadata      map[string]string      `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Document) Reset() {
+	*x = Document{}
+	mi := &file_proto_silicon_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Document) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Document) ProtoMessage() {}
+
+func (x *Document) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_silicon_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Document.ProtoReflect.Descriptor instead.
+func (*Document) Descriptor() ([]byte, []int) {
+	return file_proto_silicon_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Document) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Document) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Document) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// 索引文档请求
+type IndexDocumentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Collection    string                 `protobuf:"bytes,1,opt,name=collection,proto3" json:"collection,omitempty"`
+	Documents     []*Document            `protobuf:"bytes,2,rep,name=documents,proto3" json:"documents,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IndexDocumentsRequest) Reset() {
+	*x = IndexDocumentsRequest{}
+	mi := &file_proto_silicon_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IndexDocumentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IndexDocumentsRequest) ProtoMessage() {}
+
+func (x *IndexDocumentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_silicon_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IndexDocumentsRequest.ProtoReflect.Descriptor instead.
+func (*IndexDocumentsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_silicon_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *IndexDocumentsRequest) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+func (x *IndexDocumentsRequest) GetDocuments() []*Document {
+	if x != nil {
+		return x.Documents
+	}
+	return nil
+}
+
+// 索引文档响应
+type IndexDocumentsResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Documents       int32                  `protobuf:"varint,1,opt,name=documents,proto3" json:"documents,omitempty"`
+	Chunks          int32                  `protobuf:"varint,2,opt,name=chunks,proto3" json:"chunks,omitempty"`
+	EmbeddingTokens int32                  `protobuf:"varint,3,opt,name=embedding_tokens,json=embeddingTokens,proto3" json:"embedding_tokens,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *IndexDocumentsResponse) Reset() {
+	*x = IndexDocumentsResponse{}
+	mi := &file_proto_silicon_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IndexDocumentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IndexDocumentsResponse) ProtoMessage() {}
+
+func (x *IndexDocumentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_silicon_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IndexDocumentsResponse.ProtoReflect.Descriptor instead.
+func (*IndexDocumentsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_silicon_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *IndexDocumentsResponse) GetDocuments() int32 {
+	if x != nil {
+		return x.Documents
+	}
+	return 0
+}
+
+func (x *IndexDocumentsResponse) GetChunks() int32 {
+	if x != nil {
+		return x.Chunks
+	}
+	return 0
+}
+
+func (x *IndexDocumentsResponse) GetEmbeddingTokens() int32 {
+	if x != nil {
+		return x.EmbeddingTokens
+	}
+	return 0
+}
+
+// 检索请求
+type SearchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Collection    string                 `protobuf:"bytes,1,opt,name=collection,proto3" json:"collection,omitempty"`
+	Query         string                 `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	TopK          int32                  `protobuf:"varint,3,opt,name=top_k,json=topK,proto3" json:"top_k,omitempty"`
+	Rerank        bool                   `protobuf:"varint,4,opt,name=rerank,proto3" json:"rerank,omitempty"`
+	RerankModel   string                 `protobuf:"bytes,5,opt,name=rerank_model,json=rerankModel,proto3" json:"rerank_model,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchRequest) Reset() {
+	*x = SearchRequest{}
+	mi := &file_proto_silicon_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRequest) ProtoMessage() {}
+
+func (x *SearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_silicon_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_silicon_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SearchRequest) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetTopK() int32 {
+	if x != nil {
+		return x.TopK
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetRerank() bool {
+	if x != nil {
+		return x.Rerank
+	}
+	return false
+}
+
+func (x *SearchRequest) GetRerankModel() string {
+	if x != nil {
+		return x.RerankModel
+	}
+	return ""
+}
+
+// 检索命中的文本块
+type SearchHit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DocumentId    string                 `protobuf:"bytes,1,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	ChunkIndex    int32                  `protobuf:"varint,2,opt,name=chunk_index,json=chunkIndex,proto3" json:"chunk_index,omitempty"`
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	Score         float64                `protobuf:"fixed64,4,opt,name=score,proto3" json:"score,omitempty"`
+	Start         int32                  `protobuf:"varint,5,opt,name=start,proto3" json:"start,omitempty"`
+	End           int32                  `protobuf:"varint,6,opt,name=end,proto3" json:"end,omitempty"`
+	Metadata      map[string]string      `protobuf:"bytes,7,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchHit) Reset() {
+	*x = SearchHit{}
+	mi := &file_proto_silicon_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchHit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchHit) ProtoMessage() {}
+
+func (x *SearchHit) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_silicon_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchHit.ProtoReflect.Descriptor instead.
+func (*SearchHit) Descriptor() ([]byte, []int) {
+	return file_proto_silicon_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SearchHit) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *SearchHit) GetChunkIndex() int32 {
+	if x != nil {
+		return x.ChunkIndex
+	}
+	return 0
+}
+
+func (x *SearchHit) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *SearchHit) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *SearchHit) GetStart() int32 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *SearchHit) GetEnd() int32 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *SearchHit) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// 检索响应
+type SearchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hits          []*SearchHit           `protobuf:"bytes,1,rep,name=hits,proto3" json:"hits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResponse) Reset() {
+	*x = SearchResponse{}
+	mi := &file_proto_silicon_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResponse) ProtoMessage() {}
+
+func (x *SearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_silicon_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResponse.ProtoReflect.Descriptor instead.
+func (*SearchResponse) Descriptor() ([]byte, []int) {
+	return file_proto_silicon_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SearchResponse) GetHits() []*SearchHit {
+	if x != nil {
+		return x.Hits
+	}
+	return nil
+}
+
+// 检索增强聊天请求
+type ChatWithContextRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Collection     string                 `protobuf:"bytes,1,opt,name=collection,proto3" json:"collection,omitempty"`
+	Model          string                 `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
+	Query          string                 `protobuf:"bytes,3,opt,name=query,proto3" json:"query,omitempty"`
+	History        []*ChatMessage         `protobuf:"bytes,4,rep,name=history,proto3" json:"history,omitempty"`
+	TopK           int32                  `protobuf:"varint,5,opt,name=top_k,json=topK,proto3" json:"top_k,omitempty"`
+	RerankModel    string                 `protobuf:"bytes,6,opt,name=rerank_model,json=rerankModel,proto3" json:"rerank_model,omitempty"`
+	PromptTemplate string                 `protobuf:"bytes,7,opt,name=prompt_template,json=promptTemplate,proto3" json:"prompt_template,omitempty"`
+	ContextTokens  int32                  `protobuf:"varint,8,opt,name=context_tokens,json=contextTokens,proto3" json:"context_tokens,omitempty"`
+	// 未设置时使用模型默认值
+	Temperature   *float32 `protobuf:"fixed32,9,opt,name=temperature,proto3,oneof" json:"temperature,omitempty"`
+	MaxTokens     int32    `protobuf:"varint,10,opt,name=max_tokens,json=maxTokens,proto3" json:"max_tokens,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatWithContextRequest) Reset() {
+	*x = ChatWithContextRequest{}
+	mi := &file_proto_silicon_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatWithContextRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatWithContextRequest) ProtoMessage() {}
+
+func (x *ChatWithContextRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_silicon_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatWithContextRequest.ProtoReflect.Descriptor instead.
+func (*ChatWithContextRequest) Descriptor() ([]byte, []int) {
+	return file_proto_silicon_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ChatWithContextRequest) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+func (x *ChatWithContextRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *ChatWithContextRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ChatWithContextRequest) GetHistory() []*ChatMessage {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+func (x *ChatWithContextRequest) GetTopK() int32 {
+	if x != nil {
+		return x.TopK
+	}
+	return 0
+}
+
+func (x *ChatWithContextRequest) GetRerankModel() string {
+	if x != nil {
+		return x.RerankModel
+	}
+	return ""
+}
+
+func (x *ChatWithContextRequest) GetPromptTemplate() string {
+	if x != nil {
+		return x.PromptTemplate
+	}
+	return ""
+}
+
+func (x *ChatWithContextRequest) GetContextTokens() int32 {
+	if x != nil {
+		return x.ContextTokens
+	}
+	return 0
+}
+
+func (x *ChatWithContextRequest) GetTemperature() float32 {
+	if x != nil && x.Temperature != nil {
+		return *x.Temperature
+	}
+	return 0
+}
+
+func (x *ChatWithContextRequest) GetMaxTokens() int32 {
+	if x != nil {
+		return x.MaxTokens
+	}
+	return 0
+}
+
+// 回答引用的文本块
+type Citation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	DocumentId    string                 `protobuf:"bytes,2,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`
+	ChunkIndex    int32                  `protobuf:"varint,3,opt,name=chunk_index,json=chunkIndex,proto3" json:"chunk_index,omitempty"`
+	Start         int32                  `protobuf:"varint,4,opt,name=start,proto3" json:"start,omitempty"`
+	End           int32                  `protobuf:"varint,5,opt,name=end,proto3" json:"end,omitempty"`
+	Score         float64                `protobuf:"fixed64,6,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Citation) Reset() {
+	*x = Citation{}
+	mi := &file_proto_silicon_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Citation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Citation) ProtoMessage() {}
+
+func (x *Citation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_silicon_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Citation.ProtoReflect.Descriptor instead.
+func (*Citation) Descriptor() ([]byte, []int) {
+	return file_proto_silicon_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *Citation) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *Citation) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *Citation) GetChunkIndex() int32 {
+	if x != nil {
+		return x.ChunkIndex
+	}
+	return 0
+}
+
+func (x *Citation) GetStart() int32 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *Citation) GetEnd() int32 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *Citation) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+// 检索增强聊天的流式响应，第一条消息携带引用，最后一条消息携带用量
+type ChatWithContextResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Delta          string                 `protobuf:"bytes,1,opt,name=delta,proto3" json:"delta,omitempty"`
+	ReasoningDelta string                 `protobuf:"bytes,2,opt,name=reasoning_delta,json=reasoningDelta,proto3" json:"reasoning_delta,omitempty"`
+	Citations      []*Citation            `protobuf:"bytes,3,rep,name=citations,proto3" json:"citations,omitempty"`
+	Done           bool                   `protobuf:"varint,4,opt,name=done,proto3" json:"done,omitempty"`
+	FinishReason   string                 `protobuf:"bytes,5,opt,name=finish_reason,json=finishReason,proto3" json:"finish_reason,omitempty"`
+	Usage          *Usage                 `protobuf:"bytes,6,opt,name=usage,proto3" json:"usage,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ChatWithContextResponse) Reset() {
+	*x = ChatWithContextResponse{}
+	mi := &file_proto_silicon_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatWithContextResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatWithContextResponse) ProtoMessage() {}
+
+func (x *ChatWithContextResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_silicon_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatWithContextResponse.ProtoReflect.Descriptor instead.
+func (*ChatWithContextResponse) Descriptor() ([]byte, []int) {
+	return file_proto_silicon_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ChatWithContextResponse) GetDelta() string {
+	if x != nil {
+		return x.Delta
+	}
+	return ""
+}
+
+func (x *ChatWithContextResponse) GetReasoningDelta() string {
+	if x != nil {
+		return x.ReasoningDelta
+	}
+	return ""
+}
+
+func (x *ChatWithContextResponse) GetCitations() []*Citation {
+	if x != nil {
+		return x.Citations
+	}
+	return nil
+}
+
+func (x *ChatWithContextResponse) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+func (x *ChatWithContextResponse) GetFinishReason() string {
+	if x != nil {
+		return x.FinishReason
+	}
+	return ""
+}
+
+func (x *ChatWithContextResponse) GetUsage() *Usage {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+// 语音转文本的音频分片，model和file_name只需在第一个分片中设置
+type TranscriptionChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Model         string                 `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	FileName      string                 `protobuf:"bytes,2,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TranscriptionChunk) Reset() {
+	*x = TranscriptionChunk{}
+	mi := &file_proto_silicon_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TranscriptionChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TranscriptionChunk) ProtoMessage() {}
+
+func (x *TranscriptionChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_silicon_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TranscriptionChunk.ProtoReflect.Descriptor instead.
+func (*TranscriptionChunk) Descriptor() ([]byte, []int) {
+	return file_proto_silicon_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *TranscriptionChunk) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *TranscriptionChunk) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *TranscriptionChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// 语音转文本响应
+type TranscriptionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TranscriptionResponse) Reset() {
+	*x = TranscriptionResponse{}
+	mi := &file_proto_silicon_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TranscriptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TranscriptionResponse) ProtoMessage() {}
+
+func (x *TranscriptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_silicon_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TranscriptionResponse.ProtoReflect.Descriptor instead.
+func (*TranscriptionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_silicon_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *TranscriptionResponse) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+// 音色
+type Voice struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Uri              string                 `protobuf:"bytes,1,opt,name=uri,proto3" json:"uri,omitempty"`
+	Model            string                 `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
+	Name             string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Text             string                 `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
+	OwnerTeam        string                 `protobuf:"bytes,5,opt,name=owner_team,json=ownerTeam,proto3" json:"owner_team,omitempty"`
+	Language         string                 `protobuf:"bytes,6,opt,name=language,proto3" json:"language,omitempty"`
+	SampleTranscript string                 `protobuf:"bytes,7,opt,name=sample_transcript,json=sampleTranscript,proto3" json:"sample_transcript,omitempty"`
+	CreatedAt        int64                  `protobuf:"varint,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Voice) Reset() {
+	*x = Voice{}
+	mi := &file_proto_silicon_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Voice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Voice) ProtoMessage() {}
+
+func (x *Voice) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_silicon_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Voice.ProtoReflect.Descriptor instead.
+func (*Voice) Descriptor() ([]byte, []int) {
+	return file_proto_silicon_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *Voice) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+func (x *Voice) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *Voice) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Voice) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Voice) GetOwnerTeam() string {
+	if x != nil {
+		return x.OwnerTeam
+	}
+	return ""
+}
+
+func (x *Voice) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *Voice) GetSampleTranscript() string {
+	if x != nil {
+		return x.SampleTranscript
+	}
+	return ""
+}
+
+func (x *Voice) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+// 查询音色请求，空字段匹配任意值
+type ListVoicesRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	OwnerTeam string                 `protobuf:"bytes,1,opt,name=owner_team,json=ownerTeam,proto3" json:"owner_team,omitempty"`
+	Language  string                 `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	Model     string                 `protobuf:"bytes,3,opt,name=model,proto3" json:"model,omitempty"`
+	Query     string                 `protobuf:"bytes,4,opt,name=query,proto3" json:"query,omitempty"`
+	// 查询前先与上游同步
+	Sync          bool `protobuf:"varint,5,opt,name=sync,proto3" json:"sync,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListVoicesRequest) Reset() {
+	*x = ListVoicesRequest{}
+	mi := &file_proto_silicon_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVoicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVoicesRequest) ProtoMessage() {}
+
+func (x *ListVoicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_silicon_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVoicesRequest.ProtoReflect.Descriptor instead.
+func (*ListVoicesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_silicon_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListVoicesRequest) GetOwnerTeam() string {
+	if x != nil {
+		return x.OwnerTeam
+	}
+	return ""
+}
+
+func (x *ListVoicesRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *ListVoicesRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *ListVoicesRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ListVoicesRequest) GetSync() bool {
+	if x != nil {
+		return x.Sync
+	}
+	return false
+}
+
+// 查询音色响应
+type ListVoicesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Voices        []*Voice               `protobuf:"bytes,1,rep,name=voices,proto3" json:"voices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListVoicesResponse) Reset() {
+	*x = ListVoicesResponse{}
+	mi := &file_proto_silicon_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVoicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVoicesResponse) ProtoMessage() {}
+
+func (x *ListVoicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_silicon_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVoicesResponse.ProtoReflect.Descriptor instead.
+func (*ListVoicesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_silicon_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListVoicesResponse) GetVoices() []*Voice {
+	if x != nil {
+		return x.Voices
+	}
+	return nil
+}
+
+// 按名称或URI获取音色请求
+type GetVoiceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVoiceRequest) Reset() {
+	*x = GetVoiceRequest{}
+	mi := &file_proto_silicon_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVoiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVoiceRequest) ProtoMessage() {}
+
+func (x *GetVoiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_silicon_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVoiceRequest.ProtoReflect.Descriptor instead.
+func (*GetVoiceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_silicon_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetVoiceRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// 按名称或URI删除音色请求
+type DeleteVoiceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteVoiceRequest) Reset() {
+	*x = DeleteVoiceRequest{}
+	mi := &file_proto_silicon_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteVoiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteVoiceRequest) ProtoMessage() {}
+
+func (x *DeleteVoiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_silicon_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteVoiceRequest.ProtoReflect.Descriptor instead.
+func (*DeleteVoiceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_silicon_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DeleteVoiceRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// 通过音色注册表上传音色请求，同一模型下内容相同的音频不会重复上传
+type UploadVoiceRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Model string                 `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// 参考音频对应的文字
+	Text             string `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	Audio            []byte `protobuf:"bytes,4,opt,name=audio,proto3" json:"audio,omitempty"`
+	FileName         string `protobuf:"bytes,5,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	OwnerTeam        string `protobuf:"bytes,6,opt,name=owner_team,json=ownerTeam,proto3" json:"owner_team,omitempty"`
+	Language         string `protobuf:"bytes,7,opt,name=language,proto3" json:"language,omitempty"`
+	SampleTranscript string `protobuf:"bytes,8,opt,name=sample_transcript,json=sampleTranscript,proto3" json:"sample_transcript,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *UploadVoiceRequest) Reset() {
+	*x = UploadVoiceRequest{}
+	mi := &file_proto_silicon_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadVoiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadVoiceRequest) ProtoMessage() {}
+
+func (x *UploadVoiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_silicon_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadVoiceRequest.ProtoReflect.Descriptor instead.
+func (*UploadVoiceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_silicon_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *UploadVoiceRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *UploadVoiceRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UploadVoiceRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *UploadVoiceRequest) GetAudio() []byte {
+	if x != nil {
+		return x.Audio
+	}
+	return nil
+}
+
+func (x *UploadVoiceRequest) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *UploadVoiceRequest) GetOwnerTeam() string {
+	if x != nil {
+		return x.OwnerTeam
+	}
+	return ""
+}
+
+func (x *UploadVoiceRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *UploadVoiceRequest) GetSampleTranscript() string {
+	if x != nil {
+		return x.SampleTranscript
+	}
+	return ""
+}
+
+// 上传音色响应
+type UploadVoiceResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Voice *Voice                 `protobuf:"bytes,1,opt,name=voice,proto3" json:"voice,omitempty"`
+	// 为true时表示已存在内容相同的音色，没有重新上传
+	Duplicate     bool `protobuf:"varint,2,opt,name=duplicate,proto3" json:"duplicate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadVoiceResponse) Reset() {
+	*x = UploadVoiceResponse{}
+	mi := &file_proto_silicon_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadVoiceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadVoiceResponse) ProtoMessage() {}
+
+func (x *UploadVoiceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_silicon_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadVoiceResponse.ProtoReflect.Descriptor instead.
+func (*UploadVoiceResponse) Descriptor() ([]byte, []int) {
+	return file_proto_silicon_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *UploadVoiceResponse) GetVoice() *Voice {
+	if x != nil {
+		return x.Voice
+	}
+	return nil
+}
+
+func (x *UploadVoiceResponse) GetDuplicate() bool {
+	if x != nil {
+		return x.Duplicate
+	}
+	return false
+}
+
+// 更新音色元数据请求，元数据整体替换
+type SetVoiceMetadataRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 名称或URI
+	Name             string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	OwnerTeam        string `protobuf:"bytes,2,opt,name=owner_team,json=ownerTeam,proto3" json:"owner_team,omitempty"`
+	Language         string `protobuf:"bytes,3,opt,name=language,proto3" json:"language,omitempty"`
+	SampleTranscript string `protobuf:"bytes,4,opt,name=sample_transcript,json=sampleTranscript,proto3" json:"sample_transcript,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SetVoiceMetadataRequest) Reset() {
+	*x = SetVoiceMetadataRequest{}
+	mi := &file_proto_silicon_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetVoiceMetadataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetVoiceMetadataRequest) ProtoMessage() {}
+
+func (x *SetVoiceMetadataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_silicon_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetVoiceMetadataRequest.ProtoReflect.Descriptor instead.
+func (*SetVoiceMetadataRequest) Descriptor() ([]byte, []int) {
+	return file_proto_silicon_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SetVoiceMetadataRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SetVoiceMetadataRequest) GetOwnerTeam() string {
+	if x != nil {
+		return x.OwnerTeam
+	}
+	return ""
+}
+
+func (x *SetVoiceMetadataRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *SetVoiceMetadataRequest) GetSampleTranscript() string {
+	if x != nil {
+		return x.SampleTranscript
+	}
+	return ""
+}
+
+// 查询模型目录请求，零值字段不参与过滤
+type ListModelsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// text、image、audio或video
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// 如chat、embedding、reranker、text-to-image等
+	SubType          string `protobuf:"bytes,2,opt,name=sub_type,json=subType,proto3" json:"sub_type,omitempty"`
+	SupportsTools    bool   `protobuf:"varint,3,opt,name=supports_tools,json=supportsTools,proto3" json:"supports_tools,omitempty"`
+	SupportsVision   bool   `protobuf:"varint,4,opt,name=supports_vision,json=supportsVision,proto3" json:"supports_vision,omitempty"`
+	SupportsJson     bool   `protobuf:"varint,5,opt,name=supports_json,json=supportsJson,proto3" json:"supports_json,omitempty"`
+	MinContextLength int32  `protobuf:"varint,6,opt,name=min_context_length,json=minContextLength,proto3" json:"min_context_length,omitempty"`
+	// 查询前强制刷新上游模型列表
+	Refresh       bool `protobuf:"varint,7,opt,name=refresh,proto3" json:"refresh,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListModelsRequest) Reset() {
+	*x = ListModelsRequest{}
+	mi := &file_proto_silicon_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListModelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListModelsRequest) ProtoMessage() {}
+
+func (x *ListModelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_silicon_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListModelsRequest.ProtoReflect.Descriptor instead.
+func (*ListModelsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_silicon_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListModelsRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ListModelsRequest) GetSubType() string {
+	if x != nil {
+		return x.SubType
+	}
+	return ""
+}
+
+func (x *ListModelsRequest) GetSupportsTools() bool {
+	if x != nil {
+		return x.SupportsTools
+	}
+	return false
+}
+
+func (x *ListModelsRequest) GetSupportsVision() bool {
+	if x != nil {
+		return x.SupportsVision
+	}
+	return false
+}
+
+func (x *ListModelsRequest) GetSupportsJson() bool {
+	if x != nil {
+		return x.SupportsJson
+	}
+	return false
+}
+
+func (x *ListModelsRequest) GetMinContextLength() int32 {
+	if x != nil {
+		return x.MinContextLength
+	}
+	return 0
+}
+
+func (x *ListModelsRequest) GetRefresh() bool {
+	if x != nil {
+		return x.Refresh
+	}
+	return false
+}
+
+// 模型目录条目
+type ModelInfo struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OwnedBy        string                 `protobuf:"bytes,2,opt,name=owned_by,json=ownedBy,proto3" json:"owned_by,omitempty"`
+	Type           string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	SubTypes       []string               `protobuf:"bytes,4,rep,name=sub_types,json=subTypes,proto3" json:"sub_types,omitempty"`
+	ContextLength  int32                  `protobuf:"varint,5,opt,name=context_length,json=contextLength,proto3" json:"context_length,omitempty"`
+	SupportsTools  bool                   `protobuf:"varint,6,opt,name=supports_tools,json=supportsTools,proto3" json:"supports_tools,omitempty"`
+	SupportsVision bool                   `protobuf:"varint,7,opt,name=supports_vision,json=supportsVision,proto3" json:"supports_vision,omitempty"`
+	SupportsJson   bool                   `protobuf:"varint,8,opt,name=supports_json,json=supportsJson,proto3" json:"supports_json,omitempty"`
+	// 每百万token的价格（元）
+	InputPrice    float64 `protobuf:"fixed64,9,opt,name=input_price,json=inputPrice,proto3" json:"input_price,omitempty"`
+	OutputPrice   float64 `protobuf:"fixed64,10,opt,name=output_price,json=outputPrice,proto3" json:"output_price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModelInfo) Reset() {
+	*x = ModelInfo{}
+	mi := &file_proto_silicon_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModelInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelInfo) ProtoMessage() {}
+
+func (x *ModelInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_silicon_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelInfo.ProtoReflect.Descriptor instead.
+func (*ModelInfo) Descriptor() ([]byte, []int) {
+	return file_proto_silicon_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ModelInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ModelInfo) GetOwnedBy() string {
+	if x != nil {
+		return x.OwnedBy
+	}
+	return ""
+}
+
+func (x *ModelInfo) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ModelInfo) GetSubTypes() []string {
+	if x != nil {
+		return x.SubTypes
+	}
+	return nil
+}
+
+func (x *ModelInfo) GetContextLength() int32 {
+	if x != nil {
+		return x.ContextLength
+	}
+	return 0
+}
+
+func (x *ModelInfo) GetSupportsTools() bool {
+	if x != nil {
+		return x.SupportsTools
+	}
+	return false
+}
+
+func (x *ModelInfo) GetSupportsVision() bool {
+	if x != nil {
+		return x.SupportsVision
+	}
+	return false
+}
+
+func (x *ModelInfo) GetSupportsJson() bool {
+	if x != nil {
+		return x.SupportsJson
+	}
+	return false
+}
+
+func (x *ModelInfo) GetInputPrice() float64 {
+	if x != nil {
+		return x.InputPrice
+	}
+	return 0
+}
+
+func (x *ModelInfo) GetOutputPrice() float64 {
+	if x != nil {
+		return x.OutputPrice
+	}
+	return 0
+}
+
+// 查询模型目录响应
+type ListModelsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Models        []*ModelInfo           `protobuf:"bytes,1,rep,name=models,proto3" json:"models,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListModelsResponse) Reset() {
+	*x = ListModelsResponse{}
+	mi := &file_proto_silicon_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListModelsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListModelsResponse) ProtoMessage() {}
+
+func (x *ListModelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_silicon_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListModelsResponse.ProtoReflect.Descriptor instead.
+func (*ListModelsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_silicon_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListModelsResponse) GetModels() []*ModelInfo {
+	if x != nil {
+		return x.Models
+	}
+	return nil
+}
+
+// 渲染提示词模板并对话的请求
+type RenderAndCompleteRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Template string                 `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	// 为空时使用固定版本，未固定时使用最新版本
+	Version   string            `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Variables map[string]string `protobuf:"bytes,3,rep,name=variables,proto3" json:"variables,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// 不为空时覆盖模板中的模型
+	Model         string `protobuf:"bytes,4,opt,name=model,proto3" json:"model,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenderAndCompleteRequest) Reset() {
+	*x = RenderAndCompleteRequest{}
+	mi := &file_proto_silicon_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderAndCompleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderAndCompleteRequest) ProtoMessage() {}
+
+func (x *RenderAndCompleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_silicon_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderAndCompleteRequest.ProtoReflect.Descriptor instead.
+func (*RenderAndCompleteRequest) Descriptor() ([]byte, []int) {
+	return file_proto_silicon_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *RenderAndCompleteRequest) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
+func (x *RenderAndCompleteRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *RenderAndCompleteRequest) GetVariables() map[string]string {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+func (x *RenderAndCompleteRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+// 渲染提示词模板并对话的响应，附带实际使用的模板版本
+type RenderAndCompleteResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Completion    *ChatCompletionResponse `protobuf:"bytes,1,opt,name=completion,proto3" json:"completion,omitempty"`
+	Template      string                  `protobuf:"bytes,2,opt,name=template,proto3" json:"template,omitempty"`
+	Version       string                  `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenderAndCompleteResponse) Reset() {
+	*x = RenderAndCompleteResponse{}
+	mi := &file_proto_silicon_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderAndCompleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderAndCompleteResponse) ProtoMessage() {}
+
+func (x *RenderAndCompleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_silicon_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderAndCompleteResponse.ProtoReflect.Descriptor instead.
+func (*RenderAndCompleteResponse) Descriptor() ([]byte, []int) {
+	return file_proto_silicon_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *RenderAndCompleteResponse) GetCompletion() *ChatCompletionResponse {
+	if x != nil {
+		return x.Completion
+	}
+	return nil
+}
+
+func (x *RenderAndCompleteResponse) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
+func (x *RenderAndCompleteResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+// 查询提示词模板请求
+type ListPromptTemplatesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 为空时返回全部模板
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPromptTemplatesRequest) Reset() {
+	*x = ListPromptTemplatesRequest{}
+	mi := &file_proto_silicon_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPromptTemplatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPromptTemplatesRequest) ProtoMessage() {}
+
+func (x *ListPromptTemplatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_silicon_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPromptTemplatesRequest.ProtoReflect.Descriptor instead.
+func (*ListPromptTemplatesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_silicon_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ListPromptTemplatesRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// 提示词模板的一个版本
+type PromptTemplateVersion struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Version     string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Metadata    map[string]string      `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Model       string                 `protobuf:"bytes,4,opt,name=model,proto3" json:"model,omitempty"`
+	Variables   []string               `protobuf:"bytes,5,rep,name=variables,proto3" json:"variables,omitempty"`
+	// 模板文件路径，内置模板以builtin:开头
+	Source        string `protobuf:"bytes,6,opt,name=source,proto3" json:"source,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromptTemplateVersion) Reset() {
+	*x = PromptTemplateVersion{}
+	mi := &file_proto_silicon_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptTemplateVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptTemplateVersion) ProtoMessage() {}
+
+func (x *PromptTemplateVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_silicon_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptTemplateVersion.ProtoReflect.Descriptor instead.
+func (*PromptTemplateVersion) Descriptor() ([]byte, []int) {
+	return file_proto_silicon_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *PromptTemplateVersion) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *PromptTemplateVersion) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *PromptTemplateVersion) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *PromptTemplateVersion) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *PromptTemplateVersion) GetVariables() []string {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+func (x *PromptTemplateVersion) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+// 提示词模板
+type PromptTemplate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// 固定的版本，为空表示未固定
+	Pinned string `protobuf:"bytes,2,opt,name=pinned,proto3" json:"pinned,omitempty"`
+	// 未指定版本时使用的版本
+	DefaultVersion string                   `protobuf:"bytes,3,opt,name=default_version,json=defaultVersion,proto3" json:"default_version,omitempty"`
+	Versions       []*PromptTemplateVersion `protobuf:"bytes,4,rep,name=versions,proto3" json:"versions,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PromptTemplate) Reset() {
+	*x = PromptTemplate{}
+	mi := &file_proto_silicon_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptTemplate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptTemplate) ProtoMessage() {}
+
+func (x *PromptTemplate) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_silicon_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptTemplate.ProtoReflect.Descriptor instead.
+func (*PromptTemplate) Descriptor() ([]byte, []int) {
+	return file_proto_silicon_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *PromptTemplate) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PromptTemplate) GetPinned() string {
+	if x != nil {
+		return x.Pinned
+	}
+	return ""
+}
+
+func (x *PromptTemplate) GetDefaultVersion() string {
+	if x != nil {
+		return x.DefaultVersion
+	}
+	return ""
+}
+
+func (x *PromptTemplate) GetVersions() []*PromptTemplateVersion {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+// 查询提示词模板响应
+type ListPromptTemplatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Templates     []*PromptTemplate      `protobuf:"bytes,1,rep,name=templates,proto3" json:"templates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPromptTemplatesResponse) Reset() {
+	*x = ListPromptTemplatesResponse{}
+	mi := &file_proto_silicon_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPromptTemplatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPromptTemplatesResponse) ProtoMessage() {}
+
+func (x *ListPromptTemplatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_silicon_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPromptTemplatesResponse.ProtoReflect.Descriptor instead.
+func (*ListPromptTemplatesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_silicon_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ListPromptTemplatesResponse) GetTemplates() []*PromptTemplate {
+	if x != nil {
+		return x.Templates
+	}
+	return nil
+}
+
+// 提交视频任务请求
+type SubmitVideoRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Model          string                 `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	Prompt         string                 `protobuf:"bytes,2,opt,name=prompt,proto3" json:"prompt,omitempty"`
+	NegativePrompt string                 `protobuf:"bytes,3,opt,name=negative_prompt,json=negativePrompt,proto3" json:"negative_prompt,omitempty"`
+	// 1280x720、720x1280或960x960
+	ImageSize string `protobuf:"bytes,4,opt,name=image_size,json=imageSize,proto3" json:"image_size,omitempty"`
+	// 图生视频的输入图像，URL或base64数据URI
+	Image string `protobuf:"bytes,5,opt,name=image,proto3" json:"image,omitempty"`
+	Seed  int64  `protobuf:"varint,6,opt,name=seed,proto3" json:"seed,omitempty"`
+	// 任务结束后以POST JSON方式通知的地址
+	Webhook       string `protobuf:"bytes,7,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitVideoRequest) Reset() {
+	*x = SubmitVideoRequest{}
+	mi := &file_proto_silicon_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitVideoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitVideoRequest) ProtoMessage() {}
+
+func (x *SubmitVideoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_silicon_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitVideoRequest.ProtoReflect.Descriptor instead.
+func (*SubmitVideoRequest) Descriptor() ([]byte, []int) {
+	return file_proto_silicon_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *SubmitVideoRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *SubmitVideoRequest) GetPrompt() string {
+	if x != nil {
+		return x.Prompt
+	}
+	return ""
+}
+
+func (x *SubmitVideoRequest) GetNegativePrompt() string {
+	if x != nil {
+		return x.NegativePrompt
+	}
+	return ""
+}
+
+func (x *SubmitVideoRequest) GetImageSize() string {
+	if x != nil {
+		return x.ImageSize
+	}
+	return ""
+}
+
+func (x *SubmitVideoRequest) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *SubmitVideoRequest) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+func (x *SubmitVideoRequest) GetWebhook() string {
+	if x != nil {
+		return x.Webhook
+	}
+	return ""
+}
+
+// 查询视频任务请求
+type GetVideoJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVideoJobRequest) Reset() {
+	*x = GetVideoJobRequest{}
+	mi := &file_proto_silicon_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVideoJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVideoJobRequest) ProtoMessage() {}
+
+func (x *GetVideoJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_silicon_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVideoJobRequest.ProtoReflect.Descriptor instead.
+func (*GetVideoJobRequest) Descriptor() ([]byte, []int) {
+	return file_proto_silicon_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *GetVideoJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// 视频任务
+type VideoJob struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 上游的requestId
+	Id     string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Model  string `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
+	Prompt string `protobuf:"bytes,3,opt,name=prompt,proto3" json:"prompt,omitempty"`
+	// InQueue、InProgress、Succeed、Failed或Timeout
+	Status   string `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Reason   string `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	VideoUrl string `protobuf:"bytes,6,opt,name=video_url,json=videoUrl,proto3" json:"video_url,omitempty"`
+	// 服务端下载的视频文件路径
+	LocalPath     string `protobuf:"bytes,7,opt,name=local_path,json=localPath,proto3" json:"local_path,omitempty"`
+	SubmittedAt   int64  `protobuf:"varint,8,opt,name=submitted_at,json=submittedAt,proto3" json:"submitted_at,omitempty"`
+	CompletedAt   int64  `protobuf:"varint,9,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VideoJob) Reset() {
+	*x = VideoJob{}
+	mi := &file_proto_silicon_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VideoJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VideoJob) ProtoMessage() {}
+
+func (x *VideoJob) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_silicon_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VideoJob.ProtoReflect.Descriptor instead.
+func (*VideoJob) Descriptor() ([]byte, []int) {
+	return file_proto_silicon_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *VideoJob) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *VideoJob) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *VideoJob) GetPrompt() string {
+	if x != nil {
+		return x.Prompt
+	}
+	return ""
+}
+
+func (x *VideoJob) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *VideoJob) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *VideoJob) GetVideoUrl() string {
+	if x != nil {
+		return x.VideoUrl
+	}
+	return ""
+}
+
+func (x *VideoJob) GetLocalPath() string {
+	if x != nil {
+		return x.LocalPath
+	}
+	return ""
+}
+
+func (x *VideoJob) GetSubmittedAt() int64 {
+	if x != nil {
+		return x.SubmittedAt
+	}
+	return 0
+}
+
+func (x *VideoJob) GetCompletedAt() int64 {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return 0
+}
+
+// 语义缓存失效请求，空字段匹配任意值
+type InvalidateSemanticCacheRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Model        string                 `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	SystemPrompt string                 `protobuf:"bytes,2,opt,name=system_prompt,json=systemPrompt,proto3" json:"system_prompt,omitempty"`
+	// 只有未识别租户的请求可以指定，租户请求只能使自己的条目失效
+	Tenant        string `protobuf:"bytes,3,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvalidateSemanticCacheRequest) Reset() {
+	*x = InvalidateSemanticCacheRequest{}
+	mi := &file_proto_silicon_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvalidateSemanticCacheRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvalidateSemanticCacheRequest) ProtoMessage() {}
+
+func (x *InvalidateSemanticCacheRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_silicon_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvalidateSemanticCacheRequest.ProtoReflect.Descriptor instead.
+func (*InvalidateSemanticCacheRequest) Descriptor() ([]byte, []int) {
+	return file_proto_silicon_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *InvalidateSemanticCacheRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *InvalidateSemanticCacheRequest) GetSystemPrompt() string {
+	if x != nil {
+		return x.SystemPrompt
+	}
+	return ""
+}
+
+func (x *InvalidateSemanticCacheRequest) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+// 语义缓存失效响应
+type InvalidateSemanticCacheResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 删除的条目数
+	Removed       int64 `protobuf:"varint,1,opt,name=removed,proto3" json:"removed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvalidateSemanticCacheResponse) Reset() {
+	*x = InvalidateSemanticCacheResponse{}
+	mi := &file_proto_silicon_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvalidateSemanticCacheResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvalidateSemanticCacheResponse) ProtoMessage() {}
+
+func (x *InvalidateSemanticCacheResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_silicon_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvalidateSemanticCacheResponse.ProtoReflect.Descriptor instead.
+func (*InvalidateSemanticCacheResponse) Descriptor() ([]byte, []int) {
+	return file_proto_silicon_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *InvalidateSemanticCacheResponse) GetRemoved() int64 {
+	if x != nil {
+		return x.Removed
+	}
+	return 0
+}
+
+// 语义缓存统计信息
+type SemanticCacheStats struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Lookups int64                  `protobuf:"varint,1,opt,name=lookups,proto3" json:"lookups,omitempty"`
+	Hits    int64                  `protobuf:"varint,2,opt,name=hits,proto3" json:"hits,omitempty"`
+	Misses  int64                  `protobuf:"varint,3,opt,name=misses,proto3" json:"misses,omitempty"`
+	HitRate float64                `protobuf:"fixed64,4,opt,name=hit_rate,json=hitRate,proto3" json:"hit_rate,omitempty"`
+	Entries int64                  `protobuf:"varint,5,opt,name=entries,proto3" json:"entries,omitempty"`
+	// 因过期或超出容量而删除的条目数
+	Evictions int64 `protobuf:"varint,6,opt,name=evictions,proto3" json:"evictions,omitempty"`
+	// 命中节省的聊天token数
+	SavedTokens int64 `protobuf:"varint,7,opt,name=saved_tokens,json=savedTokens,proto3" json:"saved_tokens,omitempty"`
+	// 查询消耗的嵌入token数
+	EmbeddingTokens int64 `protobuf:"varint,8,opt,name=embedding_tokens,json=embeddingTokens,proto3" json:"embedding_tokens,omitempty"`
+	// 计算消息向量失败而跳过缓存的次数
+	EmbeddingErrors int64 `protobuf:"varint,9,opt,name=embedding_errors,json=embeddingErrors,proto3" json:"embedding_errors,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SemanticCacheStats) Reset() {
+	*x = SemanticCacheStats{}
+	mi := &file_proto_silicon_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SemanticCacheStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SemanticCacheStats) ProtoMessage() {}
+
+func (x *SemanticCacheStats) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_silicon_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SemanticCacheStats.ProtoReflect.Descriptor instead.
+func (*SemanticCacheStats) Descriptor() ([]byte, []int) {
+	return file_proto_silicon_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *SemanticCacheStats) GetLookups() int64 {
+	if x != nil {
+		return x.Lookups
+	}
+	return 0
+}
+
+func (x *SemanticCacheStats) GetHits() int64 {
+	if x != nil {
+		return x.Hits
+	}
+	return 0
+}
+
+func (x *SemanticCacheStats) GetMisses() int64 {
+	if x != nil {
+		return x.Misses
+	}
+	return 0
+}
+
+func (x *SemanticCacheStats) GetHitRate() float64 {
+	if x != nil {
+		return x.HitRate
+	}
+	return 0
+}
+
+func (x *SemanticCacheStats) GetEntries() int64 {
+	if x != nil {
+		return x.Entries
+	}
+	return 0
+}
+
+func (x *SemanticCacheStats) GetEvictions() int64 {
+	if x != nil {
+		return x.Evictions
+	}
+	return 0
+}
+
+func (x *SemanticCacheStats) GetSavedTokens() int64 {
+	if x != nil {
+		return x.SavedTokens
+	}
+	return 0
+}
+
+func (x *SemanticCacheStats) GetEmbeddingTokens() int64 {
+	if x != nil {
+		return x.EmbeddingTokens
+	}
+	return 0
+}
+
+func (x *SemanticCacheStats) GetEmbeddingErrors() int64 {
+	if x != nil {
+		return x.EmbeddingErrors
+	}
+	return 0
+}
+
+// 空消息
+type Empty struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Empty) Reset() {
+	*x = Empty{}
+	mi := &file_proto_silicon_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Empty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Empty) ProtoMessage() {}
+
+func (x *Empty) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_silicon_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
+func (*Empty) Descriptor() ([]byte, []int) {
+	return file_proto_silicon_proto_rawDescGZIP(), []int{50}
+}
+
+var File_proto_silicon_proto protoreflect.FileDescriptor
+
+const file_proto_silicon_proto_rawDesc = "" +
+	"\n" +
+	"\x13proto/silicon.proto\x12\asilicon\x1a\x1cgoogle/api/annotations.proto\"2\n" +
+	"\x05Model\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\bowned_by\x18\x02 \x01(\tR\aownedBy\":\n" +
+	"\x14GetModelListResponse\x12\"\n" +
+	"\x04data\x18\x01 \x03(\v2\x0e.silicon.ModelR\x04data\";\n" +
+	"\vChatMessage\x12\x12\n" +
+	"\x04role\x18\x01 \x01(\tR\x04role\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\"$\n" +
+	"\x0eResponseFormat\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\"^\n" +
+	"\x0eFunctionObject\x12 \n" +
+	"\vdescription\x18\x01 \x01(\tR\vdescription\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06strict\x18\x03 \x01(\bR\x06strict\"O\n" +
+	"\x04Tool\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x123\n" +
+	"\bfunction\x18\x02 \x01(\v2\x17.silicon.FunctionObjectR\bfunction\"\xad\x03\n" +
+	"\x15ChatCompletionRequest\x12\x14\n" +
+	"\x05model\x18\x01 \x01(\tR\x05model\x120\n" +
+	"\bmessages\x18\x02 \x03(\v2\x14.silicon.ChatMessageR\bmessages\x12\x16\n" +
+	"\x06stream\x18\x03 \x01(\bR\x06stream\x12\x1d\n" +
+	"\n" +
+	"max_tokens\x18\x04 \x01(\x05R\tmaxTokens\x12\x12\n" +
+	"\x04stop\x18\x05 \x03(\tR\x04stop\x12%\n" +
+	"\vtemperature\x18\x06 \x01(\x02H\x00R\vtemperature\x88\x01\x01\x12\x13\n" +
+	"\x05top_p\x18\a \x01(\x02R\x04topP\x12\x13\n" +
+	"\x05top_k\x18\b \x01(\x05R\x04topK\x12+\n" +
+	"\x11frequency_penalty\x18\t \x01(\x02R\x10frequencyPenalty\x12\f\n" +
+	"\x01n\x18\n" +
+	" \x01(\x05R\x01n\x12@\n" +
+	"\x0fresponse_format\x18\v \x01(\v2\x17.silicon.ResponseFormatR\x0eresponseFormat\x12#\n" +
+	"\x05tools\x18\f \x03(\v2\r.silicon.ToolR\x05toolsB\x0e\n" +
+	"\f_temperature\"N\n" +
+	"\x06Choice\x12.\n" +
+	"\amessage\x18\x01 \x01(\v2\x14.silicon.ChatMessageR\amessage\x12\x14\n" +
+	"\x05index\x18\x02 \x01(\x05R\x05index\"|\n" +
+	"\x05Usage\x12#\n" +
+	"\rprompt_tokens\x18\x01 \x01(\x05R\fpromptTokens\x12+\n" +
+	"\x11completion_tokens\x18\x02 \x01(\x05R\x10completionTokens\x12!\n" +
+	"\ftotal_tokens\x18\x03 \x01(\x05R\vtotalTokens\"\xc1\x01\n" +
+	"\x16ChatCompletionResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06object\x18\x02 \x01(\tR\x06object\x12\x18\n" +
+	"\acreated\x18\x03 \x01(\x03R\acreated\x12\x14\n" +
+	"\x05model\x18\x04 \x01(\tR\x05model\x12)\n" +
+	"\achoices\x18\x05 \x03(\v2\x0f.silicon.ChoiceR\achoices\x12$\n" +
+	"\x05usage\x18\x06 \x01(\v2\x0e.silicon.UsageR\x05usage\"^\n" +
+	"\x10EmbeddingRequest\x12\x14\n" +
+	"\x05model\x18\x01 \x01(\tR\x05model\x12\x14\n" +
+	"\x05input\x18\x02 \x03(\tR\x05input\x12\x1e\n" +
+	"\n" +
+	"dimensions\x18\x03 \x01(\x05R\n" +
+	"dimensions\"?\n" +
+	"\tEmbedding\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x1c\n" +
+	"\tembedding\x18\x02 \x03(\x02R\tembedding\"w\n" +
+	"\x11EmbeddingResponse\x12\x14\n" +
+	"\x05model\x18\x01 \x01(\tR\x05model\x12&\n" +
+	"\x04data\x18\x02 \x03(\v2\x12.silicon.EmbeddingR\x04data\x12$\n" +
+	"\x05usage\x18\x03 \x01(\v2\x0e.silicon.UsageR\x05usage\"n\n" +
+	"\rRerankRequest\x12\x14\n" +
+	"\x05model\x18\x01 \x01(\tR\x05model\x12\x14\n" +
+	"\x05query\x18\x02 \x01(\tR\x05query\x12\x1c\n" +
+	"\tdocuments\x18\x03 \x03(\tR\tdocuments\x12\x13\n" +
+	"\x05top_n\x18\x04 \x01(\x05R\x04topN\"M\n" +
+	"\fRerankResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12'\n" +
+	"\x0frelevance_score\x18\x02 \x01(\x01R\x0erelevanceScore\"\x8d\x01\n" +
+	"\x0eRerankResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05model\x18\x02 \x01(\tR\x05model\x12/\n" +
+	"\aresults\x18\x03 \x03(\v2\x15.silicon.RerankResultR\aresults\x12$\n" +
+	"\x05usage\x18\x04 \x01(\v2\x0e.silicon.UsageR\x05usage\"\xa8\x01\n" +
+	"\bDocument\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12;\n" +
+	"\bmetadata\x18\x03 \x03(\v2\x1f.silicon.Document.MetadataEntryR\bmetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"h\n" +
+	"\x15IndexDocumentsRequest\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x01 \x01(\tR\n" +
+	"collection\x12/\n" +
+	"\tdocuments\x18\x02 \x03(\v2\x11.silicon.DocumentR\tdocuments\"y\n" +
+	"\x16IndexDocumentsResponse\x12\x1c\n" +
+	"\tdocuments\x18\x01 \x01(\x05R\tdocuments\x12\x16\n" +
+	"\x06chunks\x18\x02 \x01(\x05R\x06chunks\x12)\n" +
+	"\x10embedding_tokens\x18\x03 \x01(\x05R\x0fembeddingTokens\"\x95\x01\n" +
+	"\rSearchRequest\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x01 \x01(\tR\n" +
+	"collection\x12\x14\n" +
+	"\x05query\x18\x02 \x01(\tR\x05query\x12\x13\n" +
+	"\x05top_k\x18\x03 \x01(\x05R\x04topK\x12\x16\n" +
+	"\x06rerank\x18\x04 \x01(\bR\x06rerank\x12!\n" +
+	"\frerank_model\x18\x05 \x01(\tR\vrerankModel\"\x9a\x02\n" +
+	"\tSearchHit\x12\x1f\n" +
+	"\vdocument_id\x18\x01 \x01(\tR\n" +
+	"documentId\x12\x1f\n" +
+	"\vchunk_index\x18\x02 \x01(\x05R\n" +
+	"chunkIndex\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\x12\x14\n" +
+	"\x05score\x18\x04 \x01(\x01R\x05score\x12\x14\n" +
+	"\x05start\x18\x05 \x01(\x05R\x05start\x12\x10\n" +
+	"\x03end\x18\x06 \x01(\x05R\x03end\x12<\n" +
+	"\bmetadata\x18\a \x03(\v2 .silicon.SearchHit.MetadataEntryR\bmetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"8\n" +
+	"\x0eSearchResponse\x12&\n" +
+	"\x04hits\x18\x01 \x03(\v2\x12.silicon.SearchHitR\x04hits\"\xf2\x02\n" +
+	"\x16ChatWithContextRequest\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x01 \x01(\tR\n" +
+	"collection\x12\x14\n" +
+	"\x05model\x18\x02 \x01(\tR\x05model\x12\x14\n" +
+	"\x05query\x18\x03 \x01(\tR\x05query\x12.\n" +
+	"\ahistory\x18\x04 \x03(\v2\x14.silicon.ChatMessageR\ahistory\x12\x13\n" +
+	"\x05top_k\x18\x05 \x01(\x05R\x04topK\x12!\n" +
+	"\frerank_model\x18\x06 \x01(\tR\vrerankModel\x12'\n" +
+	"\x0fprompt_template\x18\a \x01(\tR\x0epromptTemplate\x12%\n" +
+	"\x0econtext_tokens\x18\b \x01(\x05R\rcontextTokens\x12%\n" +
+	"\vtemperature\x18\t \x01(\x02H\x00R\vtemperature\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"max_tokens\x18\n" +
+	" \x01(\x05R\tmaxTokensB\x0e\n" +
+	"\f_temperature\"\xa0\x01\n" +
+	"\bCitation\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x1f\n" +
+	"\vdocument_id\x18\x02 \x01(\tR\n" +
+	"documentId\x12\x1f\n" +
+	"\vchunk_index\x18\x03 \x01(\x05R\n" +
+	"chunkIndex\x12\x14\n" +
+	"\x05start\x18\x04 \x01(\x05R\x05start\x12\x10\n" +
+	"\x03end\x18\x05 \x01(\x05R\x03end\x12\x14\n" +
+	"\x05score\x18\x06 \x01(\x01R\x05score\"\xe8\x01\n" +
+	"\x17ChatWithContextResponse\x12\x14\n" +
+	"\x05delta\x18\x01 \x01(\tR\x05delta\x12'\n" +
+	"\x0freasoning_delta\x18\x02 \x01(\tR\x0ereasoningDelta\x12/\n" +
+	"\tcitations\x18\x03 \x03(\v2\x11.silicon.CitationR\tcitations\x12\x12\n" +
+	"\x04done\x18\x04 \x01(\bR\x04done\x12#\n" +
+	"\rfinish_reason\x18\x05 \x01(\tR\ffinishReason\x12$\n" +
+	"\x05usage\x18\x06 \x01(\v2\x0e.silicon.UsageR\x05usage\"[\n" +
+	"\x12TranscriptionChunk\x12\x14\n" +
+	"\x05model\x18\x01 \x01(\tR\x05model\x12\x1b\n" +
+	"\tfile_name\x18\x02 \x01(\tR\bfileName\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\"+\n" +
+	"\x15TranscriptionResponse\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\"\xde\x01\n" +
+	"\x05Voice\x12\x10\n" +
+	"\x03uri\x18\x01 \x01(\tR\x03uri\x12\x14\n" +
+	"\x05model\x18\x02 \x01(\tR\x05model\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x12\n" +
+	"\x04text\x18\x04 \x01(\tR\x04text\x12\x1d\n" +
+	"\n" +
+	"owner_team\x18\x05 \x01(\tR\townerTeam\x12\x1a\n" +
+	"\blanguage\x18\x06 \x01(\tR\blanguage\x12+\n" +
+	"\x11sample_transcript\x18\a \x01(\tR\x10sampleTranscript\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\b \x01(\x03R\tcreatedAt\"\x8e\x01\n" +
+	"\x11ListVoicesRequest\x12\x1d\n" +
+	"\n" +
+	"owner_team\x18\x01 \x01(\tR\townerTeam\x12\x1a\n" +
+	"\blanguage\x18\x02 \x01(\tR\blanguage\x12\x14\n" +
+	"\x05model\x18\x03 \x01(\tR\x05model\x12\x14\n" +
+	"\x05query\x18\x04 \x01(\tR\x05query\x12\x12\n" +
+	"\x04sync\x18\x05 \x01(\bR\x04sync\"<\n" +
+	"\x12ListVoicesResponse\x12&\n" +
+	"\x06voices\x18\x01 \x03(\v2\x0e.silicon.VoiceR\x06voices\"%\n" +
+	"\x0fGetVoiceRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"(\n" +
+	"\x12DeleteVoiceRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\xed\x01\n" +
+	"\x12UploadVoiceRequest\x12\x14\n" +
+	"\x05model\x18\x01 \x01(\tR\x05model\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\x12\x14\n" +
+	"\x05audio\x18\x04 \x01(\fR\x05audio\x12\x1b\n" +
+	"\tfile_name\x18\x05 \x01(\tR\bfileName\x12\x1d\n" +
+	"\n" +
+	"owner_team\x18\x06 \x01(\tR\townerTeam\x12\x1a\n" +
+	"\blanguage\x18\a \x01(\tR\blanguage\x12+\n" +
+	"\x11sample_transcript\x18\b \x01(\tR\x10sampleTranscript\"Y\n" +
+	"\x13UploadVoiceResponse\x12$\n" +
+	"\x05voice\x18\x01 \x01(\v2\x0e.silicon.VoiceR\x05voice\x12\x1c\n" +
+	"\tduplicate\x18\x02 \x01(\bR\tduplicate\"\x95\x01\n" +
+	"\x17SetVoiceMetadataRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"owner_team\x18\x02 \x01(\tR\townerTeam\x12\x1a\n" +
+	"\blanguage\x18\x03 \x01(\tR\blanguage\x12+\n" +
+	"\x11sample_transcript\x18\x04 \x01(\tR\x10sampleTranscript\"\xff\x01\n" +
+	"\x11ListModelsRequest\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x19\n" +
+	"\bsub_type\x18\x02 \x01(\tR\asubType\x12%\n" +
+	"\x0esupports_tools\x18\x03 \x01(\bR\rsupportsTools\x12'\n" +
+	"\x0fsupports_vision\x18\x04 \x01(\bR\x0esupportsVision\x12#\n" +
+	"\rsupports_json\x18\x05 \x01(\bR\fsupportsJson\x12,\n" +
+	"\x12min_context_length\x18\x06 \x01(\x05R\x10minContextLength\x12\x18\n" +
+	"\arefresh\x18\a \x01(\bR\arefresh\"\xc7\x02\n" +
+	"\tModelInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\bowned_by\x18\x02 \x01(\tR\aownedBy\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x1b\n" +
+	"\tsub_types\x18\x04 \x03(\tR\bsubTypes\x12%\n" +
+	"\x0econtext_length\x18\x05 \x01(\x05R\rcontextLength\x12%\n" +
+	"\x0esupports_tools\x18\x06 \x01(\bR\rsupportsTools\x12'\n" +
+	"\x0fsupports_vision\x18\a \x01(\bR\x0esupportsVision\x12#\n" +
+	"\rsupports_json\x18\b \x01(\bR\fsupportsJson\x12\x1f\n" +
+	"\vinput_price\x18\t \x01(\x01R\n" +
+	"inputPrice\x12!\n" +
+	"\foutput_price\x18\n" +
+	" \x01(\x01R\voutputPrice\"@\n" +
+	"\x12ListModelsResponse\x12*\n" +
+	"\x06models\x18\x01 \x03(\v2\x12.silicon.ModelInfoR\x06models\"\xf4\x01\n" +
+	"\x18RenderAndCompleteRequest\x12\x1a\n" +
+	"\btemplate\x18\x01 \x01(\tR\btemplate\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12N\n" +
+	"\tvariables\x18\x03 \x03(\v20.silicon.RenderAndCompleteRequest.VariablesEntryR\tvariables\x12\x14\n" +
+	"\x05model\x18\x04 \x01(\tR\x05model\x1a<\n" +
+	"\x0eVariablesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x92\x01\n" +
+	"\x19RenderAndCompleteResponse\x12?\n" +
+	"\n" +
+	"completion\x18\x01 \x01(\v2\x1f.silicon.ChatCompletionResponseR\n" +
+	"completion\x12\x1a\n" +
+	"\btemplate\x18\x02 \x01(\tR\btemplate\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\"0\n" +
+	"\x1aListPromptTemplatesRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\xa6\x02\n" +
+	"\x15PromptTemplateVersion\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12H\n" +
+	"\bmetadata\x18\x03 \x03(\v2,.silicon.PromptTemplateVersion.MetadataEntryR\bmetadata\x12\x14\n" +
+	"\x05model\x18\x04 \x01(\tR\x05model\x12\x1c\n" +
+	"\tvariables\x18\x05 \x03(\tR\tvariables\x12\x16\n" +
+	"\x06source\x18\x06 \x01(\tR\x06source\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa1\x01\n" +
+	"\x0ePromptTemplate\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06pinned\x18\x02 \x01(\tR\x06pinned\x12'\n" +
+	"\x0fdefault_version\x18\x03 \x01(\tR\x0edefaultVersion\x12:\n" +
+	"\bversions\x18\x04 \x03(\v2\x1e.silicon.PromptTemplateVersionR\bversions\"T\n" +
+	"\x1bListPromptTemplatesResponse\x125\n" +
+	"\ttemplates\x18\x01 \x03(\v2\x17.silicon.PromptTemplateR\ttemplates\"\xce\x01\n" +
+	"\x12SubmitVideoRequest\x12\x14\n" +
+	"\x05model\x18\x01 \x01(\tR\x05model\x12\x16\n" +
+	"\x06prompt\x18\x02 \x01(\tR\x06prompt\x12'\n" +
+	"\x0fnegative_prompt\x18\x03 \x01(\tR\x0enegativePrompt\x12\x1d\n" +
+	"\n" +
+	"image_size\x18\x04 \x01(\tR\timageSize\x12\x14\n" +
+	"\x05image\x18\x05 \x01(\tR\x05image\x12\x12\n" +
+	"\x04seed\x18\x06 \x01(\x03R\x04seed\x12\x18\n" +
+	"\awebhook\x18\a \x01(\tR\awebhook\"$\n" +
+	"\x12GetVideoJobRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xfa\x01\n" +
+	"\bVideoJob\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05model\x18\x02 \x01(\tR\x05model\x12\x16\n" +
+	"\x06prompt\x18\x03 \x01(\tR\x06prompt\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12\x1b\n" +
+	"\tvideo_url\x18\x06 \x01(\tR\bvideoUrl\x12\x1d\n" +
+	"\n" +
+	"local_path\x18\a \x01(\tR\tlocalPath\x12!\n" +
+	"\fsubmitted_at\x18\b \x01(\x03R\vsubmittedAt\x12!\n" +
+	"\fcompleted_at\x18\t \x01(\x03R\vcompletedAt\"s\n" +
+	"\x1eInvalidateSemanticCacheRequest\x12\x14\n" +
+	"\x05model\x18\x01 \x01(\tR\x05model\x12#\n" +
+	"\rsystem_prompt\x18\x02 \x01(\tR\fsystemPrompt\x12\x16\n" +
+	"\x06tenant\x18\x03 \x01(\tR\x06tenant\";\n" +
+	"\x1fInvalidateSemanticCacheResponse\x12\x18\n" +
+	"\aremoved\x18\x01 \x01(\x03R\aremoved\"\xa6\x02\n" +
+	"\x12SemanticCacheStats\x12\x18\n" +
+	"\alookups\x18\x01 \x01(\x03R\alookups\x12\x12\n" +
+	"\x04hits\x18\x02 \x01(\x03R\x04hits\x12\x16\n" +
+	"\x06misses\x18\x03 \x01(\x03R\x06misses\x12\x19\n" +
+	"\bhit_rate\x18\x04 \x01(\x01R\ahitRate\x12\x18\n" +
+	"\aentries\x18\x05 \x01(\x03R\aentries\x12\x1c\n" +
+	"\tevictions\x18\x06 \x01(\x03R\tevictions\x12!\n" +
+	"\fsaved_tokens\x18\a \x01(\x03R\vsavedTokens\x12)\n" +
+	"\x10embedding_tokens\x18\b \x01(\x03R\x0fembeddingTokens\x12)\n" +
+	"\x10embedding_errors\x18\t \x01(\x03R\x0fembeddingErrors\"\a\n" +
+	"\x05Empty2\xf9\x10\n" +
+	"\x0eSiliconService\x12Q\n" +
+	"\fGetModelList\x12\x0e.silicon.Empty\x1a\x1d.silicon.GetModelListResponse\"\x12\x82\xd3\xe4\x93\x02\f\x12\n" +
+	"/v1/models\x12x\n" +
+	"\x14CreateChatCompletion\x12\x1e.silicon.ChatCompletionRequest\x1a\x1f.silicon.ChatCompletionResponse\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/v1/chat/completions\x12c\n" +
+	"\x0fCreateEmbedding\x12\x19.silicon.EmbeddingRequest\x1a\x1a.silicon.EmbeddingResponse\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/v1/embeddings\x12V\n" +
+	"\fCreateRerank\x12\x16.silicon.RerankRequest\x1a\x17.silicon.RerankResponse\"\x15\x82\xd3\xe4\x93\x02\x0f:\x01*\"\n" +
+	"/v1/rerank\x12\x84\x01\n" +
+	"\x0eIndexDocuments\x12\x1e.silicon.IndexDocumentsRequest\x1a\x1f.silicon.IndexDocumentsResponse\"1\x82\xd3\xe4\x93\x02+:\x01*\"&/v1/collections/{collection}/documents\x12i\n" +
+	"\x06Search\x12\x16.silicon.SearchRequest\x1a\x17.silicon.SearchResponse\".\x82\xd3\xe4\x93\x02(:\x01*\"#/v1/collections/{collection}/search\x12\x84\x01\n" +
+	"\x0fChatWithContext\x12\x1f.silicon.ChatWithContextRequest\x1a .silicon.ChatWithContextResponse\",\x82\xd3\xe4\x93\x02&:\x01*\"!/v1/collections/{collection}/chat0\x01\x12y\n" +
+	"\x13CreateTranscription\x12\x1b.silicon.TranscriptionChunk\x1a\x1e.silicon.TranscriptionResponse\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/v1/audio/transcriptions(\x01\x12Y\n" +
+	"\n" +
+	"ListVoices\x12\x1a.silicon.ListVoicesRequest\x1a\x1b.silicon.ListVoicesResponse\"\x12\x82\xd3\xe4\x93\x02\f\x12\n" +
+	"/v1/voices\x12O\n" +
+	"\bGetVoice\x12\x18.silicon.GetVoiceRequest\x1a\x0e.silicon.Voice\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/voices/{name}\x12_\n" +
+	"\vUploadVoice\x12\x1b.silicon.UploadVoiceRequest\x1a\x1c.silicon.UploadVoiceResponse\"\x15\x82\xd3\xe4\x93\x02\x0f:\x01*\"\n" +
+	"/v1/voices\x12k\n" +
+	"\x10SetVoiceMetadata\x12 .silicon.SetVoiceMetadataRequest\x1a\x0e.silicon.Voice\"%\x82\xd3\xe4\x93\x02\x1f:\x01*\x1a\x1a/v1/voices/{name}/metadata\x12U\n" +
+	"\vDeleteVoice\x12\x1b.silicon.DeleteVoiceRequest\x1a\x0e.silicon.Voice\"\x19\x82\xd3\xe4\x93\x02\x13*\x11/v1/voices/{name}\x12X\n" +
+	"\vSubmitVideo\x12\x1b.silicon.SubmitVideoRequest\x1a\x11.silicon.VideoJob\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/v1/video/jobs\x12Z\n" +
+	"\vGetVideoJob\x12\x1b.silicon.GetVideoJobRequest\x1a\x11.silicon.VideoJob\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/video/jobs/{id}\x12a\n" +
+	"\n" +
+	"ListModels\x12\x1a.silicon.ListModelsRequest\x1a\x1b.silicon.ListModelsResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/catalog/models\x12\x86\x01\n" +
+	"\x11RenderAndComplete\x12!.silicon.RenderAndCompleteRequest\x1a\".silicon.RenderAndCompleteResponse\"*\x82\xd3\xe4\x93\x02$:\x01*\"\x1f/v1/prompts/{template}/complete\x12u\n" +
+	"\x13ListPromptTemplates\x12#.silicon.ListPromptTemplatesRequest\x1a$.silicon.ListPromptTemplatesResponse\"\x13\x82\xd3\xe4\x93\x02\r\x12\v/v1/prompts\x12\x96\x01\n" +
+	"\x17InvalidateSemanticCache\x12'.silicon.InvalidateSemanticCacheRequest\x1a(.silicon.InvalidateSemanticCacheResponse\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/v1/cache/semantic/invalidate\x12f\n" +
+	"\x15GetSemanticCacheStats\x12\x0e.silicon.Empty\x1a\x1b.silicon.SemanticCacheStats\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/v1/cache/semantic/statsB%Z#github.com/kriswu/go_deepseek/protob\x06proto3"
 
 var (
 	file_proto_silicon_proto_rawDescOnce sync.Once
@@ -768,19 +3838,63 @@ func file_proto_silicon_proto_rawDescGZIP() []byte {
 	return file_proto_silicon_proto_rawDescData
 }
 
-var file_proto_silicon_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_proto_silicon_proto_msgTypes = make([]protoimpl.MessageInfo, 55)
 var file_proto_silicon_proto_goTypes = []any{
-	(*Model)(nil),                  // 0: silicon.Model
-	(*GetModelListResponse)(nil),   // 1: silicon.GetModelListResponse
-	(*ChatMessage)(nil),            // 2: silicon.ChatMessage
-	(*ResponseFormat)(nil),         // 3: silicon.ResponseFormat
-	(*FunctionObject)(nil),         // 4: silicon.FunctionObject
-	(*Tool)(nil),                   // 5: silicon.Tool
-	(*ChatCompletionRequest)(nil),  // 6: silicon.ChatCompletionRequest
-	(*Choice)(nil),                 // 7: silicon.Choice
-	(*Usage)(nil),                  // 8: silicon.Usage
-	(*ChatCompletionResponse)(nil), // 9: silicon.ChatCompletionResponse
-	(*Empty)(nil),                  // 10: silicon.Empty
+	(*Model)(nil),                           // 0: silicon.Model
+	(*GetModelListResponse)(nil),            // 1: silicon.GetModelListResponse
+	(*ChatMessage)(nil),                     // 2: silicon.ChatMessage
+	(*ResponseFormat)(nil),                  // 3: silicon.ResponseFormat
+	(*FunctionObject)(nil),                  // 4: silicon.FunctionObject
+	(*Tool)(nil),                            // 5: silicon.Tool
+	(*ChatCompletionRequest)(nil),           // 6: silicon.ChatCompletionRequest
+	(*Choice)(nil),                          // 7: silicon.Choice
+	(*Usage)(nil),                           // 8: silicon.Usage
+	(*ChatCompletionResponse)(nil),          // 9: silicon.ChatCompletionResponse
+	(*EmbeddingRequest)(nil),                // 10: silicon.EmbeddingRequest
+	(*Embedding)(nil),                       // 11: silicon.Embedding
+	(*EmbeddingResponse)(nil),               // 12: silicon.EmbeddingResponse
+	(*RerankRequest)(nil),                   // 13: silicon.RerankRequest
+	(*RerankResult)(nil),                    // 14: silicon.RerankResult
+	(*RerankResponse)(nil),                  // 15: silicon.RerankResponse
+	(*Document)(nil),                        // 16: silicon.Document
+	(*IndexDocumentsRequest)(nil),           // 17: silicon.IndexDocumentsRequest
+	(*IndexDocumentsResponse)(nil),          // 18: silicon.IndexDocumentsResponse
+	(*SearchRequest)(nil),                   // 19: silicon.SearchRequest
+	(*SearchHit)(nil),                       // 20: silicon.SearchHit
+	(*SearchResponse)(nil),                  // 21: silicon.SearchResponse
+	(*ChatWithContextRequest)(nil),          // 22: silicon.ChatWithContextRequest
+	(*Citation)(nil),                        // 23: silicon.Citation
+	(*ChatWithContextResponse)(nil),         // 24: silicon.ChatWithContextResponse
+	(*TranscriptionChunk)(nil),              // 25: silicon.TranscriptionChunk
+	(*TranscriptionResponse)(nil),           // 26: silicon.TranscriptionResponse
+	(*Voice)(nil),                           // 27: silicon.Voice
+	(*ListVoicesRequest)(nil),               // 28: silicon.ListVoicesRequest
+	(*ListVoicesResponse)(nil),              // 29: silicon.ListVoicesResponse
+	(*GetVoiceRequest)(nil),                 // 30: silicon.GetVoiceRequest
+	(*DeleteVoiceRequest)(nil),              // 31: silicon.DeleteVoiceRequest
+	(*UploadVoiceRequest)(nil),              // 32: silicon.UploadVoiceRequest
+	(*UploadVoiceResponse)(nil),             // 33: silicon.UploadVoiceResponse
+	(*SetVoiceMetadataRequest)(nil),         // 34: silicon.SetVoiceMetadataRequest
+	(*ListModelsRequest)(nil),               // 35: silicon.ListModelsRequest
+	(*ModelInfo)(nil),                       // 36: silicon.ModelInfo
+	(*ListModelsResponse)(nil),              // 37: silicon.ListModelsResponse
+	(*RenderAndCompleteRequest)(nil),        // 38: silicon.RenderAndCompleteRequest
+	(*RenderAndCompleteResponse)(nil),       // 39: silicon.RenderAndCompleteResponse
+	(*ListPromptTemplatesRequest)(nil),      // 40: silicon.ListPromptTemplatesRequest
+	(*PromptTemplateVersion)(nil),           // 41: silicon.PromptTemplateVersion
+	(*PromptTemplate)(nil),                  // 42: silicon.PromptTemplate
+	(*ListPromptTemplatesResponse)(nil),     // 43: silicon.ListPromptTemplatesResponse
+	(*SubmitVideoRequest)(nil),              // 44: silicon.SubmitVideoRequest
+	(*GetVideoJobRequest)(nil),              // 45: silicon.GetVideoJobRequest
+	(*VideoJob)(nil),                        // 46: silicon.VideoJob
+	(*InvalidateSemanticCacheRequest)(nil),  // 47: silicon.InvalidateSemanticCacheRequest
+	(*InvalidateSemanticCacheResponse)(nil), // 48: silicon.InvalidateSemanticCacheResponse
+	(*SemanticCacheStats)(nil),              // 49: silicon.SemanticCacheStats
+	(*Empty)(nil),                           // 50: silicon.Empty
+	nil,                                     // 51: silicon.Document.MetadataEntry
+	nil,                                     // 52: silicon.SearchHit.MetadataEntry
+	nil,                                     // 53: silicon.RenderAndCompleteRequest.VariablesEntry
+	nil,                                     // 54: silicon.PromptTemplateVersion.MetadataEntry
 }
 var file_proto_silicon_proto_depIdxs = []int32{
 	0,  // 0: silicon.GetModelListResponse.data:type_name -> silicon.Model
@@ -791,15 +3905,70 @@ var file_proto_silicon_proto_depIdxs = []int32{
 	2,  // 5: silicon.Choice.message:type_name -> silicon.ChatMessage
 	7,  // 6: silicon.ChatCompletionResponse.choices:type_name -> silicon.Choice
 	8,  // 7: silicon.ChatCompletionResponse.usage:type_name -> silicon.Usage
-	10, // 8: silicon.SiliconService.GetModelList:input_type -> silicon.Empty
-	6,  // 9: silicon.SiliconService.CreateChatCompletion:input_type -> silicon.ChatCompletionRequest
-	1,  // 10: silicon.SiliconService.GetModelList:output_type -> silicon.GetModelListResponse
-	9,  // 11: silicon.SiliconService.CreateChatCompletion:output_type -> silicon.ChatCompletionResponse
-	10, // [10:12] is the sub-list for method output_type
-	8,  // [8:10] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	11, // 8: silicon.EmbeddingResponse.data:type_name -> silicon.Embedding
+	8,  // 9: silicon.EmbeddingResponse.usage:type_name -> silicon.Usage
+	14, // 10: silicon.RerankResponse.results:type_name -> silicon.RerankResult
+	8,  // 11: silicon.RerankResponse.usage:type_name -> silicon.Usage
+	51, // 12: silicon.Document.metadata:type_name -> silicon.Document.MetadataEntry
+	16, // 13: silicon.IndexDocumentsRequest.documents:type_name -> silicon.Document
+	52, // 14: silicon.SearchHit.metadata:type_name -> silicon.SearchHit.MetadataEntry
+	20, // 15: silicon.SearchResponse.hits:type_name -> silicon.SearchHit
+	2,  // 16: silicon.ChatWithContextRequest.history:type_name -> silicon.ChatMessage
+	23, // 17: silicon.ChatWithContextResponse.citations:type_name -> silicon.Citation
+	8,  // 18: silicon.ChatWithContextResponse.usage:type_name -> silicon.Usage
+	27, // 19: silicon.ListVoicesResponse.voices:type_name -> silicon.Voice
+	27, // 20: silicon.UploadVoiceResponse.voice:type_name -> silicon.Voice
+	36, // 21: silicon.ListModelsResponse.models:type_name -> silicon.ModelInfo
+	53, // 22: silicon.RenderAndCompleteRequest.variables:type_name -> silicon.RenderAndCompleteRequest.VariablesEntry
+	9,  // 23: silicon.RenderAndCompleteResponse.completion:type_name -> silicon.ChatCompletionResponse
+	54, // 24: silicon.PromptTemplateVersion.metadata:type_name -> silicon.PromptTemplateVersion.MetadataEntry
+	41, // 25: silicon.PromptTemplate.versions:type_name -> silicon.PromptTemplateVersion
+	42, // 26: silicon.ListPromptTemplatesResponse.templates:type_name -> silicon.PromptTemplate
+	50, // 27: silicon.SiliconService.GetModelList:input_type -> silicon.Empty
+	6,  // 28: silicon.SiliconService.CreateChatCompletion:input_type -> silicon.ChatCompletionRequest
+	10, // 29: silicon.SiliconService.CreateEmbedding:input_type -> silicon.EmbeddingRequest
+	13, // 30: silicon.SiliconService.CreateRerank:input_type -> silicon.RerankRequest
+	17, // 31: silicon.SiliconService.IndexDocuments:input_type -> silicon.IndexDocumentsRequest
+	19, // 32: silicon.SiliconService.Search:input_type -> silicon.SearchRequest
+	22, // 33: silicon.SiliconService.ChatWithContext:input_type -> silicon.ChatWithContextRequest
+	25, // 34: silicon.SiliconService.CreateTranscription:input_type -> silicon.TranscriptionChunk
+	28, // 35: silicon.SiliconService.ListVoices:input_type -> silicon.ListVoicesRequest
+	30, // 36: silicon.SiliconService.GetVoice:input_type -> silicon.GetVoiceRequest
+	32, // 37: silicon.SiliconService.UploadVoice:input_type -> silicon.UploadVoiceRequest
+	34, // 38: silicon.SiliconService.SetVoiceMetadata:input_type -> silicon.SetVoiceMetadataRequest
+	31, // 39: silicon.SiliconService.DeleteVoice:input_type -> silicon.DeleteVoiceRequest
+	44, // 40: silicon.SiliconService.SubmitVideo:input_type -> silicon.SubmitVideoRequest
+	45, // 41: silicon.SiliconService.GetVideoJob:input_type -> silicon.GetVideoJobRequest
+	35, // 42: silicon.SiliconService.ListModels:input_type -> silicon.ListModelsRequest
+	38, // 43: silicon.SiliconService.RenderAndComplete:input_type -> silicon.RenderAndCompleteRequest
+	40, // 44: silicon.SiliconService.ListPromptTemplates:input_type -> silicon.ListPromptTemplatesRequest
+	47, // 45: silicon.SiliconService.InvalidateSemanticCache:input_type -> silicon.InvalidateSemanticCacheRequest
+	50, // 46: silicon.SiliconService.GetSemanticCacheStats:input_type -> silicon.Empty
+	1,  // 47: silicon.SiliconService.GetModelList:output_type -> silicon.GetModelListResponse
+	9,  // 48: silicon.SiliconService.CreateChatCompletion:output_type -> silicon.ChatCompletionResponse
+	12, // 49: silicon.SiliconService.CreateEmbedding:output_type -> silicon.EmbeddingResponse
+	15, // 50: silicon.SiliconService.CreateRerank:output_type -> silicon.RerankResponse
+	18, // 51: silicon.SiliconService.IndexDocuments:output_type -> silicon.IndexDocumentsResponse
+	21, // 52: silicon.SiliconService.Search:output_type -> silicon.SearchResponse
+	24, // 53: silicon.SiliconService.ChatWithContext:output_type -> silicon.ChatWithContextResponse
+	26, // 54: silicon.SiliconService.CreateTranscription:output_type -> silicon.TranscriptionResponse
+	29, // 55: silicon.SiliconService.ListVoices:output_type -> silicon.ListVoicesResponse
+	27, // 56: silicon.SiliconService.GetVoice:output_type -> silicon.Voice
+	33, // 57: silicon.SiliconService.UploadVoice:output_type -> silicon.UploadVoiceResponse
+	27, // 58: silicon.SiliconService.SetVoiceMetadata:output_type -> silicon.Voice
+	27, // 59: silicon.SiliconService.DeleteVoice:output_type -> silicon.Voice
+	46, // 60: silicon.SiliconService.SubmitVideo:output_type -> silicon.VideoJob
+	46, // 61: silicon.SiliconService.GetVideoJob:output_type -> silicon.VideoJob
+	37, // 62: silicon.SiliconService.ListModels:output_type -> silicon.ListModelsResponse
+	39, // 63: silicon.SiliconService.RenderAndComplete:output_type -> silicon.RenderAndCompleteResponse
+	43, // 64: silicon.SiliconService.ListPromptTemplates:output_type -> silicon.ListPromptTemplatesResponse
+	48, // 65: silicon.SiliconService.InvalidateSemanticCache:output_type -> silicon.InvalidateSemanticCacheResponse
+	49, // 66: silicon.SiliconService.GetSemanticCacheStats:output_type -> silicon.SemanticCacheStats
+	47, // [47:67] is the sub-list for method output_type
+	27, // [27:47] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
 }
 
 func init() { file_proto_silicon_proto_init() }
@@ -807,13 +3976,15 @@ func file_proto_silicon_proto_init() {
 	if File_proto_silicon_proto != nil {
 		return
 	}
+	file_proto_silicon_proto_msgTypes[6].OneofWrappers = []any{}
+	file_proto_silicon_proto_msgTypes[22].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_silicon_proto_rawDesc), len(file_proto_silicon_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   55,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,8 +19,26 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	SiliconService_GetModelList_FullMethodName         = "/silicon.SiliconService/GetModelList"
-	SiliconService_CreateChatCompletion_FullMethodName = "/silicon.SiliconService/CreateChatCompletion"
+	SiliconService_GetModelList_FullMethodName            = "/silicon.SiliconService/GetModelList"
+	SiliconService_CreateChatCompletion_FullMethodName    = "/silicon.SiliconService/CreateChatCompletion"
+	SiliconService_CreateEmbedding_FullMethodName         = "/silicon.SiliconService/CreateEmbedding"
+	SiliconService_CreateRerank_FullMethodName            = "/silicon.SiliconService/CreateRerank"
+	SiliconService_IndexDocuments_FullMethodName          = "/silicon.SiliconService/IndexDocuments"
+	SiliconService_Search_FullMethodName                  = "/silicon.SiliconService/Search"
+	SiliconService_ChatWithContext_FullMethodName         = "/silicon.SiliconService/ChatWithContext"
+	SiliconService_CreateTranscription_FullMethodName     = "/silicon.SiliconService/CreateTranscription"
+	SiliconService_ListVoices_FullMethodName              = "/silicon.SiliconService/ListVoices"
+	SiliconService_GetVoice_FullMethodName                = "/silicon.SiliconService/GetVoice"
+	SiliconService_UploadVoice_FullMethodName             = "/silicon.SiliconService/UploadVoice"
+	SiliconService_SetVoiceMetadata_FullMethodName        = "/silicon.SiliconService/SetVoiceMetadata"
+	SiliconService_DeleteVoice_FullMethodName             = "/silicon.SiliconService/DeleteVoice"
+	SiliconService_SubmitVideo_FullMethodName             = "/silicon.SiliconService/SubmitVideo"
+	SiliconService_GetVideoJob_FullMethodName             = "/silicon.SiliconService/GetVideoJob"
+	SiliconService_ListModels_FullMethodName              = "/silicon.SiliconService/ListModels"
+	SiliconService_RenderAndComplete_FullMethodName       = "/silicon.SiliconService/RenderAndComplete"
+	SiliconService_ListPromptTemplates_FullMethodName     = "/silicon.SiliconService/ListPromptTemplates"
+	SiliconService_InvalidateSemanticCache_FullMethodName = "/silicon.SiliconService/InvalidateSemanticCache"
+	SiliconService_GetSemanticCacheStats_FullMethodName   = "/silicon.SiliconService/GetSemanticCacheStats"
 )
 
 // SiliconServiceClient is the client API for SiliconService service.
@@ -31,8 +49,44 @@ const (
 type SiliconServiceClient interface {
 	// 获取模型列表
 	GetModelList(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetModelListResponse, error)
-	// 创建聊天对话
+	// 创建聊天对话，启用缓存时响应头x-cache为hit、miss或semantic_hit
 	CreateChatCompletion(ctx context.Context, in *ChatCompletionRequest, opts ...grpc.CallOption) (*ChatCompletionResponse, error)
+	// 创建文本嵌入，启用缓存时响应头x-cache为hit或miss
+	CreateEmbedding(ctx context.Context, in *EmbeddingRequest, opts ...grpc.CallOption) (*EmbeddingResponse, error)
+	// 文档重排序，启用缓存时响应头x-cache为hit或miss
+	CreateRerank(ctx context.Context, in *RerankRequest, opts ...grpc.CallOption) (*RerankResponse, error)
+	// 索引文档到集合
+	IndexDocuments(ctx context.Context, in *IndexDocumentsRequest, opts ...grpc.CallOption) (*IndexDocumentsResponse, error)
+	// 在集合中检索文本块
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	// 基于集合内容的检索增强聊天
+	ChatWithContext(ctx context.Context, in *ChatWithContextRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ChatWithContextResponse], error)
+	// 上传音频分片并返回转写文本
+	CreateTranscription(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[TranscriptionChunk, TranscriptionResponse], error)
+	// 查询音色
+	ListVoices(ctx context.Context, in *ListVoicesRequest, opts ...grpc.CallOption) (*ListVoicesResponse, error)
+	// 按名称或URI获取音色
+	GetVoice(ctx context.Context, in *GetVoiceRequest, opts ...grpc.CallOption) (*Voice, error)
+	// 上传音色并登记元数据
+	UploadVoice(ctx context.Context, in *UploadVoiceRequest, opts ...grpc.CallOption) (*UploadVoiceResponse, error)
+	// 按名称或URI更新音色元数据
+	SetVoiceMetadata(ctx context.Context, in *SetVoiceMetadataRequest, opts ...grpc.CallOption) (*Voice, error)
+	// 按名称或URI删除音色
+	DeleteVoice(ctx context.Context, in *DeleteVoiceRequest, opts ...grpc.CallOption) (*Voice, error)
+	// 提交视频生成任务，服务端轮询直到任务结束
+	SubmitVideo(ctx context.Context, in *SubmitVideoRequest, opts ...grpc.CallOption) (*VideoJob, error)
+	// 查询视频任务状态
+	GetVideoJob(ctx context.Context, in *GetVideoJobRequest, opts ...grpc.CallOption) (*VideoJob, error)
+	// 按类型和能力查询模型目录
+	ListModels(ctx context.Context, in *ListModelsRequest, opts ...grpc.CallOption) (*ListModelsResponse, error)
+	// 渲染提示词模板并发起对话
+	RenderAndComplete(ctx context.Context, in *RenderAndCompleteRequest, opts ...grpc.CallOption) (*RenderAndCompleteResponse, error)
+	// 查询提示词模板及其版本
+	ListPromptTemplates(ctx context.Context, in *ListPromptTemplatesRequest, opts ...grpc.CallOption) (*ListPromptTemplatesResponse, error)
+	// 删除匹配的语义缓存条目
+	InvalidateSemanticCache(ctx context.Context, in *InvalidateSemanticCacheRequest, opts ...grpc.CallOption) (*InvalidateSemanticCacheResponse, error)
+	// 查询语义缓存统计信息
+	GetSemanticCacheStats(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SemanticCacheStats, error)
 }
 
 type siliconServiceClient struct {
@@ -63,6 +117,198 @@ func (c *siliconServiceClient) CreateChatCompletion(ctx context.Context, in *Cha
 	return out, nil
 }
 
+func (c *siliconServiceClient) CreateEmbedding(ctx context.Context, in *EmbeddingRequest, opts ...grpc.CallOption) (*EmbeddingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmbeddingResponse)
+	err := c.cc.Invoke(ctx, SiliconService_CreateEmbedding_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *siliconServiceClient) CreateRerank(ctx context.Context, in *RerankRequest, opts ...grpc.CallOption) (*RerankResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RerankResponse)
+	err := c.cc.Invoke(ctx, SiliconService_CreateRerank_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *siliconServiceClient) IndexDocuments(ctx context.Context, in *IndexDocumentsRequest, opts ...grpc.CallOption) (*IndexDocumentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IndexDocumentsResponse)
+	err := c.cc.Invoke(ctx, SiliconService_IndexDocuments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *siliconServiceClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, SiliconService_Search_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *siliconServiceClient) ChatWithContext(ctx context.Context, in *ChatWithContextRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ChatWithContextResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SiliconService_ServiceDesc.Streams[0], SiliconService_ChatWithContext_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ChatWithContextRequest, ChatWithContextResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SiliconService_ChatWithContextClient = grpc.ServerStreamingClient[ChatWithContextResponse]
+
+func (c *siliconServiceClient) CreateTranscription(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[TranscriptionChunk, TranscriptionResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SiliconService_ServiceDesc.Streams[1], SiliconService_CreateTranscription_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[TranscriptionChunk, TranscriptionResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SiliconService_CreateTranscriptionClient = grpc.ClientStreamingClient[TranscriptionChunk, TranscriptionResponse]
+
+func (c *siliconServiceClient) ListVoices(ctx context.Context, in *ListVoicesRequest, opts ...grpc.CallOption) (*ListVoicesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListVoicesResponse)
+	err := c.cc.Invoke(ctx, SiliconService_ListVoices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *siliconServiceClient) GetVoice(ctx context.Context, in *GetVoiceRequest, opts ...grpc.CallOption) (*Voice, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Voice)
+	err := c.cc.Invoke(ctx, SiliconService_GetVoice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *siliconServiceClient) UploadVoice(ctx context.Context, in *UploadVoiceRequest, opts ...grpc.CallOption) (*UploadVoiceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadVoiceResponse)
+	err := c.cc.Invoke(ctx, SiliconService_UploadVoice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *siliconServiceClient) SetVoiceMetadata(ctx context.Context, in *SetVoiceMetadataRequest, opts ...grpc.CallOption) (*Voice, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Voice)
+	err := c.cc.Invoke(ctx, SiliconService_SetVoiceMetadata_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *siliconServiceClient) DeleteVoice(ctx context.Context, in *DeleteVoiceRequest, opts ...grpc.CallOption) (*Voice, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Voice)
+	err := c.cc.Invoke(ctx, SiliconService_DeleteVoice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *siliconServiceClient) SubmitVideo(ctx context.Context, in *SubmitVideoRequest, opts ...grpc.CallOption) (*VideoJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VideoJob)
+	err := c.cc.Invoke(ctx, SiliconService_SubmitVideo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *siliconServiceClient) GetVideoJob(ctx context.Context, in *GetVideoJobRequest, opts ...grpc.CallOption) (*VideoJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VideoJob)
+	err := c.cc.Invoke(ctx, SiliconService_GetVideoJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *siliconServiceClient) ListModels(ctx context.Context, in *ListModelsRequest, opts ...grpc.CallOption) (*ListModelsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListModelsResponse)
+	err := c.cc.Invoke(ctx, SiliconService_ListModels_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *siliconServiceClient) RenderAndComplete(ctx context.Context, in *RenderAndCompleteRequest, opts ...grpc.CallOption) (*RenderAndCompleteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenderAndCompleteResponse)
+	err := c.cc.Invoke(ctx, SiliconService_RenderAndComplete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *siliconServiceClient) ListPromptTemplates(ctx context.Context, in *ListPromptTemplatesRequest, opts ...grpc.CallOption) (*ListPromptTemplatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPromptTemplatesResponse)
+	err := c.cc.Invoke(ctx, SiliconService_ListPromptTemplates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *siliconServiceClient) InvalidateSemanticCache(ctx context.Context, in *InvalidateSemanticCacheRequest, opts ...grpc.CallOption) (*InvalidateSemanticCacheResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InvalidateSemanticCacheResponse)
+	err := c.cc.Invoke(ctx, SiliconService_InvalidateSemanticCache_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *siliconServiceClient) GetSemanticCacheStats(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SemanticCacheStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SemanticCacheStats)
+	err := c.cc.Invoke(ctx, SiliconService_GetSemanticCacheStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SiliconServiceServer is the server API for SiliconService service.
 // All implementations must embed UnimplementedSiliconServiceServer
 // for forward compatibility.
@@ -71,8 +317,44 @@ func (c *siliconServiceClient) CreateChatCompletion(ctx context.Context, in *Cha
 type SiliconServiceServer interface {
 	// 获取模型列表
 	GetModelList(context.Context, *Empty) (*GetModelListResponse, error)
-	// 创建聊天对话
+	// 创建聊天对话，启用缓存时响应头x-cache为hit、miss或semantic_hit
 	CreateChatCompletion(context.Context, *ChatCompletionRequest) (*ChatCompletionResponse, error)
+	// 创建文本嵌入，启用缓存时响应头x-cache为hit或miss
+	CreateEmbedding(context.Context, *EmbeddingRequest) (*EmbeddingResponse, error)
+	// 文档重排序，启用缓存时响应头x-cache为hit或miss
+	CreateRerank(context.Context, *RerankRequest) (*RerankResponse, error)
+	// 索引文档到集合
+	IndexDocuments(context.Context, *IndexDocumentsRequest) (*IndexDocumentsResponse, error)
+	// 在集合中检索文本块
+	Search(context.Context, *SearchRequest) (*SearchResponse, error)
+	// 基于集合内容的检索增强聊天
+	ChatWithContext(*ChatWithContextRequest, grpc.ServerStreamingServer[ChatWithContextResponse]) error
+	// 上传音频分片并返回转写文本
+	CreateTranscription(grpc.ClientStreamingServer[TranscriptionChunk, TranscriptionResponse]) error
+	// 查询音色
+	ListVoices(context.Context, *ListVoicesRequest) (*ListVoicesResponse, error)
+	// 按名称或URI获取音色
+	GetVoice(context.Context, *GetVoiceRequest) (*Voice, error)
+	// 上传音色并登记元数据
+	UploadVoice(context.Context, *UploadVoiceRequest) (*UploadVoiceResponse, error)
+	// 按名称或URI更新音色元数据
+	SetVoiceMetadata(context.Context, *SetVoiceMetadataRequest) (*Voice, error)
+	// 按名称或URI删除音色
+	DeleteVoice(context.Context, *DeleteVoiceRequest) (*Voice, error)
+	// 提交视频生成任务，服务端轮询直到任务结束
+	SubmitVideo(context.Context, *SubmitVideoRequest) (*VideoJob, error)
+	// 查询视频任务状态
+	GetVideoJob(context.Context, *GetVideoJobRequest) (*VideoJob, error)
+	// 按类型和能力查询模型目录
+	ListModels(context.Context, *ListModelsRequest) (*ListModelsResponse, error)
+	// 渲染提示词模板并发起对话
+	RenderAndComplete(context.Context, *RenderAndCompleteRequest) (*RenderAndCompleteResponse, error)
+	// 查询提示词模板及其版本
+	ListPromptTemplates(context.Context, *ListPromptTemplatesRequest) (*ListPromptTemplatesResponse, error)
+	// 删除匹配的语义缓存条目
+	InvalidateSemanticCache(context.Context, *InvalidateSemanticCacheRequest) (*InvalidateSemanticCacheResponse, error)
+	// 查询语义缓存统计信息
+	GetSemanticCacheStats(context.Context, *Empty) (*SemanticCacheStats, error)
 	mustEmbedUnimplementedSiliconServiceServer()
 }
 
@@ -89,6 +371,60 @@ func (UnimplementedSiliconServiceServer) GetModelList(context.Context, *Empty) (
 func (UnimplementedSiliconServiceServer) CreateChatCompletion(context.Context, *ChatCompletionRequest) (*ChatCompletionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateChatCompletion not implemented")
 }
+func (UnimplementedSiliconServiceServer) CreateEmbedding(context.Context, *EmbeddingRequest) (*EmbeddingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateEmbedding not implemented")
+}
+func (UnimplementedSiliconServiceServer) CreateRerank(context.Context, *RerankRequest) (*RerankResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRerank not implemented")
+}
+func (UnimplementedSiliconServiceServer) IndexDocuments(context.Context, *IndexDocumentsRequest) (*IndexDocumentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IndexDocuments not implemented")
+}
+func (UnimplementedSiliconServiceServer) Search(context.Context, *SearchRequest) (*SearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (UnimplementedSiliconServiceServer) ChatWithContext(*ChatWithContextRequest, grpc.ServerStreamingServer[ChatWithContextResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ChatWithContext not implemented")
+}
+func (UnimplementedSiliconServiceServer) CreateTranscription(grpc.ClientStreamingServer[TranscriptionChunk, TranscriptionResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method CreateTranscription not implemented")
+}
+func (UnimplementedSiliconServiceServer) ListVoices(context.Context, *ListVoicesRequest) (*ListVoicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListVoices not implemented")
+}
+func (UnimplementedSiliconServiceServer) GetVoice(context.Context, *GetVoiceRequest) (*Voice, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVoice not implemented")
+}
+func (UnimplementedSiliconServiceServer) UploadVoice(context.Context, *UploadVoiceRequest) (*UploadVoiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UploadVoice not implemented")
+}
+func (UnimplementedSiliconServiceServer) SetVoiceMetadata(context.Context, *SetVoiceMetadataRequest) (*Voice, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetVoiceMetadata not implemented")
+}
+func (UnimplementedSiliconServiceServer) DeleteVoice(context.Context, *DeleteVoiceRequest) (*Voice, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteVoice not implemented")
+}
+func (UnimplementedSiliconServiceServer) SubmitVideo(context.Context, *SubmitVideoRequest) (*VideoJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitVideo not implemented")
+}
+func (UnimplementedSiliconServiceServer) GetVideoJob(context.Context, *GetVideoJobRequest) (*VideoJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVideoJob not implemented")
+}
+func (UnimplementedSiliconServiceServer) ListModels(context.Context, *ListModelsRequest) (*ListModelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListModels not implemented")
+}
+func (UnimplementedSiliconServiceServer) RenderAndComplete(context.Context, *RenderAndCompleteRequest) (*RenderAndCompleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenderAndComplete not implemented")
+}
+func (UnimplementedSiliconServiceServer) ListPromptTemplates(context.Context, *ListPromptTemplatesRequest) (*ListPromptTemplatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPromptTemplates not implemented")
+}
+func (UnimplementedSiliconServiceServer) InvalidateSemanticCache(context.Context, *InvalidateSemanticCacheRequest) (*InvalidateSemanticCacheResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InvalidateSemanticCache not implemented")
+}
+func (UnimplementedSiliconServiceServer) GetSemanticCacheStats(context.Context, *Empty) (*SemanticCacheStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSemanticCacheStats not implemented")
+}
 func (UnimplementedSiliconServiceServer) mustEmbedUnimplementedSiliconServiceServer() {}
 func (UnimplementedSiliconServiceServer) testEmbeddedByValue()                        {}
 
@@ -146,6 +482,312 @@ func _SiliconService_CreateChatCompletion_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SiliconService_CreateEmbedding_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmbeddingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SiliconServiceServer).CreateEmbedding(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SiliconService_CreateEmbedding_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SiliconServiceServer).CreateEmbedding(ctx, req.(*EmbeddingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SiliconService_CreateRerank_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RerankRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SiliconServiceServer).CreateRerank(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SiliconService_CreateRerank_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SiliconServiceServer).CreateRerank(ctx, req.(*RerankRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SiliconService_IndexDocuments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IndexDocumentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SiliconServiceServer).IndexDocuments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SiliconService_IndexDocuments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SiliconServiceServer).IndexDocuments(ctx, req.(*IndexDocumentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SiliconService_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SiliconServiceServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SiliconService_Search_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SiliconServiceServer).Search(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SiliconService_ChatWithContext_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ChatWithContextRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SiliconServiceServer).ChatWithContext(m, &grpc.GenericServerStream[ChatWithContextRequest, ChatWithContextResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SiliconService_ChatWithContextServer = grpc.ServerStreamingServer[ChatWithContextResponse]
+
+func _SiliconService_CreateTranscription_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SiliconServiceServer).CreateTranscription(&grpc.GenericServerStream[TranscriptionChunk, TranscriptionResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SiliconService_CreateTranscriptionServer = grpc.ClientStreamingServer[TranscriptionChunk, TranscriptionResponse]
+
+func _SiliconService_ListVoices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVoicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SiliconServiceServer).ListVoices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SiliconService_ListVoices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SiliconServiceServer).ListVoices(ctx, req.(*ListVoicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SiliconService_GetVoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVoiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SiliconServiceServer).GetVoice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SiliconService_GetVoice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SiliconServiceServer).GetVoice(ctx, req.(*GetVoiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SiliconService_UploadVoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadVoiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SiliconServiceServer).UploadVoice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SiliconService_UploadVoice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SiliconServiceServer).UploadVoice(ctx, req.(*UploadVoiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SiliconService_SetVoiceMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetVoiceMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SiliconServiceServer).SetVoiceMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SiliconService_SetVoiceMetadata_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SiliconServiceServer).SetVoiceMetadata(ctx, req.(*SetVoiceMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SiliconService_DeleteVoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteVoiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SiliconServiceServer).DeleteVoice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SiliconService_DeleteVoice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SiliconServiceServer).DeleteVoice(ctx, req.(*DeleteVoiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SiliconService_SubmitVideo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitVideoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SiliconServiceServer).SubmitVideo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SiliconService_SubmitVideo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SiliconServiceServer).SubmitVideo(ctx, req.(*SubmitVideoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SiliconService_GetVideoJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVideoJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SiliconServiceServer).GetVideoJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SiliconService_GetVideoJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SiliconServiceServer).GetVideoJob(ctx, req.(*GetVideoJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SiliconService_ListModels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListModelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SiliconServiceServer).ListModels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SiliconService_ListModels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SiliconServiceServer).ListModels(ctx, req.(*ListModelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SiliconService_RenderAndComplete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenderAndCompleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SiliconServiceServer).RenderAndComplete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SiliconService_RenderAndComplete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SiliconServiceServer).RenderAndComplete(ctx, req.(*RenderAndCompleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SiliconService_ListPromptTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPromptTemplatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SiliconServiceServer).ListPromptTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SiliconService_ListPromptTemplates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SiliconServiceServer).ListPromptTemplates(ctx, req.(*ListPromptTemplatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SiliconService_InvalidateSemanticCache_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InvalidateSemanticCacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SiliconServiceServer).InvalidateSemanticCache(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SiliconService_InvalidateSemanticCache_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SiliconServiceServer).InvalidateSemanticCache(ctx, req.(*InvalidateSemanticCacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SiliconService_GetSemanticCacheStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SiliconServiceServer).GetSemanticCacheStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SiliconService_GetSemanticCacheStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SiliconServiceServer).GetSemanticCacheStats(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SiliconService_ServiceDesc is the grpc.ServiceDesc for SiliconService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -161,7 +803,82 @@ var SiliconService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateChatCompletion",
 			Handler:    _SiliconService_CreateChatCompletion_Handler,
 		},
+		{
+			MethodName: "CreateEmbedding",
+			Handler:    _SiliconService_CreateEmbedding_Handler,
+		},
+		{
+			MethodName: "CreateRerank",
+			Handler:    _SiliconService_CreateRerank_Handler,
+		},
+		{
+			MethodName: "IndexDocuments",
+			Handler:    _SiliconService_IndexDocuments_Handler,
+		},
+		{
+			MethodName: "Search",
+			Handler:    _SiliconService_Search_Handler,
+		},
+		{
+			MethodName: "ListVoices",
+			Handler:    _SiliconService_ListVoices_Handler,
+		},
+		{
+			MethodName: "GetVoice",
+			Handler:    _SiliconService_GetVoice_Handler,
+		},
+		{
+			MethodName: "UploadVoice",
+			Handler:    _SiliconService_UploadVoice_Handler,
+		},
+		{
+			MethodName: "SetVoiceMetadata",
+			Handler:    _SiliconService_SetVoiceMetadata_Handler,
+		},
+		{
+			MethodName: "DeleteVoice",
+			Handler:    _SiliconService_DeleteVoice_Handler,
+		},
+		{
+			MethodName: "SubmitVideo",
+			Handler:    _SiliconService_SubmitVideo_Handler,
+		},
+		{
+			MethodName: "GetVideoJob",
+			Handler:    _SiliconService_GetVideoJob_Handler,
+		},
+		{
+			MethodName: "ListModels",
+			Handler:    _SiliconService_ListModels_Handler,
+		},
+		{
+			MethodName: "RenderAndComplete",
+			Handler:    _SiliconService_RenderAndComplete_Handler,
+		},
+		{
+			MethodName: "ListPromptTemplates",
+			Handler:    _SiliconService_ListPromptTemplates_Handler,
+		},
+		{
+			MethodName: "InvalidateSemanticCache",
+			Handler:    _SiliconService_InvalidateSemanticCache_Handler,
+		},
+		{
+			MethodName: "GetSemanticCacheStats",
+			Handler:    _SiliconService_GetSemanticCacheStats_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ChatWithContext",
+			Handler:       _SiliconService_ChatWithContext_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "CreateTranscription",
+			Handler:       _SiliconService_CreateTranscription_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/silicon.proto",
 }
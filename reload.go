@@ -0,0 +1,180 @@
+package main
+
+import (
+	"expvar"
+	"log"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/kriswu/go_deepseek/balancemonitor"
+	"github.com/kriswu/go_deepseek/config"
+	"github.com/kriswu/go_deepseek/grpc"
+	"github.com/kriswu/go_deepseek/modelcatalog"
+	"github.com/kriswu/go_deepseek/prompttemplate"
+	"github.com/kriswu/go_deepseek/siliconproxy"
+)
+
+// 配置重载指标
+var (
+	reloadsMetric    = expvar.NewMap("silicon_config_reloads") // 按结果计数：success、failure
+	lastReloadMetric = expvar.NewMap("silicon_config_last_reload")
+	tlsReloadsMetric = expvar.NewMap("silicon_tls_reloads") // 按结果计数：success、failure
+)
+
+// 配置重载器，在运行中替换上游凭据、租户、模型能力、提示词模板和余额监控
+// 监听地址、资源限制、缓存、检索等在启动时创建的组件需要重启后才能生效
+// 资源限制是gRPC服务器选项，只能在创建服务器时设置
+type reloader struct {
+	loader  *config.Loader
+	sp      *siliconproxy.SiliconProxy
+	server  *grpc.SiliconServer
+	catalog *modelcatalog.Catalog
+	prompts *prompttemplate.Registry
+	watcher *config.Watcher
+
+	mu      sync.Mutex
+	current *config.Config
+	monitor *balancemonitor.Monitor // 未启用余额监控时为nil
+}
+
+// 创建重载器，conf为启动时使用的配置，monitor为已启动的余额监控
+func newReloader(loader *config.Loader, conf *config.Config, sp *siliconproxy.SiliconProxy, server *grpc.SiliconServer, catalog *modelcatalog.Catalog, prompts *prompttemplate.Registry, monitor *balancemonitor.Monitor) *reloader {
+	r := &reloader{loader: loader, sp: sp, server: server, catalog: catalog, prompts: prompts, current: conf, monitor: monitor}
+	r.watcher = config.NewWatcher(0, func() { r.reload("文件变化") })
+	r.watcher.SetPaths(r.watchedFiles(conf))
+	return r
+}
+
+// 返回需要监视的文件
+func (r *reloader) watchedFiles(conf *config.Config) []string {
+	files := append([]string{r.loader.Path()}, conf.SecretFiles()...)
+	if conf.ModelCatalog != nil && conf.ModelCatalog.CapabilitiesFile != "" {
+		files = append(files, conf.ModelCatalog.CapabilitiesFile)
+	}
+	// 模板目录中新增的文件需要SIGHUP才能加载
+	files = append(files, r.prompts.Files()...)
+	return files
+}
+
+// 重新加载配置，失败时保留当前配置
+// 先加载并校验全部配置项，全部成功后才一起替换，不会只生效一部分
+func (r *reloader) reload(reason string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	lastReloadMetric.Set("time", stringVar(time.Now().Format(time.RFC3339)))
+	lastReloadMetric.Set("reason", stringVar(reason))
+
+	conf, err := r.loader.Load()
+	var caps map[string]modelcatalog.Capabilities
+	if err == nil && r.catalog != nil && conf.ModelCatalog != nil && conf.ModelCatalog.CapabilitiesFile != "" {
+		caps, err = modelcatalog.LoadCapabilities(conf.ModelCatalog.CapabilitiesFile)
+	}
+	var prompts *prompttemplate.Registry
+	if err == nil {
+		prompts, err = prompttemplate.Load(conf.PromptTemplates.Settings())
+	}
+	if err != nil {
+		reloadsMetric.Add("failure", 1)
+		lastReloadMetric.Set("result", stringVar("failure"))
+		lastReloadMetric.Set("error", stringVar(err.Error()))
+		log.Printf("重载配置失败（%s），继续使用当前配置: %v", reason, err)
+		return
+	}
+
+	for _, section := range restartRequired(r.current, conf) {
+		log.Printf("配置项%s已修改，需要重启后生效", section)
+	}
+
+	if caps != nil {
+		r.catalog.SetCapabilities(caps)
+	}
+	r.prompts.Replace(prompts)
+	r.sp.SetCredentials(conf.Upstream.Token, conf.Upstream.BaseURL)
+	r.server.SetTenants(newTenants(conf, r.sp), conf.RequireTenant)
+	r.reloadMonitor(conf)
+	r.watcher.SetPaths(r.watchedFiles(conf))
+	r.current = conf
+
+	reloadsMetric.Add("success", 1)
+	lastReloadMetric.Set("result", stringVar("success"))
+	lastReloadMetric.Set("error", stringVar(""))
+	log.Printf("配置已重载（%s），租户数: %d", reason, len(conf.Tenants))
+}
+
+// 应用新的余额监控配置，只有阈值变化时直接更换阈值，保留已告警状态
+// 其他变化时停止旧监控并按新配置重新创建
+func (r *reloader) reloadMonitor(conf *config.Config) {
+	old, next := r.current.BalanceMonitor, conf.BalanceMonitor
+	if reflect.DeepEqual(monitorSettings(old, r.current.Upstream), monitorSettings(next, conf.Upstream)) {
+		if next != nil && old.Threshold != next.Threshold {
+			r.monitor.SetThreshold(next.Threshold)
+		}
+		return
+	}
+
+	if r.monitor != nil {
+		r.monitor.Stop()
+		r.monitor = nil
+	}
+	if next != nil {
+		r.monitor = newBalanceMonitor(next, conf.Upstream)
+		r.monitor.Start()
+	}
+}
+
+// 返回创建余额监控所用的配置，不含阈值，conf为nil时返回nil
+func monitorSettings(conf *config.BalanceMonitorConfig, upstream config.UpstreamConfig) any {
+	if conf == nil {
+		return nil
+	}
+	c := *conf
+	c.Threshold = 0
+	return struct {
+		Monitor        config.BalanceMonitorConfig
+		Token, BaseURL string
+	}{c, upstream.Token, upstream.BaseURL}
+}
+
+// 停止余额监控，用于退出
+func (r *reloader) stopMonitor() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.monitor != nil {
+		r.monitor.Stop()
+		r.monitor = nil
+	}
+}
+
+// 返回新旧配置中有差异且无法在运行中替换的配置项
+func restartRequired(old, next *config.Config) []string {
+	var sections []string
+	check := func(name string, a, b any) {
+		if !reflect.DeepEqual(a, b) {
+			sections = append(sections, name)
+		}
+	}
+	check("upstream.timeout_seconds", old.Upstream.TimeoutSeconds, next.Upstream.TimeoutSeconds)
+	check("listen", old.Listen, next.Listen)
+	check("limits", old.Limits, next.Limits)
+	check("shutdown", old.Shutdown, next.Shutdown)
+	check("cache", old.Cache, next.Cache)
+	check("semantic_cache", old.SemanticCache, next.SemanticCache)
+	check("retrieval", old.Retrieval, next.Retrieval)
+	check("voice_registry", old.VoiceRegistry, next.VoiceRegistry)
+	check("health_probe", old.HealthProbe, next.HealthProbe)
+	check("admin", old.Admin, next.Admin)
+	if (old.ModelCatalog == nil) != (next.ModelCatalog == nil) ||
+		(old.ModelCatalog != nil && old.ModelCatalog.RefreshIntervalSeconds != next.ModelCatalog.RefreshIntervalSeconds) {
+		sections = append(sections, "model_catalog")
+	}
+	return sections
+}
+
+// 构造expvar字符串
+func stringVar(s string) *expvar.String {
+	v := new(expvar.String)
+	v.Set(s)
+	return v
+}
@@ -0,0 +1,82 @@
+package retrieval
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// 默认分块参数，单位为字符
+const (
+	DefaultChunkSize    = 500
+	DefaultChunkOverlap = 50
+)
+
+// Chunk 表示文档中的一个文本块
+type Chunk struct {
+	Text  string
+	Start int // 在原文中的起始字节偏移
+	End   int // 在原文中的结束字节偏移（不含）
+}
+
+// 句子结束符，分块时优先在这些位置切分
+const sentenceEnds = "。！？；.!?;\n"
+
+// SplitText 将文本切分为最多size个字符的块，相邻块重叠overlap个字符
+// 每块尽量在窗口后半段的句子边界处结束
+func SplitText(text string, size, overlap int) []Chunk {
+	if size <= 0 {
+		size = DefaultChunkSize
+	}
+	if overlap < 0 || overlap >= size {
+		overlap = 0
+	}
+
+	// 记录每个字符的字节偏移，便于按字符计数、按字节返回
+	offsets := make([]int, 0, utf8.RuneCountInString(text)+1)
+	for i := range text {
+		offsets = append(offsets, i)
+	}
+	total := len(offsets)
+	offsets = append(offsets, len(text))
+
+	var chunks []Chunk
+	for start := 0; start < total; {
+		end := start + size
+		if end >= total {
+			end = total
+		} else {
+			// 在窗口后半段寻找最后一个句子结束符
+			for i := end - 1; i > start+size/2; i-- {
+				r, _ := utf8.DecodeRuneInString(text[offsets[i]:])
+				if strings.ContainsRune(sentenceEnds, r) {
+					end = i + 1
+					break
+				}
+			}
+		}
+
+		// 去掉首尾空白，偏移随之收缩，保证text[Start:End] == Text
+		raw := text[offsets[start]:offsets[end]]
+		chunkText := strings.TrimLeftFunc(raw, unicode.IsSpace)
+		chunkStart := offsets[start] + len(raw) - len(chunkText)
+		chunkText = strings.TrimRightFunc(chunkText, unicode.IsSpace)
+		if chunkText != "" {
+			chunks = append(chunks, Chunk{
+				Text:  chunkText,
+				Start: chunkStart,
+				End:   chunkStart + len(chunkText),
+			})
+		}
+
+		if end == total {
+			break
+		}
+		next := end - overlap
+		if next <= start {
+			next = end
+		}
+		start = next
+	}
+	return chunks
+}
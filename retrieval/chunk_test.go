@@ -0,0 +1,34 @@
+package retrieval
+
+import "testing"
+
+func TestSplitTextOffsets(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		size    int
+		overlap int
+		want    []string
+	}{
+		{name: "首尾空白不计入偏移", text: "  你好。\n", size: 10, want: []string{"你好。"}},
+		{name: "句子边界后的换行与空格", text: "第一句。\n  第二句。 ", size: 6, want: []string{"第一句。", "第二句。"}},
+		{name: "重叠切分", text: "abcdef ghij", size: 6, overlap: 2, want: []string{"abcdef", "ef ghi", "hij"}},
+		{name: "全是空白", text: " \n\t ", size: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunks := SplitText(tt.text, tt.size, tt.overlap)
+			if len(chunks) != len(tt.want) {
+				t.Fatalf("切分为%+v，期望%q", chunks, tt.want)
+			}
+			for i, c := range chunks {
+				if c.Text != tt.want[i] {
+					t.Fatalf("第%d块为%q，期望%q", i, c.Text, tt.want[i])
+				}
+				if got := tt.text[c.Start:c.End]; got != c.Text {
+					t.Fatalf("第%d块偏移[%d,%d)对应%q，期望%q", i, c.Start, c.End, got, c.Text)
+				}
+			}
+		})
+	}
+}
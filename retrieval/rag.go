@@ -0,0 +1,121 @@
+package retrieval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/kriswu/go_deepseek/siliconproxy"
+)
+
+// 默认的上下文token预算
+const DefaultContextTokens = 3000
+
+// DefaultPromptTemplate 是默认的检索增强系统提示词模板
+// 可用变量：.Context 为编号后的参考资料，.Query 为用户问题
+const DefaultPromptTemplate = `请根据以下参考资料回答用户的问题。引用资料时使用方括号标注编号，例如[1]。如果参考资料中没有相关信息，请直接说明。
+
+参考资料：
+{{.Context}}`
+
+// ContextChatRequest 表示检索增强聊天请求
+type ContextChatRequest struct {
+	Collection     string
+	Model          string
+	Query          string
+	History        []siliconproxy.ChatCompletionMessage // 之前的对话轮次，不含本次问题
+	TopK           int
+	RerankModel    string   // 为空时使用配置中的默认模型，均为空则不重排序
+	PromptTemplate string   // 为空时使用DefaultPromptTemplate
+	ContextTokens  int      // 参考资料的token预算，默认3000
+	Temperature    *float64 // 为nil时使用模型默认值
+	MaxTokens      int
+}
+
+// Citation 表示回答引用的文本块，Index与提示词中的编号一致
+type Citation struct {
+	Index      int
+	DocumentID string
+	ChunkIndex int
+	Start      int
+	End        int
+	Score      float64
+}
+
+// BuildContextChat 检索相关文本块并构造聊天请求
+// 文本块按相关性依次加入提示词，直到用完token预算
+func (s *Store) BuildContextChat(ctx context.Context, req *ContextChatRequest) (*siliconproxy.ChatCompletionRequest, []Citation, error) {
+	if req.Query == "" {
+		return nil, nil, errors.New("问题不能为空")
+	}
+
+	tmplText := req.PromptTemplate
+	if tmplText == "" {
+		tmplText = DefaultPromptTemplate
+	}
+	tmpl, err := template.New("context").Parse(tmplText)
+	if err != nil {
+		return nil, nil, fmt.Errorf("解析提示词模板失败: %w", err)
+	}
+
+	results, err := s.Search(ctx, &SearchRequest{
+		Collection:  req.Collection,
+		Query:       req.Query,
+		TopK:        req.TopK,
+		Rerank:      req.RerankModel != "" || s.conf.RerankModel != "",
+		RerankModel: req.RerankModel,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	budget := req.ContextTokens
+	if budget <= 0 {
+		budget = DefaultContextTokens
+	}
+
+	var (
+		sb        strings.Builder
+		citations []Citation
+		used      int
+	)
+	for _, r := range results {
+		entry := fmt.Sprintf("[%d] (文档: %s)\n%s\n\n", len(citations)+1, r.Chunk.DocumentID, r.Chunk.Text)
+		tokens := siliconproxy.EstimateTokens(entry)
+		if used+tokens > budget {
+			continue
+		}
+		used += tokens
+		sb.WriteString(entry)
+		citations = append(citations, Citation{
+			Index:      len(citations) + 1,
+			DocumentID: r.Chunk.DocumentID,
+			ChunkIndex: r.Chunk.Index,
+			Start:      r.Chunk.Start,
+			End:        r.Chunk.End,
+			Score:      r.Score,
+		})
+	}
+
+	var prompt strings.Builder
+	if err := tmpl.Execute(&prompt, map[string]string{
+		"Context": strings.TrimSpace(sb.String()),
+		"Query":   req.Query,
+	}); err != nil {
+		return nil, nil, fmt.Errorf("渲染提示词模板失败: %w", err)
+	}
+
+	messages := make([]siliconproxy.ChatCompletionMessage, 0, len(req.History)+2)
+	messages = append(messages, siliconproxy.ChatCompletionMessage{Role: "system", Content: prompt.String()})
+	messages = append(messages, req.History...)
+	messages = append(messages, siliconproxy.ChatCompletionMessage{Role: "user", Content: req.Query})
+
+	return &siliconproxy.ChatCompletionRequest{
+		Model:       req.Model,
+		Messages:    messages,
+		Temperature: req.Temperature,
+		MaxTokens:   req.MaxTokens,
+	}, citations, nil
+}
@@ -0,0 +1,424 @@
+package retrieval
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/kriswu/go_deepseek/siliconproxy"
+	"github.com/kriswu/go_deepseek/vectorindex"
+)
+
+// 默认检索参数
+const (
+	DefaultTopK          = 5
+	DefaultRerankFanout  = 4 // 重排序时召回TopK的倍数作为候选
+	indexFileName        = "index.gob"
+	chunksFileName       = "chunks.json"
+	collectionNameFormat = `^[A-Za-z0-9_-]{1,64}$`
+)
+
+var collectionNamePattern = regexp.MustCompile(collectionNameFormat)
+
+// ErrCollectionNotFound 表示集合不存在
+var ErrCollectionNotFound = errors.New("集合不存在")
+
+// Config 表示检索存储配置
+type Config struct {
+	Dir            string // 数据目录，每个集合一个子目录
+	EmbeddingModel string
+	RerankModel    string // 默认重排序模型
+	IndexType      string // flat或hnsw，默认flat
+	ChunkSize      int    // 分块大小（字符），默认500
+	ChunkOverlap   int    // 分块重叠（字符），默认50
+}
+
+// Document 表示待索引的文档
+type Document struct {
+	ID       string
+	Text     string
+	Metadata map[string]string
+}
+
+// ChunkRecord 表示已索引的文本块
+type ChunkRecord struct {
+	ID         string            `json:"id"`
+	DocumentID string            `json:"document_id"`
+	Index      int               `json:"index"`
+	Text       string            `json:"text"`
+	Start      int               `json:"start"`
+	End        int               `json:"end"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+}
+
+// IndexStats 表示一次索引操作的统计
+type IndexStats struct {
+	Documents       int
+	Chunks          int
+	EmbeddingTokens int
+}
+
+// SearchRequest 表示检索请求
+type SearchRequest struct {
+	Collection  string
+	Query       string
+	TopK        int    // 返回结果数，默认5
+	Rerank      bool   // 是否使用CreateRerank重排序
+	RerankModel string // 为空时使用配置中的默认模型
+}
+
+// SearchResult 表示一条检索结果
+type SearchResult struct {
+	Chunk ChunkRecord
+	Score float64 // 未重排序时为余弦相似度，重排序后为相关性分数
+}
+
+// Store 管理按集合划分的文档向量索引
+type Store struct {
+	sp   *siliconproxy.SiliconProxy
+	conf Config
+
+	mu          sync.Mutex
+	collections map[string]*collection
+}
+
+type collection struct {
+	mu        sync.RWMutex
+	dir       string
+	indexType string
+	index     vectorindex.Index
+	chunks    map[string]*ChunkRecord
+	docs      map[string][]string // 文档ID到文本块ID列表
+}
+
+// NewStore 创建检索存储
+func NewStore(sp *siliconproxy.SiliconProxy, conf Config) (*Store, error) {
+	if conf.EmbeddingModel == "" {
+		return nil, errors.New("未配置嵌入模型")
+	}
+	if conf.ChunkSize <= 0 {
+		conf.ChunkSize = DefaultChunkSize
+	}
+	if conf.ChunkOverlap <= 0 {
+		conf.ChunkOverlap = DefaultChunkOverlap
+	}
+	if _, err := vectorindex.New(conf.IndexType); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(conf.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("创建数据目录失败: %w", err)
+	}
+
+	return &Store{
+		sp:          sp,
+		conf:        conf,
+		collections: make(map[string]*collection),
+	}, nil
+}
+
+// IndexDocuments 将文档切块、计算向量并写入集合，集合不存在时自动创建
+// 已存在的同ID文档会被整体替换
+func (s *Store) IndexDocuments(ctx context.Context, name string, docs []Document) (*IndexStats, error) {
+	col, err := s.collection(name, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// 切块
+	var records []*ChunkRecord
+	for _, doc := range docs {
+		if doc.ID == "" {
+			return nil, errors.New("文档ID不能为空")
+		}
+		for i, chunk := range SplitText(doc.Text, s.conf.ChunkSize, s.conf.ChunkOverlap) {
+			records = append(records, &ChunkRecord{
+				ID:         doc.ID + "#" + strconv.Itoa(i),
+				DocumentID: doc.ID,
+				Index:      i,
+				Text:       chunk.Text,
+				Start:      chunk.Start,
+				End:        chunk.End,
+				Metadata:   doc.Metadata,
+			})
+		}
+	}
+
+	stats := &IndexStats{Documents: len(docs), Chunks: len(records)}
+
+	// 计算向量
+	var embeddings [][]float64
+	if len(records) > 0 {
+		texts := make([]string, len(records))
+		for i, r := range records {
+			texts[i] = r.Text
+		}
+		resp, err := s.sp.CreateEmbeddingsBatch(ctx, &siliconproxy.BatchEmbeddingRequest{
+			Model: s.conf.EmbeddingModel,
+			Texts: texts,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("计算文本块向量失败: %w", err)
+		}
+		embeddings = resp.Embeddings
+		stats.EmbeddingTokens = resp.Usage.TotalTokens
+	}
+
+	col.mu.Lock()
+	defer col.mu.Unlock()
+
+	// 修改集合前检查全部向量，避免旧文档已删除而新文本块写入失败
+	if len(embeddings) != len(records) {
+		return nil, fmt.Errorf("计算文本块向量失败: 返回%d个向量，期望%d个", len(embeddings), len(records))
+	}
+	dim := col.index.Dim()
+	for i, vec := range embeddings {
+		if dim == 0 {
+			dim = len(vec)
+		}
+		if len(vec) == 0 || len(vec) != dim {
+			return nil, fmt.Errorf("文本块%s的向量维度为%d，集合维度为%d: %w", records[i].ID, len(vec), dim, vectorindex.ErrDimensionMismatch)
+		}
+	}
+
+	for _, doc := range docs {
+		col.removeDocument(doc.ID)
+	}
+	for i, r := range records {
+		if err := col.index.Add(r.ID, vectorindex.FromFloat64(embeddings[i])); err != nil {
+			return nil, fmt.Errorf("写入索引失败: %w", err)
+		}
+		col.chunks[r.ID] = r
+		col.docs[r.DocumentID] = append(col.docs[r.DocumentID], r.ID)
+	}
+
+	if err := col.save(); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// DeleteDocuments 从集合中删除文档，返回删除的文本块数量
+func (s *Store) DeleteDocuments(name string, ids []string) (int, error) {
+	col, err := s.collection(name, false)
+	if err != nil {
+		return 0, err
+	}
+
+	col.mu.Lock()
+	defer col.mu.Unlock()
+
+	removed := 0
+	for _, id := range ids {
+		removed += col.removeDocument(id)
+	}
+	if err := col.save(); err != nil {
+		return 0, err
+	}
+	return removed, nil
+}
+
+// Search 检索与查询最相关的文本块，可选使用CreateRerank重排序
+func (s *Store) Search(ctx context.Context, req *SearchRequest) ([]SearchResult, error) {
+	col, err := s.collection(req.Collection, false)
+	if err != nil {
+		return nil, err
+	}
+
+	topK := req.TopK
+	if topK <= 0 {
+		topK = DefaultTopK
+	}
+	candidates := topK
+	if req.Rerank {
+		candidates = topK * DefaultRerankFanout
+	}
+
+	// 计算查询向量
+	embResp, err := s.sp.CreateEmbedding(ctx, &siliconproxy.EmbeddingRequest{
+		Model: s.conf.EmbeddingModel,
+		Input: req.Query,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("计算查询向量失败: %w", err)
+	}
+	if len(embResp.Data) == 0 {
+		return nil, errors.New("计算查询向量失败: 响应为空")
+	}
+
+	col.mu.RLock()
+	hits, err := col.index.Search(vectorindex.FromFloat64(embResp.Data[0].Embedding), candidates)
+	if err != nil {
+		col.mu.RUnlock()
+		return nil, fmt.Errorf("检索失败: %w", err)
+	}
+	results := make([]SearchResult, 0, len(hits))
+	for _, hit := range hits {
+		if chunk, ok := col.chunks[hit.ID]; ok {
+			results = append(results, SearchResult{Chunk: *chunk, Score: hit.Score})
+		}
+	}
+	col.mu.RUnlock()
+
+	if req.Rerank && len(results) > 0 {
+		return s.rerank(ctx, req, results, topK)
+	}
+	if len(results) > topK {
+		results = results[:topK]
+	}
+	return results, nil
+}
+
+// 使用CreateRerank对候选结果重排序
+func (s *Store) rerank(ctx context.Context, req *SearchRequest, candidates []SearchResult, topK int) ([]SearchResult, error) {
+	model := req.RerankModel
+	if model == "" {
+		model = s.conf.RerankModel
+	}
+	if model == "" {
+		return nil, errors.New("未配置重排序模型")
+	}
+
+	ranked, err := siliconproxy.RerankItems(ctx, s.sp, siliconproxy.RerankRequest{
+		Model: model,
+		Query: req.Query,
+		TopN:  topK,
+	}, candidates, func(c SearchResult) string { return c.Chunk.Text })
+	if err != nil {
+		return nil, fmt.Errorf("重排序失败: %w", err)
+	}
+
+	results := make([]SearchResult, len(ranked))
+	for i, r := range ranked {
+		results[i] = r.Item
+		results[i].Score = r.RelevanceScore
+	}
+	if len(results) > topK {
+		results = results[:topK]
+	}
+	return results, nil
+}
+
+// 获取集合，未加载时从磁盘加载，create为true时不存在则创建
+func (s *Store) collection(name string, create bool) (*collection, error) {
+	if !collectionNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("集合名称不合法: %q", name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if col, ok := s.collections[name]; ok {
+		return col, nil
+	}
+
+	dir := filepath.Join(s.conf.Dir, name)
+	col, err := loadCollection(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		if !create {
+			return nil, fmt.Errorf("%w: %s", ErrCollectionNotFound, name)
+		}
+		col, err = newCollection(dir, s.conf.IndexType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.collections[name] = col
+	return col, nil
+}
+
+func newCollection(dir, indexType string) (*collection, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("创建集合目录失败: %w", err)
+	}
+	idx, err := vectorindex.New(indexType)
+	if err != nil {
+		return nil, err
+	}
+	return &collection{
+		dir:       dir,
+		indexType: indexType,
+		index:     idx,
+		chunks:    make(map[string]*ChunkRecord),
+		docs:      make(map[string][]string),
+	}, nil
+}
+
+// 从磁盘加载集合，集合不存在时返回os.ErrNotExist
+func loadCollection(dir string) (*collection, error) {
+	data, err := os.ReadFile(filepath.Join(dir, chunksFileName))
+	if err != nil {
+		return nil, err
+	}
+	var records []*ChunkRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("解析文本块文件失败: %w", err)
+	}
+
+	idx, indexType, err := vectorindex.Load(filepath.Join(dir, indexFileName))
+	if err != nil {
+		return nil, err
+	}
+
+	col := &collection{
+		dir:       dir,
+		indexType: indexType,
+		index:     idx,
+		chunks:    make(map[string]*ChunkRecord, len(records)),
+		docs:      make(map[string][]string),
+	}
+	for _, r := range records {
+		col.chunks[r.ID] = r
+		col.docs[r.DocumentID] = append(col.docs[r.DocumentID], r.ID)
+	}
+	return col, nil
+}
+
+// 删除文档的全部文本块，调用方需持有写锁
+func (c *collection) removeDocument(docID string) int {
+	ids := c.docs[docID]
+	for _, id := range ids {
+		c.index.Remove(id)
+		delete(c.chunks, id)
+	}
+	delete(c.docs, docID)
+	return len(ids)
+}
+
+// 保存集合到磁盘，调用方需持有写锁
+func (c *collection) save() error {
+	if err := vectorindex.Save(filepath.Join(c.dir, indexFileName), c.indexType, c.index); err != nil {
+		return err
+	}
+
+	records := make([]*ChunkRecord, 0, len(c.chunks))
+	for _, r := range c.chunks {
+		records = append(records, r)
+	}
+	sort.Slice(records, func(a, b int) bool {
+		if records[a].DocumentID != records[b].DocumentID {
+			return records[a].DocumentID < records[b].DocumentID
+		}
+		return records[a].Index < records[b].Index
+	})
+
+	data, err := json.Marshal(records)
+	if err != nil {
+		return fmt.Errorf("序列化文本块失败: %w", err)
+	}
+	path := filepath.Join(c.dir, chunksFileName)
+	if err := os.WriteFile(path+".tmp", data, 0o644); err != nil {
+		return fmt.Errorf("写入文本块文件失败: %w", err)
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return fmt.Errorf("重命名文本块文件失败: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package retrieval
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/kriswu/go_deepseek/siliconproxy"
+	"github.com/kriswu/go_deepseek/vectorindex"
+)
+
+// 模拟嵌入接口，返回维度为dim的向量
+func newEmbeddingUpstream(t *testing.T, dim *atomic.Int64) *siliconproxy.SiliconProxy {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Input []string `json:"input"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		data := make([]map[string]any, len(req.Input))
+		for i := range req.Input {
+			vec := make([]float64, dim.Load())
+			vec[i%len(vec)] = 1
+			data[i] = map[string]any{"index": i, "embedding": vec}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": data, "usage": map[string]int{"total_tokens": len(req.Input)}})
+	}))
+	t.Cleanup(srv.Close)
+
+	sp := siliconproxy.NewSiliconProxy("token")
+	sp.SetBaseURL(srv.URL)
+	return sp
+}
+
+func TestIndexDocumentsDimensionMismatchKeepsCollection(t *testing.T) {
+	for _, indexType := range []string{vectorindex.TypeFlat, vectorindex.TypeHNSW} {
+		t.Run(indexType, func(t *testing.T) {
+			var dim atomic.Int64
+			dim.Store(4)
+			dir := t.TempDir()
+			store, err := NewStore(newEmbeddingUpstream(t, &dim), Config{Dir: dir, EmbeddingModel: "e", IndexType: indexType})
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx := context.Background()
+			if _, err := store.IndexDocuments(ctx, "docs", []Document{{ID: "a", Text: "hello"}}); err != nil {
+				t.Fatal(err)
+			}
+
+			dim.Store(8)
+			_, err = store.IndexDocuments(ctx, "docs", []Document{{ID: "a", Text: "replaced"}, {ID: "b", Text: "new"}})
+			if !errors.Is(err, vectorindex.ErrDimensionMismatch) {
+				t.Fatalf("错误为%v，期望ErrDimensionMismatch", err)
+			}
+
+			// 内存和磁盘中都应保留原文档
+			reloaded, err := NewStore(store.sp, store.conf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, s := range []*Store{store, reloaded} {
+				col, err := s.collection("docs", false)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(col.chunks) != 1 || col.chunks["a#0"] == nil || col.chunks["a#0"].Text != "hello" {
+					t.Fatalf("文本块 %+v", col.chunks)
+				}
+				if col.index.Len() != 1 {
+					t.Fatalf("索引向量数%d，期望1", col.index.Len())
+				}
+			}
+		})
+	}
+}
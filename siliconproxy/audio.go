@@ -1,29 +1,44 @@
 package siliconproxy
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"mime/multipart"
-	"net/http"
 	"os"
 	"path/filepath"
+
+	"github.com/kriswu/go_deepseek/httpclient"
 )
 
 // UploadVoiceRequest 表示上传参考音频请求
+// 音频来源三选一：FilePath、Reader（multipart上传）或Audio（JSON上传）
 type UploadVoiceRequest struct {
-	Name        string `json:"name"`
-	Description string `json:"description,omitempty"`
-	FilePath    string `json:"-"` // 不序列化到JSON
+	Model      string `json:"model"`      // 如FunAudioLLM/CosyVoice2-0.5B
+	CustomName string `json:"customName"` // 自定义音色名称
+	Text       string `json:"text"`       // 参考音频对应的文字
+	// base64数据URI或音频URL，见SetAudioFromFile
+	Audio string `json:"audio,omitempty"`
+
+	FilePath string    `json:"-"` // 本地音频文件路径
+	Reader   io.Reader `json:"-"` // 音频数据流
+	FileName string    `json:"-"` // 使用Reader时上传的文件名
+}
+
+// SetAudioFromFile 读取本地音频文件并以base64数据URI作为Audio
+func (req *UploadVoiceRequest) SetAudioFromFile(path string) error {
+	uri, err := DataURIFromFile(path)
+	if err != nil {
+		return err
+	}
+	req.Audio = uri
+	return nil
 }
 
-// UploadVoiceResponse 表示上传参考音频响应
+// UploadVoiceResponse 表示上传参考音频响应，URI可直接作为CreateSpeechRequest的Voice
 type UploadVoiceResponse struct {
-	ID          string `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	CreatedAt   int64  `json:"created_at"`
+	URI string `json:"uri"`
 }
 
 // CreateSpeechRequest 表示文本转语音请求
@@ -31,139 +46,120 @@ type CreateSpeechRequest struct {
 	Model          string  `json:"model"`
 	Input          string  `json:"input"`
 	Voice          string  `json:"voice"`
-	ResponseFormat string  `json:"response_format,omitempty"`
+	ResponseFormat string  `json:"response_format,omitempty"` // mp3、opus、wav或pcm
 	Speed          float64 `json:"speed,omitempty"`
+	Stream         bool    `json:"stream,omitempty"`
+	SampleRate     int     `json:"sample_rate,omitempty"`
+	Gain           float64 `json:"gain,omitempty"` // 音量增益(dB)
 }
 
 // VoiceListResponse 表示参考音频列表响应
 type VoiceListResponse struct {
-	Object string      `json:"object"`
-	Data   []VoiceData `json:"data"`
+	Result []VoiceData `json:"result"`
 }
 
 // VoiceData 表示参考音频数据
 type VoiceData struct {
-	ID          string `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	CreatedAt   int64  `json:"created_at"`
+	Model      string `json:"model"`
+	CustomName string `json:"customName"`
+	Text       string `json:"text"`
+	URI        string `json:"uri"`
 }
 
 // DeleteVoiceRequest 表示删除参考音频请求
 type DeleteVoiceRequest struct {
-	ID string `json:"id"`
+	URI string `json:"uri"`
 }
 
 // DeleteVoiceResponse 表示删除参考音频响应
 type DeleteVoiceResponse struct {
-	ID      string `json:"id"`
+	URI     string `json:"uri"`
 	Deleted bool   `json:"deleted"`
 }
 
-// UploadVoice 上传参考音频
-func (sp *SiliconProxy) UploadVoice(req *UploadVoiceRequest) (*UploadVoiceResponse, error) {
-	url := BaseURL + UploadVoicePath
-
-	// 打开文件
-	file, err := os.Open(req.FilePath)
-	if err != nil {
-		return nil, fmt.Errorf("打开文件失败: %w", err)
-	}
-	defer file.Close()
-
-	// 创建multipart表单
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
-
-	// 添加文件
-	part, err := writer.CreateFormFile("file", filepath.Base(req.FilePath))
-	if err != nil {
-		return nil, fmt.Errorf("创建表单文件失败: %w", err)
-	}
-
-	if _, err = io.Copy(part, file); err != nil {
-		return nil, fmt.Errorf("复制文件内容失败: %w", err)
-	}
-
-	// 添加其他字段
-	if err = writer.WriteField("name", req.Name); err != nil {
-		return nil, fmt.Errorf("添加name字段失败: %w", err)
-	}
-
-	if req.Description != "" {
-		if err = writer.WriteField("description", req.Description); err != nil {
-			return nil, fmt.Errorf("添加description字段失败: %w", err)
+// UploadVoice 上传参考音频，返回TTS使用的音色URI
+func (sp *SiliconProxy) UploadVoice(ctx context.Context, req *UploadVoiceRequest) (*UploadVoiceResponse, error) {
+	url := sp.endpoint(UploadVoicePath)
+
+	var (
+		resp *httpclient.Response
+		err  error
+	)
+	switch {
+	case req.FilePath != "" || req.Reader != nil:
+		reader := req.Reader
+		fileName := req.FileName
+		if req.FilePath != "" {
+			// 打开文件
+			file, err := os.Open(req.FilePath)
+			if err != nil {
+				return nil, fmt.Errorf("打开文件失败: %w", err)
+			}
+			defer file.Close()
+			reader = file
+			fileName = filepath.Base(req.FilePath)
+		}
+		if fileName == "" {
+			fileName = "audio"
 		}
-	}
-
-	if err = writer.Close(); err != nil {
-		return nil, fmt.Errorf("关闭writer失败: %w", err)
-	}
 
-	// 创建请求
-	httpReq, err := http.NewRequest("POST", url, body)
-	if err != nil {
-		return nil, fmt.Errorf("创建请求失败: %w", err)
+		// 以multipart表单发送
+		resp, err = sp.client.PostMultipartWithAuth(ctx, url,
+			map[string]string{
+				"model":      req.Model,
+				"customName": req.CustomName,
+				"text":       req.Text,
+			},
+			[]httpclient.MultipartFile{{FieldName: "file", FileName: fileName, Reader: reader}},
+			sp.authToken())
+	case req.Audio != "":
+		// 将请求转换为JSON
+		reqBody, marshalErr := json.Marshal(req)
+		if marshalErr != nil {
+			return nil, fmt.Errorf("序列化请求失败: %w", marshalErr)
+		}
+		resp, err = sp.client.PostWithAuth(ctx, url, "application/json", string(reqBody), sp.authToken())
+	default:
+		return nil, errors.New("未提供参考音频")
 	}
 
-	// 设置请求头
-	httpReq.Header.Set("Content-Type", writer.FormDataContentType())
-	httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", sp.token))
-
-	// 发送请求
-	resp, err := sp.client.Post(url, writer.FormDataContentType(), body.String())
+	resp, err = sp.handleAPIResponse(resp, err)
 	if err != nil {
-		return nil, fmt.Errorf("发送请求失败: %w", err)
-	}
-	// 读取响应体
-	respBody := []byte(resp.Body)
-
-	// 检查状态码
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		var errResp ErrorResponse
-		if err := json.Unmarshal(respBody, &errResp); err == nil {
-			return nil, fmt.Errorf("API错误: %s (类型: %s, 代码: %s)",
-				errResp.Error.Message, errResp.Error.Type, errResp.Error.Code)
-		}
-		return nil, fmt.Errorf("API请求失败，状态码: %d, 响应: %s", resp.StatusCode, string(respBody))
+		return nil, err
 	}
 
 	// 解析响应
 	var result UploadVoiceResponse
-	if err := json.Unmarshal(respBody, &result); err != nil {
+	if err := json.Unmarshal([]byte(resp.Body), &result); err != nil {
 		return nil, fmt.Errorf("解析响应失败: %w", err)
 	}
 
 	return &result, nil
 }
 
-// CreateSpeech 创建文本转语音请求
-func (sp *SiliconProxy) CreateSpeech(req *CreateSpeechRequest) ([]byte, error) {
-	url := BaseURL + CreateSpeechPath
-
-	// 将请求转换为JSON
-	reqBody, err := json.Marshal(req)
+// CreateSpeech 创建文本转语音请求，返回完整的音频数据
+// 长音频建议使用CreateSpeechStream以便边接收边处理
+func (sp *SiliconProxy) CreateSpeech(ctx context.Context, req *CreateSpeechRequest) ([]byte, error) {
+	stream, err := sp.CreateSpeechStream(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("序列化请求失败: %w", err)
+		return nil, err
 	}
+	defer stream.Close()
 
-	// 发送POST请求
-	resp, err := sp.client.PostWithAuth(url, "application/json", string(reqBody), sp.token)
-	resp, err = sp.handleAPIResponse(resp, err)
+	// 读取音频数据
+	data, err := io.ReadAll(stream)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("读取音频数据失败: %w", err)
 	}
-
-	// 返回原始响应体（音频数据）
-	return []byte(resp.Body), nil
+	return data, nil
 }
 
 // GetVoiceList 获取参考音频列表
-func (sp *SiliconProxy) GetVoiceList() (*VoiceListResponse, error) {
-	url := BaseURL + VoiceListPath
+func (sp *SiliconProxy) GetVoiceList(ctx context.Context) (*VoiceListResponse, error) {
+	url := sp.endpoint(VoiceListPath)
 
 	// 发送GET请求
-	resp, err := sp.client.GetWithAuth(url, sp.token)
+	resp, err := sp.client.GetWithAuth(ctx, url, sp.authToken())
 	resp, err = sp.handleAPIResponse(resp, err)
 	if err != nil {
 		return nil, err
@@ -179,8 +175,8 @@ func (sp *SiliconProxy) GetVoiceList() (*VoiceListResponse, error) {
 }
 
 // DeleteVoice 删除参考音频
-func (sp *SiliconProxy) DeleteVoice(req *DeleteVoiceRequest) (*DeleteVoiceResponse, error) {
-	url := BaseURL + DeleteVoicePath
+func (sp *SiliconProxy) DeleteVoice(ctx context.Context, req *DeleteVoiceRequest) (*DeleteVoiceResponse, error) {
+	url := sp.endpoint(DeleteVoicePath)
 
 	// 将请求转换为JSON
 	reqBody, err := json.Marshal(req)
@@ -189,17 +185,11 @@ func (sp *SiliconProxy) DeleteVoice(req *DeleteVoiceRequest) (*DeleteVoiceRespon
 	}
 
 	// 发送POST请求
-	resp, err := sp.client.PostWithAuth(url, "application/json", string(reqBody), sp.token)
-	resp, err = sp.handleAPIResponse(resp, err)
-	if err != nil {
+	resp, err := sp.client.PostWithAuth(ctx, url, "application/json", string(reqBody), sp.authToken())
+	if _, err := sp.handleAPIResponse(resp, err); err != nil {
 		return nil, err
 	}
 
-	// 解析响应
-	var result DeleteVoiceResponse
-	if err := json.Unmarshal([]byte(resp.Body), &result); err != nil {
-		return nil, fmt.Errorf("解析响应失败: %w", err)
-	}
-
-	return &result, nil
+	// 上游成功时不返回内容
+	return &DeleteVoiceResponse{URI: req.URI, Deleted: true}, nil
 }
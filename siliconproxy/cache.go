@@ -0,0 +1,71 @@
+package siliconproxy
+
+import (
+	"context"
+	"time"
+
+	"github.com/kriswu/go_deepseek/cache"
+)
+
+// 缓存状态，写入响应的CacheStatus字段
+const (
+	CacheHit  = "hit"
+	CacheMiss = "miss"
+)
+
+// EnableCache 为确定性请求启用响应缓存，ttl为0表示永不过期
+// 缓存作用于CreateEmbedding、CreateRerank和显式设置温度为0的非流式CreateChatCompletion
+func (sp *SiliconProxy) EnableCache(c cache.Cache, ttl time.Duration) {
+	sp.cache = c
+	sp.cacheTTL = ttl
+}
+
+// WithTenant 返回绑定到指定租户的代理副本，不同租户的缓存互相隔离
+func (sp *SiliconProxy) WithTenant(tenant string) *SiliconProxy {
+	scoped := *sp
+	scoped.tenant = tenant
+	return &scoped
+}
+
+// 判断聊天请求的结果是否确定，只有确定性请求才能缓存
+// 未设置温度时上游使用模型默认值，结果不确定
+func isDeterministicChat(req *ChatCompletionRequest) bool {
+	return !req.Stream && req.Temperature != nil && *req.Temperature == 0 && req.N <= 1
+}
+
+// Float64 返回指向v的指针，用于设置可选的浮点参数
+func Float64(v float64) *float64 {
+	return &v
+}
+
+// 发送JSON POST请求，cacheable为true且启用了缓存时先查询缓存
+// 返回响应体和缓存状态，未使用缓存时缓存状态为空
+func (sp *SiliconProxy) postJSON(ctx context.Context, path, model string, reqBody []byte, cacheable bool) (string, string, error) {
+	url := sp.endpoint(path)
+
+	if !cacheable || sp.cache == nil {
+		resp, err := sp.client.PostWithAuth(ctx, url, "application/json", string(reqBody), sp.authToken())
+		resp, err = sp.handleAPIResponse(resp, err)
+		if err != nil {
+			return "", "", err
+		}
+		return resp.Body, "", nil
+	}
+
+	key, err := cache.Key(sp.tenant, path, model, reqBody)
+	if err != nil {
+		return "", "", err
+	}
+	if data, ok := sp.cache.Get(key); ok {
+		return string(data), CacheHit, nil
+	}
+
+	resp, err := sp.client.PostWithAuth(ctx, url, "application/json", string(reqBody), sp.authToken())
+	resp, err = sp.handleAPIResponse(resp, err)
+	if err != nil {
+		return "", "", err
+	}
+	sp.cache.Set(key, []byte(resp.Body), sp.cacheTTL)
+
+	return resp.Body, CacheMiss, nil
+}
@@ -0,0 +1,24 @@
+package siliconproxy
+
+import "testing"
+
+func TestIsDeterministicChat(t *testing.T) {
+	tests := []struct {
+		name string
+		req  ChatCompletionRequest
+		want bool
+	}{
+		{name: "未设置温度", req: ChatCompletionRequest{}, want: false},
+		{name: "温度显式为0", req: ChatCompletionRequest{Temperature: Float64(0)}, want: true},
+		{name: "温度非0", req: ChatCompletionRequest{Temperature: Float64(0.7)}, want: false},
+		{name: "流式请求", req: ChatCompletionRequest{Temperature: Float64(0), Stream: true}, want: false},
+		{name: "多个候选", req: ChatCompletionRequest{Temperature: Float64(0), N: 2}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isDeterministicChat(&tt.req); got != tt.want {
+				t.Fatalf("isDeterministicChat() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
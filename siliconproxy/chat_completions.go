@@ -1,6 +1,7 @@
 package siliconproxy
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 )
@@ -12,7 +13,7 @@ type ChatCompletionRequest struct {
 	Stream           bool                    `json:"stream,omitempty"`
 	MaxTokens        int                     `json:"max_tokens,omitempty"`
 	Stop             interface{}             `json:"stop,omitempty"`
-	Temperature      float64                 `json:"temperature,omitempty"`
+	Temperature      *float64                `json:"temperature,omitempty"` // 为nil时使用模型默认值
 	TopP             float64                 `json:"top_p,omitempty"`
 	TopK             int                     `json:"top_k,omitempty"`
 	FrequencyPenalty float64                 `json:"frequency_penalty,omitempty"`
@@ -54,6 +55,9 @@ type ChatCompletionResponse struct {
 	Created int64                    `json:"created"`
 	Model   string                   `json:"model"`
 	Object  string                   `json:"object"`
+
+	// 缓存状态（hit/miss），未启用缓存时为空；gRPC服务通过x-cache响应头返回
+	CacheStatus string `json:"-"`
 }
 
 // ChatCompletionChoice 表示聊天完成选择
@@ -91,9 +95,7 @@ type ChatCompletionUsage struct {
 }
 
 // CreateChatCompletion 创建聊天完成请求
-func (sp *SiliconProxy) CreateChatCompletion(req *ChatCompletionRequest) (*ChatCompletionResponse, error) {
-	url := BaseURL + ChatCompletionsPath
-	
+func (sp *SiliconProxy) CreateChatCompletion(ctx context.Context, req *ChatCompletionRequest) (*ChatCompletionResponse, error) {
 	// 将请求转换为JSON
 	reqBody, err := json.Marshal(req)
 	if err != nil {
@@ -101,28 +103,17 @@ func (sp *SiliconProxy) CreateChatCompletion(req *ChatCompletionRequest) (*ChatC
 	}
 	
 	// 发送POST请求
-	resp, err := sp.client.PostWithAuth(url, "application/json", string(reqBody), sp.token)
-	resp, err = sp.handleAPIResponse(resp, err)
+	body, cacheStatus, err := sp.postJSON(ctx, ChatCompletionsPath, req.Model, reqBody, isDeterministicChat(req))
 	if err != nil {
 		return nil, err
 	}
 	
 	// 解析响应
 	var result ChatCompletionResponse
-	if err := json.Unmarshal([]byte(resp.Body), &result); err != nil {
+	if err := json.Unmarshal([]byte(body), &result); err != nil {
 		return nil, fmt.Errorf("解析响应失败: %w", err)
 	}
+	result.CacheStatus = cacheStatus
 	
 	return &result, nil
 }
-
-// CreateChatCompletionStream 创建流式聊天完成请求
-// 注意：此方法返回原始响应，客户端需要自行处理SSE流
-func (sp *SiliconProxy) CreateChatCompletionStream(req *ChatCompletionRequest) (*ChatCompletionResponse, error) {
-	// 确保流式标志设置为true
-	req.Stream = true
-	
-	// 使用普通的CreateChatCompletion方法
-	// 注意：实际实现中，应该处理SSE流，这里简化处理
-	return sp.CreateChatCompletion(req)
-}
\ No newline at end of file
@@ -0,0 +1,105 @@
+package siliconproxy
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ChatCompletionStreamChunk 表示流式聊天响应中的一个数据块
+type ChatCompletionStreamChunk struct {
+	ID      string                       `json:"id"`
+	Object  string                       `json:"object"`
+	Created int64                        `json:"created"`
+	Model   string                       `json:"model"`
+	Choices []ChatCompletionStreamChoice `json:"choices"`
+	Usage   *ChatCompletionUsage         `json:"usage,omitempty"`
+}
+
+// ChatCompletionStreamChoice 表示流式聊天响应中的选择
+type ChatCompletionStreamChoice struct {
+	Index        int                       `json:"index"`
+	Delta        ChatCompletionStreamDelta `json:"delta"`
+	FinishReason string                    `json:"finish_reason,omitempty"`
+}
+
+// ChatCompletionStreamDelta 表示流式聊天响应的增量消息
+type ChatCompletionStreamDelta struct {
+	Role             string     `json:"role,omitempty"`
+	Content          string     `json:"content,omitempty"`
+	ReasoningContent string     `json:"reasoning_content,omitempty"`
+	ToolCalls        []ToolCall `json:"tool_calls,omitempty"`
+}
+
+// ChatCompletionStream 表示流式聊天响应，使用完毕后需调用Close
+type ChatCompletionStream struct {
+	body    io.ReadCloser
+	scanner *bufio.Scanner
+}
+
+// Recv 读取下一个数据块，流结束时返回io.EOF
+func (s *ChatCompletionStream) Recv() (*ChatCompletionStreamChunk, error) {
+	for s.scanner.Scan() {
+		line := strings.TrimSpace(s.scanner.Text())
+		if !strings.HasPrefix(line, "data:") {
+			// 跳过空行、注释和其他SSE字段
+			continue
+		}
+
+		data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		if data == "[DONE]" {
+			return nil, io.EOF
+		}
+
+		var chunk ChatCompletionStreamChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return nil, fmt.Errorf("解析流式响应失败: %w", err)
+		}
+		return &chunk, nil
+	}
+
+	if err := s.scanner.Err(); err != nil {
+		return nil, fmt.Errorf("读取流式响应失败: %w", err)
+	}
+	return nil, io.EOF
+}
+
+// Close 关闭响应流
+func (s *ChatCompletionStream) Close() error {
+	return s.body.Close()
+}
+
+// CreateChatCompletionStream 创建流式聊天完成请求，通过返回的流逐块读取SSE响应
+func (sp *SiliconProxy) CreateChatCompletionStream(ctx context.Context, req *ChatCompletionRequest) (*ChatCompletionStream, error) {
+	url := sp.endpoint(ChatCompletionsPath)
+
+	// 确保流式标志设置为true
+	req.Stream = true
+
+	// 将请求转换为JSON
+	reqBody, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("序列化请求失败: %w", err)
+	}
+
+	// 发送POST请求
+	resp, err := sp.client.PostStreamWithAuth(ctx, url, "application/json", string(reqBody), sp.authToken())
+	if err != nil {
+		return nil, err
+	}
+
+	// 检查状态码
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return nil, apiError(resp.StatusCode, string(body))
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	return &ChatCompletionStream{body: resp.Body, scanner: scanner}, nil
+}
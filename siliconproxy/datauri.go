@@ -0,0 +1,43 @@
+package siliconproxy
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// DataURIFromBytes 将二进制数据编码为base64数据URI，内容类型根据数据自动检测
+func DataURIFromBytes(data []byte) string {
+	contentType := http.DetectContentType(data)
+	if i := strings.IndexByte(contentType, ';'); i >= 0 {
+		contentType = contentType[:i]
+	}
+	return "data:" + contentType + ";base64," + base64.StdEncoding.EncodeToString(data)
+}
+
+// DataURIFromFile 读取本地文件并编码为base64数据URI
+func DataURIFromFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("读取文件失败: %w", err)
+	}
+	return DataURIFromBytes(data), nil
+}
+
+// 解析base64数据URI，也接受不带前缀的纯base64字符串
+func decodeDataURI(s string) ([]byte, error) {
+	if strings.HasPrefix(s, "data:") {
+		i := strings.Index(s, ";base64,")
+		if i < 0 {
+			return nil, fmt.Errorf("不支持的数据URI格式")
+		}
+		s = s[i+len(";base64,"):]
+	}
+	data, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("解码base64数据失败: %w", err)
+	}
+	return data, nil
+}
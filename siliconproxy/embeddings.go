@@ -1,8 +1,18 @@
 package siliconproxy
 
 import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
+	"math"
+)
+
+// 嵌入编码格式
+const (
+	EncodingFormatFloat  = "float"
+	EncodingFormatBase64 = "base64"
 )
 
 // EmbeddingRequest 表示嵌入请求
@@ -10,6 +20,11 @@ type EmbeddingRequest struct {
 	Model string   `json:"model"`
 	Input interface{} `json:"input"`
 	User  string   `json:"user,omitempty"`
+
+	// 输出向量维度，仅部分模型支持
+	Dimensions int `json:"dimensions,omitempty"`
+	// 编码格式，float或base64
+	EncodingFormat string `json:"encoding_format,omitempty"`
 }
 
 // EmbeddingResponse 表示嵌入响应
@@ -18,6 +33,9 @@ type EmbeddingResponse struct {
 	Data   []EmbeddingData    `json:"data"`
 	Model  string             `json:"model"`
 	Usage  EmbeddingUsage     `json:"usage"`
+
+	// 缓存状态（hit/miss），未启用缓存时为空；gRPC服务通过x-cache响应头返回
+	CacheStatus string `json:"-"`
 }
 
 // EmbeddingData 表示嵌入数据
@@ -27,6 +45,43 @@ type EmbeddingData struct {
 	Index     int       `json:"index"`
 }
 
+// UnmarshalJSON 解析嵌入数据，base64编码的向量会被解码为浮点数组
+func (d *EmbeddingData) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Object    string          `json:"object"`
+		Embedding json.RawMessage `json:"embedding"`
+		Index     int             `json:"index"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	d.Object = raw.Object
+	d.Index = raw.Index
+	d.Embedding = nil
+
+	if len(raw.Embedding) == 0 || raw.Embedding[0] != '"' {
+		return json.Unmarshal(raw.Embedding, &d.Embedding)
+	}
+
+	// base64编码为小端序float32数组
+	var encoded string
+	if err := json.Unmarshal(raw.Embedding, &encoded); err != nil {
+		return err
+	}
+	buf, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("解码base64向量失败: %w", err)
+	}
+	if len(buf)%4 != 0 {
+		return fmt.Errorf("解码base64向量失败: 长度%d不是4的倍数", len(buf))
+	}
+	d.Embedding = make([]float64, len(buf)/4)
+	for i := range d.Embedding {
+		d.Embedding[i] = float64(math.Float32frombits(binary.LittleEndian.Uint32(buf[i*4:])))
+	}
+	return nil
+}
+
 // EmbeddingUsage 表示嵌入使用情况
 type EmbeddingUsage struct {
 	PromptTokens int `json:"prompt_tokens"`
@@ -34,9 +89,7 @@ type EmbeddingUsage struct {
 }
 
 // CreateEmbedding 创建嵌入请求
-func (sp *SiliconProxy) CreateEmbedding(req *EmbeddingRequest) (*EmbeddingResponse, error) {
-	url := BaseURL + EmbeddingsPath
-	
+func (sp *SiliconProxy) CreateEmbedding(ctx context.Context, req *EmbeddingRequest) (*EmbeddingResponse, error) {
 	// 将请求转换为JSON
 	reqBody, err := json.Marshal(req)
 	if err != nil {
@@ -44,17 +97,17 @@ func (sp *SiliconProxy) CreateEmbedding(req *EmbeddingRequest) (*EmbeddingRespon
 	}
 	
 	// 发送POST请求
-	resp, err := sp.client.PostWithAuth(url, "application/json", string(reqBody), sp.token)
-	resp, err = sp.handleAPIResponse(resp, err)
+	body, cacheStatus, err := sp.postJSON(ctx, EmbeddingsPath, req.Model, reqBody, true)
 	if err != nil {
 		return nil, err
 	}
 	
 	// 解析响应
 	var result EmbeddingResponse
-	if err := json.Unmarshal([]byte(resp.Body), &result); err != nil {
+	if err := json.Unmarshal([]byte(body), &result); err != nil {
 		return nil, fmt.Errorf("解析响应失败: %w", err)
 	}
+	result.CacheStatus = cacheStatus
 	
 	return &result, nil
 }
\ No newline at end of file
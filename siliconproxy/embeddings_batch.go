@@ -0,0 +1,183 @@
+package siliconproxy
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// 批量嵌入的默认参数
+const (
+	DefaultEmbeddingBatchSize   = 32
+	DefaultEmbeddingBatchTokens = 8192
+	DefaultEmbeddingConcurrency = 4
+	DefaultEmbeddingMaxRetries  = 2
+)
+
+// BatchEmbeddingRequest 表示批量嵌入请求
+type BatchEmbeddingRequest struct {
+	Model          string
+	Texts          []string
+	User           string
+	Dimensions     int    // 输出向量维度，为0时使用模型默认值
+	EncodingFormat string // float或base64，结果均解码为浮点数组
+
+	MaxBatchSize   int // 每批最多文本数，默认32
+	MaxBatchTokens int // 每批最多估算token数，默认8192
+	Concurrency    int // 最大并发请求数，默认4
+	MaxRetries     int // 每批失败后的最大重试次数，默认2，小于0表示不重试
+}
+
+// BatchEmbeddingResponse 表示批量嵌入响应
+type BatchEmbeddingResponse struct {
+	Model      string
+	Embeddings [][]float64 // 与输入文本顺序一致
+	Usage      EmbeddingUsage
+	Batches    int // 实际发送的批次数
+}
+
+// 单个批次，start为批次首个文本在输入中的下标
+type embeddingBatch struct {
+	start int
+	texts []string
+}
+
+// CreateEmbeddingsBatch 批量创建嵌入
+// 按文本数和估算token数切分批次，并发发送并在失败时重试，结果按输入顺序重新组装
+func (sp *SiliconProxy) CreateEmbeddingsBatch(ctx context.Context, req *BatchEmbeddingRequest) (*BatchEmbeddingResponse, error) {
+	maxSize := req.MaxBatchSize
+	if maxSize <= 0 {
+		maxSize = DefaultEmbeddingBatchSize
+	}
+	maxTokens := req.MaxBatchTokens
+	if maxTokens <= 0 {
+		maxTokens = DefaultEmbeddingBatchTokens
+	}
+	concurrency := req.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultEmbeddingConcurrency
+	}
+	retries := req.MaxRetries
+	if retries < 0 {
+		retries = 0
+	} else if retries == 0 {
+		retries = DefaultEmbeddingMaxRetries
+	}
+
+	batches := splitEmbeddingBatches(req.Texts, maxSize, maxTokens)
+	result := &BatchEmbeddingResponse{
+		Model:      req.Model,
+		Embeddings: make([][]float64, len(req.Texts)),
+		Batches:    len(batches),
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+		sem      = make(chan struct{}, concurrency)
+	)
+	for _, batch := range batches {
+		mu.Lock()
+		failed := firstErr != nil
+		mu.Unlock()
+		if failed {
+			break
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(batch embeddingBatch) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			resp, err := sp.createEmbeddingWithRetry(ctx, &EmbeddingRequest{
+				Model:          req.Model,
+				Input:          batch.texts,
+				User:           req.User,
+				Dimensions:     req.Dimensions,
+				EncodingFormat: req.EncodingFormat,
+			}, retries)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("第%d条起的批次失败: %w", batch.start, err)
+				}
+				return
+			}
+			for _, data := range resp.Data {
+				if data.Index < 0 || data.Index >= len(batch.texts) {
+					continue
+				}
+				result.Embeddings[batch.start+data.Index] = data.Embedding
+			}
+			result.Usage.PromptTokens += resp.Usage.PromptTokens
+			result.Usage.TotalTokens += resp.Usage.TotalTokens
+			if resp.Model != "" {
+				result.Model = resp.Model
+			}
+		}(batch)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	for i, emb := range result.Embeddings {
+		if emb == nil {
+			return nil, fmt.Errorf("第%d条文本缺少嵌入结果", i)
+		}
+	}
+
+	return result, nil
+}
+
+// 发送嵌入请求，失败时按指数退避重试
+func (sp *SiliconProxy) createEmbeddingWithRetry(ctx context.Context, req *EmbeddingRequest, retries int) (*EmbeddingResponse, error) {
+	backoff := 500 * time.Millisecond
+	var lastErr error
+	for attempt := 0; attempt <= retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+			backoff *= 2
+		}
+		resp, err := sp.CreateEmbedding(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// 按文本数和估算token数切分批次，单条超限的文本独占一个批次
+func splitEmbeddingBatches(texts []string, maxSize, maxTokens int) []embeddingBatch {
+	var batches []embeddingBatch
+	current := embeddingBatch{}
+	tokens := 0
+
+	for i, text := range texts {
+		n := EstimateTokens(text)
+		if len(current.texts) > 0 && (len(current.texts) >= maxSize || tokens+n > maxTokens) {
+			batches = append(batches, current)
+			current = embeddingBatch{}
+			tokens = 0
+		}
+		if len(current.texts) == 0 {
+			current.start = i
+		}
+		current.texts = append(current.texts, text)
+		tokens += n
+	}
+	if len(current.texts) > 0 {
+		batches = append(batches, current)
+	}
+	return batches
+}
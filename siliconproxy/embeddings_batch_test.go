@@ -0,0 +1,93 @@
+package siliconproxy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSplitEmbeddingBatches(t *testing.T) {
+	long := strings.Repeat("word ", 100)
+	tests := []struct {
+		name      string
+		texts     []string
+		maxSize   int
+		maxTokens int
+		want      []int // 各批次的起始下标
+	}{
+		{name: "空输入", maxSize: 2, maxTokens: 100},
+		{name: "按文本数切分", texts: []string{"a", "b", "c", "d", "e"}, maxSize: 2, maxTokens: 100, want: []int{0, 2, 4}},
+		{name: "按token数切分", texts: []string{"a", long, "b"}, maxSize: 10, maxTokens: EstimateTokens(long), want: []int{0, 1, 2}},
+		{name: "单条超限独占批次", texts: []string{long, long}, maxSize: 10, maxTokens: 1, want: []int{0, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			batches := splitEmbeddingBatches(tt.texts, tt.maxSize, tt.maxTokens)
+			var starts []int
+			total := 0
+			for _, b := range batches {
+				starts = append(starts, b.start)
+				if b.start != total {
+					t.Fatalf("批次起始下标%d，期望%d", b.start, total)
+				}
+				total += len(b.texts)
+			}
+			if fmt.Sprint(starts) != fmt.Sprint(tt.want) || total != len(tt.texts) {
+				t.Fatalf("批次起始下标为%v，期望%v", starts, tt.want)
+			}
+		})
+	}
+}
+
+// 模拟上游：文本"tN"的向量为[N]，批次越靠前响应越慢，且结果倒序返回
+func TestCreateEmbeddingsBatchOrder(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Input []string `json:"input"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		first, _ := strconv.Atoi(strings.TrimPrefix(req.Input[0], "t"))
+		time.Sleep(time.Duration(20-first) * time.Millisecond)
+
+		var data []map[string]any
+		for i := len(req.Input) - 1; i >= 0; i-- {
+			n, _ := strconv.Atoi(strings.TrimPrefix(req.Input[i], "t"))
+			data = append(data, map[string]any{"index": i, "embedding": []float64{float64(n)}})
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"data":  data,
+			"usage": map[string]int{"prompt_tokens": len(req.Input), "total_tokens": len(req.Input)},
+		})
+	}))
+	defer srv.Close()
+	sp := NewSiliconProxy("token")
+	sp.SetBaseURL(srv.URL)
+
+	texts := make([]string, 10)
+	for i := range texts {
+		texts[i] = "t" + strconv.Itoa(i)
+	}
+	resp, err := sp.CreateEmbeddingsBatch(context.Background(), &BatchEmbeddingRequest{
+		Model:        "m",
+		Texts:        texts,
+		MaxBatchSize: 3,
+		Concurrency:  4,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Batches != 4 || resp.Usage.TotalTokens != len(texts) {
+		t.Fatalf("批次数%d、token数%d，期望4、%d", resp.Batches, resp.Usage.TotalTokens, len(texts))
+	}
+	for i, emb := range resp.Embeddings {
+		if len(emb) != 1 || emb[0] != float64(i) {
+			t.Fatalf("第%d条的向量为%v，期望[%d]", i, emb, i)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package siliconproxy
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	// 注册常见图像格式的解码器
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+// 默认的图像大小上限
+const DefaultMaxImageBytes = 20 << 20
+
+// GeneratedImage 表示下载或解码后的生成图像
+type GeneratedImage struct {
+	Data        []byte
+	ContentType string // 根据数据内容检测的类型，如image/png
+}
+
+// FetchImage 获取生成的图像，URL会被下载，b64_json会被解码
+// maxBytes为0时使用DefaultMaxImageBytes，超出上限或内容不是图像时返回错误
+func (sp *SiliconProxy) FetchImage(ctx context.Context, data ImageGenerationData, maxBytes int64) (*GeneratedImage, error) {
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxImageBytes
+	}
+
+	var raw []byte
+	switch {
+	case data.B64JSON != "":
+		decoded, err := decodeDataURI(data.B64JSON)
+		if err != nil {
+			return nil, err
+		}
+		if int64(len(decoded)) > maxBytes {
+			return nil, fmt.Errorf("图像大小超过上限%d字节", maxBytes)
+		}
+		raw = decoded
+	case data.URL != "":
+		downloaded, err := sp.download(ctx, data.URL, maxBytes)
+		if err != nil {
+			return nil, err
+		}
+		raw = downloaded
+	default:
+		return nil, fmt.Errorf("图像数据为空")
+	}
+
+	contentType := http.DetectContentType(raw)
+	if !strings.HasPrefix(contentType, "image/") {
+		return nil, fmt.Errorf("内容不是图像: %s", contentType)
+	}
+
+	return &GeneratedImage{Data: raw, ContentType: contentType}, nil
+}
+
+// FetchImages 获取响应中的全部图像
+func (sp *SiliconProxy) FetchImages(ctx context.Context, resp *ImageGenerationResponse, maxBytes int64) ([]*GeneratedImage, error) {
+	all := resp.AllImages()
+	images := make([]*GeneratedImage, 0, len(all))
+	for i, data := range all {
+		img, err := sp.FetchImage(ctx, data, maxBytes)
+		if err != nil {
+			return nil, fmt.Errorf("获取第%d张图像失败: %w", i, err)
+		}
+		images = append(images, img)
+	}
+	return images, nil
+}
+
+// Decode 将图像解码为image.Image，支持png、jpeg和gif
+func (img *GeneratedImage) Decode() (image.Image, error) {
+	decoded, _, err := image.Decode(bytes.NewReader(img.Data))
+	if err != nil {
+		return nil, fmt.Errorf("解码图像失败: %w", err)
+	}
+	return decoded, nil
+}
+
+// Extension 返回与内容类型对应的文件扩展名
+func (img *GeneratedImage) Extension() string {
+	switch img.ContentType {
+	case "image/png":
+		return ".png"
+	case "image/jpeg":
+		return ".jpg"
+	case "image/gif":
+		return ".gif"
+	case "image/webp":
+		return ".webp"
+	default:
+		return ".bin"
+	}
+}
+
+// Save 将图像写入文件
+func (img *GeneratedImage) Save(path string) error {
+	if err := os.WriteFile(path, img.Data, 0o644); err != nil {
+		return fmt.Errorf("写入图像文件失败: %w", err)
+	}
+	return nil
+}
+
+// DownloadTo 将URL内容写入w，超出maxBytes时返回错误，返回写入的字节数
+// maxBytes为0表示不限制大小
+func (sp *SiliconProxy) DownloadTo(ctx context.Context, url string, w io.Writer, maxBytes int64) (int64, error) {
+	resp, err := sp.client.GetStream(ctx, url)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return 0, fmt.Errorf("下载失败，状态码: %d", resp.StatusCode)
+	}
+	if maxBytes <= 0 {
+		n, err := io.Copy(w, resp.Body)
+		if err != nil {
+			return n, fmt.Errorf("读取下载内容失败: %w", err)
+		}
+		return n, nil
+	}
+
+	if n, err := strconv.ParseInt(resp.Headers.Get("Content-Length"), 10, 64); err == nil && n > maxBytes {
+		return 0, fmt.Errorf("文件大小%d超过上限%d字节", n, maxBytes)
+	}
+	n, err := io.Copy(w, io.LimitReader(resp.Body, maxBytes+1))
+	if err != nil {
+		return n, fmt.Errorf("读取下载内容失败: %w", err)
+	}
+	if n > maxBytes {
+		return n, fmt.Errorf("文件大小超过上限%d字节", maxBytes)
+	}
+	return n, nil
+}
+
+// 下载URL内容到内存
+func (sp *SiliconProxy) download(ctx context.Context, url string, maxBytes int64) ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := sp.DownloadTo(ctx, url, &buf, maxBytes); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -1,24 +1,41 @@
 package siliconproxy
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 )
 
 // ImageGenerationRequest 表示图像生成请求
+// 数值字段为0时使用模型默认值
 type ImageGenerationRequest struct {
-	Model          string  `json:"model"`
-	Prompt         string  `json:"prompt"`
-	N              int     `json:"n,omitempty"`
-	Size           string  `json:"size,omitempty"`
-	ResponseFormat string  `json:"response_format,omitempty"`
-	User           string  `json:"user,omitempty"`
+	Model          string `json:"model"`
+	Prompt         string `json:"prompt"`
+	N              int    `json:"n,omitempty"`
+	Size           string `json:"size,omitempty"`
+	ResponseFormat string `json:"response_format,omitempty"`
+	User           string `json:"user,omitempty"`
+
+	NegativePrompt    string  `json:"negative_prompt,omitempty"`
+	ImageSize         string  `json:"image_size,omitempty"` // 形如"1024x1024"
+	BatchSize         int     `json:"batch_size,omitempty"`
+	Seed              int64   `json:"seed,omitempty"`
+	NumInferenceSteps int     `json:"num_inference_steps,omitempty"`
+	GuidanceScale     float64 `json:"guidance_scale,omitempty"`
+	PromptEnhancement bool    `json:"prompt_enhancement,omitempty"`
+	// 图生图的输入图像，可以是URL或base64数据URI，见DataURIFromFile
+	Image string `json:"image,omitempty"`
 }
 
 // ImageGenerationResponse 表示图像生成响应
 type ImageGenerationResponse struct {
-	Created int64                `json:"created"`
-	Data    []ImageGenerationData `json:"data"`
+	Created int64                 `json:"created,omitempty"`
+	Data    []ImageGenerationData `json:"data,omitempty"`
+	Images  []ImageGenerationData `json:"images,omitempty"`
+	Timings *ImageTimings         `json:"timings,omitempty"`
+	Seed    int64                 `json:"seed,omitempty"`
 }
 
 // ImageGenerationData 表示图像生成数据
@@ -27,28 +44,153 @@ type ImageGenerationData struct {
 	B64JSON string `json:"b64_json,omitempty"`
 }
 
+// ImageTimings 表示图像生成耗时
+type ImageTimings struct {
+	Inference float64 `json:"inference"`
+}
+
+// AllImages 返回响应中的全部图像，兼容images和data两种字段
+func (r *ImageGenerationResponse) AllImages() []ImageGenerationData {
+	all := make([]ImageGenerationData, 0, len(r.Images)+len(r.Data))
+	all = append(all, r.Images...)
+	all = append(all, r.Data...)
+	return all
+}
+
+// ImageModelSpec 表示图像模型支持的参数范围
+type ImageModelSpec struct {
+	NegativePrompt bool     // 是否支持negative_prompt
+	ImageToImage   bool     // 是否支持image输入
+	MaxBatchSize   int      // batch_size上限，0表示不支持
+	MaxSteps       int      // num_inference_steps上限，0表示不支持
+	MaxGuidance    float64  // guidance_scale上限，0表示不支持
+	ImageSizes     []string // 支持的image_size，为空表示不限制
+}
+
+// ImageModelSpecs 是已知图像模型的参数规则，未列出的模型只做通用范围检查
+var ImageModelSpecs = map[string]ImageModelSpec{
+	"Kwai-Kolors/Kolors": {
+		NegativePrompt: true,
+		ImageToImage:   true,
+		MaxBatchSize:   4,
+		MaxSteps:       49,
+		MaxGuidance:    20,
+		ImageSizes:     []string{"1024x1024", "960x1280", "768x1024", "720x1440", "720x1280"},
+	},
+	"stabilityai/stable-diffusion-3-5-large": {
+		NegativePrompt: true,
+		ImageToImage:   true,
+		MaxBatchSize:   4,
+		MaxSteps:       50,
+		MaxGuidance:    20,
+	},
+	"black-forest-labs/FLUX.1-dev": {
+		ImageToImage: true,
+		MaxBatchSize: 1,
+		MaxSteps:     50,
+	},
+	"black-forest-labs/FLUX.1-schnell": {
+		MaxBatchSize: 1,
+	},
+}
+
+// Validate 检查请求参数，已知模型按ImageModelSpecs检查
+func (req *ImageGenerationRequest) Validate() error {
+	if req.Model == "" {
+		return fmt.Errorf("模型不能为空")
+	}
+	if req.Prompt == "" {
+		return fmt.Errorf("提示词不能为空")
+	}
+	if req.ImageSize != "" {
+		if err := validateImageSize(req.ImageSize); err != nil {
+			return err
+		}
+	}
+	if req.BatchSize < 0 || req.NumInferenceSteps < 0 || req.GuidanceScale < 0 || req.Seed < 0 {
+		return fmt.Errorf("batch_size、num_inference_steps、guidance_scale和seed不能为负数")
+	}
+
+	spec, ok := ImageModelSpecs[req.Model]
+	if !ok {
+		return nil
+	}
+	if req.NegativePrompt != "" && !spec.NegativePrompt {
+		return fmt.Errorf("模型%s不支持negative_prompt", req.Model)
+	}
+	if req.Image != "" && !spec.ImageToImage {
+		return fmt.Errorf("模型%s不支持图生图", req.Model)
+	}
+	if req.BatchSize > 0 && req.BatchSize > spec.MaxBatchSize {
+		return fmt.Errorf("模型%s的batch_size不能超过%d", req.Model, spec.MaxBatchSize)
+	}
+	if req.NumInferenceSteps > 0 {
+		if spec.MaxSteps == 0 {
+			return fmt.Errorf("模型%s不支持num_inference_steps", req.Model)
+		}
+		if req.NumInferenceSteps > spec.MaxSteps {
+			return fmt.Errorf("模型%s的num_inference_steps不能超过%d", req.Model, spec.MaxSteps)
+		}
+	}
+	if req.GuidanceScale > 0 {
+		if spec.MaxGuidance == 0 {
+			return fmt.Errorf("模型%s不支持guidance_scale", req.Model)
+		}
+		if req.GuidanceScale > spec.MaxGuidance {
+			return fmt.Errorf("模型%s的guidance_scale不能超过%g", req.Model, spec.MaxGuidance)
+		}
+	}
+	if req.ImageSize != "" && len(spec.ImageSizes) > 0 {
+		for _, size := range spec.ImageSizes {
+			if size == req.ImageSize {
+				return nil
+			}
+		}
+		return fmt.Errorf("模型%s不支持image_size %s，可选值: %s", req.Model, req.ImageSize, strings.Join(spec.ImageSizes, ", "))
+	}
+	return nil
+}
+
+// 检查图像尺寸格式，形如"1024x1024"
+func validateImageSize(size string) error {
+	w, h, ok := strings.Cut(size, "x")
+	if !ok {
+		return fmt.Errorf("image_size格式错误: %s", size)
+	}
+	for _, v := range []string{w, h} {
+		if n, err := strconv.Atoi(v); err != nil || n <= 0 {
+			return fmt.Errorf("image_size格式错误: %s", size)
+		}
+	}
+	return nil
+}
+
 // CreateImageGeneration 创建图像生成请求
-func (sp *SiliconProxy) CreateImageGeneration(req *ImageGenerationRequest) (*ImageGenerationResponse, error) {
-	url := BaseURL + ImagesGenerationPath
-	
+func (sp *SiliconProxy) CreateImageGeneration(ctx context.Context, req *ImageGenerationRequest) (*ImageGenerationResponse, error) {
+	url := sp.endpoint(ImagesGenerationPath)
+
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
 	// 将请求转换为JSON
 	reqBody, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("序列化请求失败: %w", err)
 	}
-	
+
 	// 发送POST请求
-	resp, err := sp.client.PostWithAuth(url, "application/json", string(reqBody), sp.token)
+	resp, err := sp.client.PostWithAuth(ctx, url, "application/json", string(reqBody), sp.authToken())
 	resp, err = sp.handleAPIResponse(resp, err)
 	if err != nil {
 		return nil, err
 	}
-	
+
 	// 解析响应
 	var result ImageGenerationResponse
 	if err := json.Unmarshal([]byte(resp.Body), &result); err != nil {
 		return nil, fmt.Errorf("解析响应失败: %w", err)
 	}
-	
+
 	return &result, nil
-}
\ No newline at end of file
+}
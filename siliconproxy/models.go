@@ -1,8 +1,10 @@
 package siliconproxy
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 )
 
 // ModelListResponse 表示模型列表响应
@@ -19,22 +21,43 @@ type ModelData struct {
 	OwnedBy string `json:"owned_by"`
 }
 
+// ModelListOptions 表示模型列表的过滤条件，空字段表示不过滤
+type ModelListOptions struct {
+	Type    string // text、image、audio或video
+	SubType string // 如chat、embedding、reranker、text-to-image等
+}
+
 // GetModelList 获取模型列表
-func (sp *SiliconProxy) GetModelList() (*ModelListResponse, error) {
-	url := BaseURL + ModelsPath
-	
+func (sp *SiliconProxy) GetModelList(ctx context.Context) (*ModelListResponse, error) {
+	return sp.GetModelListWithOptions(ctx, ModelListOptions{})
+}
+
+// GetModelListWithOptions 按类型过滤获取模型列表
+func (sp *SiliconProxy) GetModelListWithOptions(ctx context.Context, opts ModelListOptions) (*ModelListResponse, error) {
+	query := url.Values{}
+	if opts.Type != "" {
+		query.Set("type", opts.Type)
+	}
+	if opts.SubType != "" {
+		query.Set("sub_type", opts.SubType)
+	}
+	reqURL := sp.endpoint(ModelsPath)
+	if len(query) > 0 {
+		reqURL += "?" + query.Encode()
+	}
+
 	// 发送GET请求
-	resp, err := sp.client.GetWithAuth(url, sp.token)
+	resp, err := sp.client.GetWithAuth(ctx, reqURL, sp.authToken())
 	resp, err = sp.handleAPIResponse(resp, err)
 	if err != nil {
 		return nil, err
 	}
-	
+
 	// 解析响应
 	var result ModelListResponse
 	if err := json.Unmarshal([]byte(resp.Body), &result); err != nil {
 		return nil, fmt.Errorf("解析响应失败: %w", err)
 	}
-	
+
 	return &result, nil
-}
\ No newline at end of file
+}
@@ -1,32 +1,72 @@
 package siliconproxy
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 )
 
 // RerankRequest 表示重排序请求
 type RerankRequest struct {
-	Model    string   `json:"model"`
-	Query    string   `json:"query"`
+	Model     string   `json:"model"`
+	Query     string   `json:"query"`
 	Documents []string `json:"documents"`
-	TopN     int      `json:"top_n,omitempty"`
-	User     string   `json:"user,omitempty"`
+	TopN      int      `json:"top_n,omitempty"`
+	User      string   `json:"user,omitempty"`
+
+	// 是否在结果中返回文档内容
+	ReturnDocuments bool `json:"return_documents,omitempty"`
+	// 长文档切分后每个文档最多保留的块数，仅部分模型支持
+	MaxChunksPerDoc int `json:"max_chunks_per_doc,omitempty"`
+	// 长文档切分时相邻块重叠的token数，仅部分模型支持
+	OverlapTokens int `json:"overlap_tokens,omitempty"`
 }
 
 // RerankResponse 表示重排序响应
 type RerankResponse struct {
-	Object string        `json:"object"`
-	Model  string        `json:"model"`
+	ID      string         `json:"id"`
+	Object  string         `json:"object,omitempty"`
+	Model   string         `json:"model,omitempty"`
 	Results []RerankResult `json:"results"`
-	Usage  RerankUsage     `json:"usage"`
+	Tokens  RerankTokens   `json:"tokens"`
+	Usage   RerankUsage    `json:"usage"`
+
+	// 缓存状态（hit/miss），未启用缓存时为空；gRPC服务通过x-cache响应头返回
+	CacheStatus string `json:"-"`
 }
 
 // RerankResult 表示重排序结果
 type RerankResult struct {
-	Index       int     `json:"index"`
-	Document    string  `json:"document"`
-	RelevanceScore float64 `json:"relevance_score"`
+	Index          int             `json:"index"`
+	Document       *RerankDocument `json:"document,omitempty"`
+	RelevanceScore float64         `json:"relevance_score"`
+}
+
+// RerankDocument 表示重排序结果中返回的文档，仅在ReturnDocuments为true时存在
+type RerankDocument struct {
+	Text string `json:"text"`
+}
+
+// UnmarshalJSON 兼容对象和字符串两种文档格式
+func (d *RerankDocument) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &d.Text)
+	}
+	var obj struct {
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	d.Text = obj.Text
+	return nil
+}
+
+// RerankTokens 表示重排序的token计费信息
+type RerankTokens struct {
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
 }
 
 // RerankUsage 表示重排序使用情况
@@ -35,28 +75,64 @@ type RerankUsage struct {
 	TotalTokens  int `json:"total_tokens"`
 }
 
+// RankedItem 表示重排序后的调用方文档
+type RankedItem[T any] struct {
+	Item           T
+	Index          int // 在输入中的下标
+	RelevanceScore float64
+}
+
+// RerankItems 对任意类型的文档重排序，text用于取出参与排序的文本
+// 结果按相关性分数降序排列，req中的Documents和Query以外的字段会原样透传
+func RerankItems[T any](ctx context.Context, sp *SiliconProxy, req RerankRequest, items []T, text func(T) string) ([]RankedItem[T], error) {
+	req.Documents = make([]string, len(items))
+	for i, item := range items {
+		req.Documents[i] = text(item)
+	}
+
+	resp, err := sp.CreateRerank(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	ranked := make([]RankedItem[T], 0, len(resp.Results))
+	for _, r := range resp.Results {
+		if r.Index < 0 || r.Index >= len(items) {
+			return nil, fmt.Errorf("重排序结果下标越界: %d", r.Index)
+		}
+		ranked = append(ranked, RankedItem[T]{
+			Item:           items[r.Index],
+			Index:          r.Index,
+			RelevanceScore: r.RelevanceScore,
+		})
+	}
+	sort.SliceStable(ranked, func(a, b int) bool {
+		return ranked[a].RelevanceScore > ranked[b].RelevanceScore
+	})
+
+	return ranked, nil
+}
+
 // CreateRerank 创建重排序请求
-func (sp *SiliconProxy) CreateRerank(req *RerankRequest) (*RerankResponse, error) {
-	url := BaseURL + RerankPath
-	
+func (sp *SiliconProxy) CreateRerank(ctx context.Context, req *RerankRequest) (*RerankResponse, error) {
 	// 将请求转换为JSON
 	reqBody, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("序列化请求失败: %w", err)
 	}
-	
+
 	// 发送POST请求
-	resp, err := sp.client.PostWithAuth(url, "application/json", string(reqBody), sp.token)
-	resp, err = sp.handleAPIResponse(resp, err)
+	body, cacheStatus, err := sp.postJSON(ctx, RerankPath, req.Model, reqBody, true)
 	if err != nil {
 		return nil, err
 	}
-	
+
 	// 解析响应
 	var result RerankResponse
-	if err := json.Unmarshal([]byte(resp.Body), &result); err != nil {
+	if err := json.Unmarshal([]byte(body), &result); err != nil {
 		return nil, fmt.Errorf("解析响应失败: %w", err)
 	}
-	
+	result.CacheStatus = cacheStatus
+
 	return &result, nil
-}
\ No newline at end of file
+}
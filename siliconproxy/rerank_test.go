@@ -0,0 +1,121 @@
+package siliconproxy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRerankDocumentUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    string
+		wantErr bool
+	}{
+		{name: "对象格式", data: `{"text":"hello"}`, want: "hello"},
+		{name: "字符串格式", data: `"hello"`, want: "hello"},
+		{name: "转义字符", data: `"a\"b\n"`, want: "a\"b\n"},
+		{name: "空对象", data: `{}`, want: ""},
+		{name: "不支持的类型", data: `42`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d RerankDocument
+			err := json.Unmarshal([]byte(tt.data), &d)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("错误为%v，期望出错: %v", err, tt.wantErr)
+			}
+			if d.Text != tt.want {
+				t.Fatalf("文本为%q，期望%q", d.Text, tt.want)
+			}
+		})
+	}
+
+	// 在响应中作为可选字段
+	var result RerankResult
+	if err := json.Unmarshal([]byte(`{"index":1,"relevance_score":0.5}`), &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Document != nil {
+		t.Fatalf("缺少document时应为nil，实际为%+v", result.Document)
+	}
+}
+
+type rerankDoc struct {
+	ID   string
+	Body string
+}
+
+func TestRerankItems(t *testing.T) {
+	tests := []struct {
+		name    string
+		results string // 上游返回的results
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "按分数降序",
+			results: `[{"index":0,"relevance_score":0.1},{"index":2,"relevance_score":0.9},{"index":1,"relevance_score":0.5}]`,
+			want:    []string{"c", "b", "a"},
+		},
+		{
+			name:    "分数相同时保持上游顺序",
+			results: `[{"index":1,"relevance_score":0.5},{"index":0,"relevance_score":0.5}]`,
+			want:    []string{"b", "a"},
+		},
+		{
+			name:    "下标越界",
+			results: `[{"index":3,"relevance_score":0.5}]`,
+			wantErr: true,
+		},
+	}
+	items := []rerankDoc{{ID: "a", Body: "first"}, {ID: "b", Body: "second"}, {ID: "c", Body: "third"}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotDocs []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req RerankRequest
+				json.NewDecoder(r.Body).Decode(&req)
+				gotDocs = req.Documents
+				w.Write([]byte(`{"id":"r","results":` + tt.results + `}`))
+			}))
+			defer srv.Close()
+			sp := NewSiliconProxy("token")
+			sp.SetBaseURL(srv.URL)
+
+			ranked, err := RerankItems(context.Background(), sp, RerankRequest{Model: "m", Query: "q"}, items,
+				func(d rerankDoc) string { return d.Body })
+			if len(gotDocs) != len(items) || gotDocs[0] != "first" || gotDocs[2] != "third" {
+				t.Fatalf("发送的文档为%v", gotDocs)
+			}
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("期望返回错误")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var ids []string
+			for _, r := range ranked {
+				if items[r.Index].ID != r.Item.ID {
+					t.Fatalf("下标%d与条目%s不对应", r.Index, r.Item.ID)
+				}
+				ids = append(ids, r.Item.ID)
+			}
+			if len(ids) != len(tt.want) {
+				t.Fatalf("结果为%v，期望%v", ids, tt.want)
+			}
+			for i := range ids {
+				if ids[i] != tt.want[i] {
+					t.Fatalf("结果为%v，期望%v", ids, tt.want)
+				}
+			}
+		})
+	}
+}
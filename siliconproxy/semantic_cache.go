@@ -0,0 +1,377 @@
+package siliconproxy
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/kriswu/go_deepseek/vectorindex"
+)
+
+// CacheSemanticHit 表示响应来自语义缓存
+const CacheSemanticHit = "semantic_hit"
+
+// 计算消息向量失败而跳过语义缓存的次数
+var semanticEmbeddingErrorsMetric = expvar.NewInt("silicon_semantic_cache_embedding_errors")
+
+// SemanticCacheConfig 表示语义缓存配置
+type SemanticCacheConfig struct {
+	EmbeddingModel string        // 用于计算用户消息向量的嵌入模型
+	Threshold      float64       // 相似度阈值，不低于该值才视为命中，默认0.95
+	TTL            time.Duration // 条目有效期，为0表示永不过期
+	MaxEntries     int           // 最多保留的条目数，超出时淘汰最早写入的条目，默认DefaultSemanticCacheMaxEntries
+}
+
+// DefaultSemanticCacheMaxEntries 是语义缓存默认的最大条目数
+const DefaultSemanticCacheMaxEntries = 10000
+
+// SemanticScope 表示语义缓存条目的作用域，用于失效操作
+// 空字段匹配任意值
+type SemanticScope struct {
+	Model        string
+	SystemPrompt string
+	Tenant       string
+	// 除最后一条用户消息外的对话上下文和请求参数（采样参数、工具、响应格式等）的摘要
+	Context string
+}
+
+// SemanticCacheStats 表示语义缓存统计信息
+type SemanticCacheStats struct {
+	Lookups         int64   // 查询次数
+	Hits            int64   // 命中次数
+	Misses          int64   // 未命中次数
+	HitRate         float64 // 命中率
+	Entries         int     // 当前条目数
+	Evictions       int64   // 因过期或超出容量而删除的条目数
+	SavedTokens     int64   // 命中节省的聊天token数
+	EmbeddingTokens int64   // 查询消耗的嵌入token数
+	EmbeddingErrors int64   // 计算消息向量失败而跳过缓存的次数
+}
+
+// SemanticCache 是基于向量相似度的聊天缓存
+// 以最后一条用户消息的向量在同一作用域内检索近邻
+// 作用域包含模型、系统提示词、租户，以及之前的对话轮次和全部请求参数，只有这些都相同的请求才可能命中
+type SemanticCache struct {
+	sp    *SiliconProxy
+	state *semanticState
+}
+
+type semanticState struct {
+	conf SemanticCacheConfig
+
+	mu      sync.Mutex
+	scopes  map[string]*semanticScopeIndex
+	entries map[string]*semanticEntry
+	// 按写入顺序排列的条目ID，所有条目的有效期相同，因此也是过期顺序
+	order  *list.List
+	nextID int64
+	stats  SemanticCacheStats
+}
+
+type semanticScopeIndex struct {
+	scope SemanticScope
+	index *vectorindex.Flat
+}
+
+type semanticEntry struct {
+	scopeKey  string
+	elem      *list.Element // 在order中的位置
+	response  ChatCompletionResponse
+	expiresAt time.Time
+}
+
+// NewSemanticCache 创建基于sp的语义缓存
+func NewSemanticCache(sp *SiliconProxy, conf SemanticCacheConfig) *SemanticCache {
+	if conf.Threshold <= 0 {
+		conf.Threshold = 0.95
+	}
+	if conf.MaxEntries <= 0 {
+		conf.MaxEntries = DefaultSemanticCacheMaxEntries
+	}
+	return &SemanticCache{
+		sp: sp,
+		state: &semanticState{
+			conf:    conf,
+			scopes:  make(map[string]*semanticScopeIndex),
+			entries: make(map[string]*semanticEntry),
+			order:   list.New(),
+		},
+	}
+}
+
+// WithProxy 返回使用指定代理的语义缓存副本，租户取自代理，与原缓存共享存储
+func (c *SemanticCache) WithProxy(sp *SiliconProxy) *SemanticCache {
+	return &SemanticCache{sp: sp, state: c.state}
+}
+
+// CreateChatCompletion 先查询语义缓存，未命中时调用上游并写入缓存
+// 流式请求和没有用户消息的请求直接透传；计算消息向量失败时跳过缓存，不影响对话
+func (c *SemanticCache) CreateChatCompletion(ctx context.Context, req *ChatCompletionRequest) (*ChatCompletionResponse, error) {
+	query := lastUserMessage(req.Messages)
+	if req.Stream || query == "" {
+		return c.sp.CreateChatCompletion(ctx, req)
+	}
+
+	scope := SemanticScope{
+		Model:        req.Model,
+		SystemPrompt: systemPrompt(req.Messages),
+		Tenant:       c.sp.tenant,
+		Context:      conversationDigest(req),
+	}
+
+	// 计算用户消息向量
+	embResp, err := c.sp.CreateEmbedding(ctx, &EmbeddingRequest{
+		Model: c.state.conf.EmbeddingModel,
+		Input: query,
+	})
+	if err == nil && len(embResp.Data) == 0 {
+		err = errors.New("响应为空")
+	}
+	if err != nil {
+		// 缓存是可选的，嵌入接口故障不能导致对话失败
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("计算消息向量失败: %w", err)
+		}
+		log.Printf("语义缓存计算消息向量失败，跳过缓存: %v", err)
+		semanticEmbeddingErrorsMetric.Add(1)
+		c.state.embeddingFailed()
+		return c.sp.CreateChatCompletion(ctx, req)
+	}
+	vec := vectorindex.FromFloat64(embResp.Data[0].Embedding)
+
+	if resp, ok := c.state.lookup(scope, vec, int64(embResp.Usage.TotalTokens)); ok {
+		return resp, nil
+	}
+
+	resp, err := c.sp.CreateChatCompletion(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	c.state.store(scope, vec, resp)
+
+	return resp, nil
+}
+
+// Invalidate 删除与scope匹配的全部条目，返回删除数量
+func (c *SemanticCache) Invalidate(scope SemanticScope) int {
+	return c.state.invalidate(scope)
+}
+
+// Stats 返回统计信息
+func (c *SemanticCache) Stats() SemanticCacheStats {
+	c.state.mu.Lock()
+	defer c.state.mu.Unlock()
+
+	stats := c.state.stats
+	stats.Entries = len(c.state.entries)
+	if stats.Lookups > 0 {
+		stats.HitRate = float64(stats.Hits) / float64(stats.Lookups)
+	}
+	return stats
+}
+
+// 记录计算消息向量失败
+func (s *semanticState) embeddingFailed() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stats.EmbeddingErrors++
+}
+
+// 在作用域内检索近邻，命中时返回缓存响应的副本
+func (s *semanticState) lookup(scope SemanticScope, vec []float32, embeddingTokens int64) (*ChatCompletionResponse, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stats.Lookups++
+	s.stats.EmbeddingTokens += embeddingTokens
+
+	if idx, ok := s.scopes[scopeKey(scope)]; ok {
+		// 多取几个候选，跳过已过期的条目
+		results, err := idx.index.Search(vec, 4)
+		if err == nil {
+			for _, r := range results {
+				if r.Score < s.conf.Threshold {
+					break
+				}
+				entry := s.entries[r.ID]
+				if entry == nil {
+					continue
+				}
+				if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+					s.removeLocked(r.ID)
+					s.stats.Evictions++
+					continue
+				}
+				s.stats.Hits++
+				s.stats.SavedTokens += int64(entry.response.Usage.TotalTokens)
+				resp := entry.response
+				resp.CacheStatus = CacheSemanticHit
+				return &resp, true
+			}
+		}
+	}
+
+	s.stats.Misses++
+	return nil, false
+}
+
+// 写入缓存条目
+func (s *semanticState) store(scope SemanticScope, vec []float32, resp *ChatCompletionResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.evictLocked()
+
+	key := scopeKey(scope)
+	idx, ok := s.scopes[key]
+	if !ok {
+		idx = &semanticScopeIndex{scope: scope, index: vectorindex.NewFlat()}
+		s.scopes[key] = idx
+	}
+
+	s.nextID++
+	id := strconv.FormatInt(s.nextID, 10)
+	if err := idx.index.Add(id, vec); err != nil {
+		return
+	}
+
+	entry := &semanticEntry{scopeKey: key, response: *resp}
+	entry.response.CacheStatus = ""
+	if s.conf.TTL > 0 {
+		entry.expiresAt = time.Now().Add(s.conf.TTL)
+	}
+	entry.elem = s.order.PushBack(id)
+	s.entries[id] = entry
+}
+
+// 删除已过期的条目，并在达到容量上限时淘汰最早写入的条目，为新条目腾出位置
+func (s *semanticState) evictLocked() {
+	now := time.Now()
+	for front := s.order.Front(); front != nil; front = s.order.Front() {
+		entry := s.entries[front.Value.(string)]
+		expired := !entry.expiresAt.IsZero() && now.After(entry.expiresAt)
+		if !expired && len(s.entries) < s.conf.MaxEntries {
+			return
+		}
+		s.removeLocked(front.Value.(string))
+		s.stats.Evictions++
+	}
+}
+
+// 删除匹配作用域的条目
+func (s *semanticState) invalidate(scope SemanticScope) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	removed := 0
+	for key, idx := range s.scopes {
+		if !scope.matches(idx.scope) {
+			continue
+		}
+		for id, entry := range s.entries {
+			if entry.scopeKey == key {
+				s.order.Remove(entry.elem)
+				delete(s.entries, id)
+				removed++
+			}
+		}
+		delete(s.scopes, key)
+	}
+	return removed
+}
+
+func (s *semanticState) removeLocked(id string) {
+	entry, ok := s.entries[id]
+	if !ok {
+		return
+	}
+	if idx, ok := s.scopes[entry.scopeKey]; ok {
+		idx.index.Remove(id)
+		if idx.index.Len() == 0 {
+			delete(s.scopes, entry.scopeKey)
+		}
+	}
+	s.order.Remove(entry.elem)
+	delete(s.entries, id)
+}
+
+// 判断作用域是否匹配，空字段匹配任意值
+func (filter SemanticScope) matches(scope SemanticScope) bool {
+	return (filter.Model == "" || filter.Model == scope.Model) &&
+		(filter.SystemPrompt == "" || filter.SystemPrompt == scope.SystemPrompt) &&
+		(filter.Tenant == "" || filter.Tenant == scope.Tenant) &&
+		(filter.Context == "" || filter.Context == scope.Context)
+}
+
+// 计算作用域的唯一键
+func scopeKey(scope SemanticScope) string {
+	h := sha256.New()
+	for _, part := range []string{scope.Tenant, scope.Model, scope.SystemPrompt, scope.Context} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// 计算除最后一条用户消息内容外整个请求的摘要
+// 最后一条用户消息保留占位，系统提示词已单独计入作用域
+func conversationDigest(req *ChatCompletionRequest) string {
+	last := -1
+	for i := len(req.Messages) - 1; i >= 0; i-- {
+		if req.Messages[i].Role == "user" {
+			last = i
+			break
+		}
+	}
+
+	rest := *req
+	rest.Stream = false
+	rest.Messages = make([]ChatCompletionMessage, 0, len(req.Messages))
+	for i, msg := range req.Messages {
+		switch {
+		case msg.Role == "system":
+			continue
+		case i == last:
+			msg.Content = ""
+		}
+		rest.Messages = append(rest.Messages, msg)
+	}
+
+	body, err := json.Marshal(rest)
+	if err != nil {
+		// 无法序列化的参数不会出现在合法请求中，退化为不可命中的唯一值
+		return fmt.Sprintf("unserializable-%p", req)
+	}
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// 获取最后一条用户消息
+func lastUserMessage(messages []ChatCompletionMessage) string {
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role == "user" {
+			return messages[i].Content
+		}
+	}
+	return ""
+}
+
+// 获取系统提示词，多条时拼接
+func systemPrompt(messages []ChatCompletionMessage) string {
+	var prompt string
+	for _, msg := range messages {
+		if msg.Role == "system" {
+			prompt += msg.Content + "\n"
+		}
+	}
+	return prompt
+}
@@ -0,0 +1,202 @@
+package siliconproxy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// 模拟上游：嵌入向量由用户消息的首字母决定，对话回显用户消息
+func newSemanticUpstream(t *testing.T, embeddingsDown *atomic.Bool, chats *atomic.Int64) *SiliconProxy {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case EmbeddingsPath:
+			if embeddingsDown.Load() {
+				http.Error(w, `{"error":{"message":"unavailable"}}`, http.StatusServiceUnavailable)
+				return
+			}
+			var req struct {
+				Input string `json:"input"`
+			}
+			json.NewDecoder(r.Body).Decode(&req)
+			vec := make([]float64, 26)
+			vec[int(strings.ToLower(req.Input)[0]-'a')%26] = 1
+			json.NewEncoder(w).Encode(map[string]any{
+				"data":  []map[string]any{{"index": 0, "embedding": vec}},
+				"usage": map[string]int{"total_tokens": 1},
+			})
+		case ChatCompletionsPath:
+			chats.Add(1)
+			var req ChatCompletionRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			json.NewEncoder(w).Encode(map[string]any{
+				"choices": []map[string]any{{"message": map[string]string{"role": "assistant", "content": req.Messages[len(req.Messages)-1].Content}}},
+				"usage":   map[string]int{"total_tokens": 10},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	sp := NewSiliconProxy("token")
+	sp.SetBaseURL(srv.URL)
+	return sp
+}
+
+func chatRequest(content string) *ChatCompletionRequest {
+	return &ChatCompletionRequest{
+		Model:    "m",
+		Messages: []ChatCompletionMessage{{Role: "user", Content: content}},
+	}
+}
+
+func TestSemanticCacheHit(t *testing.T) {
+	var down atomic.Bool
+	var chats atomic.Int64
+	c := NewSemanticCache(newSemanticUpstream(t, &down, &chats), SemanticCacheConfig{EmbeddingModel: "e"})
+
+	first, err := c.CreateChatCompletion(context.Background(), chatRequest("apple"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := c.CreateChatCompletion(context.Background(), chatRequest("avocado"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.CacheStatus != "" || second.CacheStatus != CacheSemanticHit {
+		t.Fatalf("缓存状态为%q、%q，期望\"\"、%q", first.CacheStatus, second.CacheStatus, CacheSemanticHit)
+	}
+	if second.Choices[0].Message.Content != "apple" {
+		t.Fatalf("命中时返回%q，期望缓存的apple", second.Choices[0].Message.Content)
+	}
+	if chats.Load() != 1 {
+		t.Fatalf("上游对话调用%d次，期望1次", chats.Load())
+	}
+}
+
+func TestSemanticCacheEmbeddingFailureFallsThrough(t *testing.T) {
+	var down atomic.Bool
+	var chats atomic.Int64
+	c := NewSemanticCache(newSemanticUpstream(t, &down, &chats), SemanticCacheConfig{EmbeddingModel: "e"})
+	down.Store(true)
+
+	resp, err := c.CreateChatCompletion(context.Background(), chatRequest("apple"))
+	if err != nil {
+		t.Fatalf("嵌入接口故障导致对话失败: %v", err)
+	}
+	if resp.Choices[0].Message.Content != "apple" || chats.Load() != 1 {
+		t.Fatalf("没有回退到上游对话: %+v", resp)
+	}
+	if stats := c.Stats(); stats.EmbeddingErrors != 1 || stats.Lookups != 0 {
+		t.Fatalf("统计信息 %+v", stats)
+	}
+}
+
+func TestSemanticCacheMaxEntries(t *testing.T) {
+	var down atomic.Bool
+	var chats atomic.Int64
+	c := NewSemanticCache(newSemanticUpstream(t, &down, &chats), SemanticCacheConfig{EmbeddingModel: "e", MaxEntries: 2})
+
+	for _, q := range []string{"apple", "banana", "cherry"} {
+		if _, err := c.CreateChatCompletion(context.Background(), chatRequest(q)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stats := c.Stats()
+	if stats.Entries != 2 || stats.Evictions != 1 {
+		t.Fatalf("条目数%d、淘汰数%d，期望2、1", stats.Entries, stats.Evictions)
+	}
+
+	// 最早写入的apple已被淘汰
+	resp, err := c.CreateChatCompletion(context.Background(), chatRequest("apricot"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.CacheStatus == CacheSemanticHit {
+		t.Fatal("已淘汰的条目仍然命中")
+	}
+	resp, err = c.CreateChatCompletion(context.Background(), chatRequest("cranberry"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.CacheStatus != CacheSemanticHit {
+		t.Fatal("未淘汰的条目没有命中")
+	}
+}
+
+func TestSemanticCacheScope(t *testing.T) {
+	withHistory := func(content string, history ...string) *ChatCompletionRequest {
+		req := chatRequest(content)
+		var msgs []ChatCompletionMessage
+		for _, h := range history {
+			msgs = append(msgs, ChatCompletionMessage{Role: "user", Content: h}, ChatCompletionMessage{Role: "assistant", Content: "ok"})
+		}
+		req.Messages = append(msgs, req.Messages...)
+		return req
+	}
+
+	tests := []struct {
+		name    string
+		first   *ChatCompletionRequest
+		second  *ChatCompletionRequest
+		wantHit bool
+	}{
+		{
+			name:    "相同上下文的近似问题",
+			first:   withHistory("apple", "hello"),
+			second:  withHistory("avocado", "hello"),
+			wantHit: true,
+		},
+		{
+			name:   "之前的对话轮次不同",
+			first:  withHistory("apple", "hello"),
+			second: withHistory("avocado", "goodbye"),
+		},
+		{
+			name:   "单轮与多轮",
+			first:  chatRequest("apple"),
+			second: withHistory("avocado", "hello"),
+		},
+		{
+			name:   "采样参数不同",
+			first:  chatRequest("apple"),
+			second: &ChatCompletionRequest{Model: "m", Messages: chatRequest("avocado").Messages, TopP: 0.5},
+		},
+		{
+			name:   "响应格式不同",
+			first:  chatRequest("apple"),
+			second: &ChatCompletionRequest{Model: "m", Messages: chatRequest("avocado").Messages, ResponseFormat: &ResponseFormat{Type: "json_object"}},
+		},
+		{
+			name:  "工具不同",
+			first: chatRequest("apple"),
+			second: &ChatCompletionRequest{Model: "m", Messages: chatRequest("avocado").Messages, Tools: []Tool{
+				{Type: "function", Function: FunctionObject{Name: "lookup"}},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var down atomic.Bool
+			var chats atomic.Int64
+			c := NewSemanticCache(newSemanticUpstream(t, &down, &chats), SemanticCacheConfig{EmbeddingModel: "e"})
+
+			if _, err := c.CreateChatCompletion(context.Background(), tt.first); err != nil {
+				t.Fatal(err)
+			}
+			resp, err := c.CreateChatCompletion(context.Background(), tt.second)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if hit := resp.CacheStatus == CacheSemanticHit; hit != tt.wantHit {
+				t.Fatalf("命中为%v，期望%v", hit, tt.wantHit)
+			}
+		})
+	}
+}
@@ -3,7 +3,11 @@ package siliconproxy
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
 
+	"github.com/kriswu/go_deepseek/cache"
 	"github.com/kriswu/go_deepseek/httpclient"
 )
 
@@ -16,30 +20,109 @@ const (
 	EmbeddingsPath      = "/embeddings"
 	RerankPath          = "/rerank"
 	ImagesGenerationPath = "/images/generations"
-	UploadVoicePath     = "/audio/voice"
+	UploadVoicePath     = "/uploads/audio/voice"
 	CreateSpeechPath    = "/audio/speech"
 	VoiceListPath       = "/audio/voice/list"
-	DeleteVoicePath     = "/audio/voice/delete"
-	VideosSubmitPath    = "/videos/submit"
-	VideosStatusPath    = "/videos/status"
+	DeleteVoicePath     = "/audio/voice/deletions"
+	TranscriptionsPath  = "/audio/transcriptions"
+	VideosSubmitPath    = "/video/submit"
+	VideosStatusPath    = "/video/status"
 	ModelsPath          = "/models"
-	UserInfoPath        = "/userinfo"
+	UserInfoPath        = "/user/info"
 )
 
 // SiliconProxy 是Silicon Flow API的代理
+// WithToken和WithTenant产生的副本与原代理共享同一个HTTP客户端，
+// 任何一个副本调用Close都会永久取消全部副本的请求，因此只能在进程退出时调用，配置重载等路径不能调用
 type SiliconProxy struct {
 	client *httpclient.Client
-	token  string
+	// 上游凭据，WithTenant产生的副本共享同一份，更新对所有副本生效
+	creds *atomic.Pointer[credentials]
+
+	// 响应缓存，为nil时不启用
+	cache    cache.Cache
+	cacheTTL time.Duration
+	// 租户标识，用于隔离缓存
+	tenant string
 }
 
 // NewSiliconProxy 创建一个新的Silicon Flow API代理
 func NewSiliconProxy(token string, options ...httpclient.ClientOption) *SiliconProxy {
+	creds := &atomic.Pointer[credentials]{}
+	creds.Store(&credentials{token: token, baseURL: BaseURL})
 	return &SiliconProxy{
 		client: httpclient.NewClient(options...),
-		token:  token,
+		creds:  creds,
 	}
 }
 
+// Close 取消所有进行中的上游请求，之后所有副本都不能再发起请求
+func (sp *SiliconProxy) Close() {
+	sp.client.Close()
+}
+
+// 上游凭据
+type credentials struct {
+	token   string
+	baseURL string
+}
+
+// SetBaseURL 设置API基础URL，用于私有部署或测试
+func (sp *SiliconProxy) SetBaseURL(baseURL string) {
+	sp.updateCredentials(func(c *credentials) {
+		c.baseURL = strings.TrimRight(baseURL, "/")
+	})
+}
+
+// SetToken 更换API令牌，已发出的请求不受影响
+func (sp *SiliconProxy) SetToken(token string) {
+	sp.updateCredentials(func(c *credentials) {
+		c.token = token
+	})
+}
+
+// SetCredentials 同时更换API令牌和基础URL
+// 两者一次替换，请求不会把新令牌发往旧地址或把旧令牌发往新地址
+func (sp *SiliconProxy) SetCredentials(token, baseURL string) {
+	sp.updateCredentials(func(c *credentials) {
+		c.token = token
+		c.baseURL = strings.TrimRight(baseURL, "/")
+	})
+}
+
+// 基于当前凭据修改后整体替换，并发修改时重试，不会丢失其他修改
+func (sp *SiliconProxy) updateCredentials(update func(*credentials)) {
+	for {
+		old := sp.creds.Load()
+		c := *old
+		update(&c)
+		if sp.creds.CompareAndSwap(old, &c) {
+			return
+		}
+	}
+}
+
+// WithToken 返回使用指定令牌的代理副本，缓存等其他设置保持不变
+// 副本的凭据独立于原代理，之后对原代理调用SetToken不影响副本
+func (sp *SiliconProxy) WithToken(token string) *SiliconProxy {
+	c := *sp.creds.Load()
+	c.token = token
+	scoped := *sp
+	scoped.creds = &atomic.Pointer[credentials]{}
+	scoped.creds.Store(&c)
+	return &scoped
+}
+
+// 返回当前令牌
+func (sp *SiliconProxy) authToken() string {
+	return sp.creds.Load().token
+}
+
+// 拼接API路径
+func (sp *SiliconProxy) endpoint(path string) string {
+	return sp.creds.Load().baseURL + path
+}
+
 // 错误响应结构
 type ErrorResponse struct {
 	Error struct {
@@ -58,13 +141,18 @@ func (sp *SiliconProxy) handleAPIResponse(resp *httpclient.Response, err error)
 
 	// 检查状态码
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		var errResp ErrorResponse
-		if err := json.Unmarshal([]byte(resp.Body), &errResp); err == nil {
-			return nil, fmt.Errorf("API错误: %s (类型: %s, 代码: %s)", 
-				errResp.Error.Message, errResp.Error.Type, errResp.Error.Code)
-		}
-		return nil, fmt.Errorf("API请求失败，状态码: %d, 响应: %s", resp.StatusCode, resp.Body)
+		return nil, apiError(resp.StatusCode, resp.Body)
 	}
 
 	return resp, nil
+}
+
+// 根据错误响应构造错误
+func apiError(statusCode int, body string) error {
+	var errResp ErrorResponse
+	if err := json.Unmarshal([]byte(body), &errResp); err == nil {
+		return fmt.Errorf("API错误: %s (类型: %s, 代码: %s)",
+			errResp.Error.Message, errResp.Error.Type, errResp.Error.Code)
+	}
+	return fmt.Errorf("API请求失败，状态码: %d, 响应: %s", statusCode, body)
 }
\ No newline at end of file
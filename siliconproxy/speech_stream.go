@@ -0,0 +1,214 @@
+package siliconproxy
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// 语音合成的输出格式
+const (
+	SpeechFormatMP3  = "mp3"
+	SpeechFormatOpus = "opus"
+	SpeechFormatWAV  = "wav"
+	SpeechFormatPCM  = "pcm"
+)
+
+// MaxSpeechSegmentRunes 是单次语音合成请求的最大字符数，超出时按句子切分为多次请求
+const MaxSpeechSegmentRunes = 500
+
+// 未指定采样率时wav/pcm使用的默认采样率
+const defaultPCMSampleRate = 44100
+
+// CreateSpeechStream 创建文本转语音请求，返回随数据到达即可读取的音频流
+// 长文本会在句子边界切分为多次请求，按顺序拼接为一个音频输出
+// wav格式在切分时会以pcm请求各段并只写入一次wav头
+func (sp *SiliconProxy) CreateSpeechStream(ctx context.Context, req *CreateSpeechRequest) (io.ReadCloser, error) {
+	format := req.ResponseFormat
+	switch format {
+	case "", SpeechFormatMP3, SpeechFormatOpus, SpeechFormatWAV, SpeechFormatPCM:
+	default:
+		return nil, fmt.Errorf("不支持的音频格式: %s", format)
+	}
+
+	segments := splitSentences(req.Input, MaxSpeechSegmentRunes)
+	if len(segments) <= 1 {
+		return sp.openSpeech(ctx, req)
+	}
+
+	segReq := *req
+	var header []byte
+	if format == SpeechFormatWAV {
+		if segReq.SampleRate == 0 {
+			segReq.SampleRate = defaultPCMSampleRate
+		}
+		segReq.ResponseFormat = SpeechFormatPCM
+		header = streamingWAVHeader(segReq.SampleRate)
+	}
+
+	r := &speechSegmentsReader{ctx: ctx, sp: sp, req: segReq, segments: segments, header: header}
+	// 先打开第一段，使请求错误能直接返回给调用方
+	if err := r.next(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// 发送单次语音合成请求并返回响应体
+func (sp *SiliconProxy) openSpeech(ctx context.Context, req *CreateSpeechRequest) (io.ReadCloser, error) {
+	url := sp.endpoint(CreateSpeechPath)
+
+	// 将请求转换为JSON
+	reqBody, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("序列化请求失败: %w", err)
+	}
+
+	// 发送POST请求
+	resp, err := sp.client.PostStreamWithAuth(ctx, url, "application/json", string(reqBody), sp.authToken())
+	if err != nil {
+		return nil, err
+	}
+
+	// 检查状态码
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return nil, apiError(resp.StatusCode, string(body))
+	}
+
+	return resp.Body, nil
+}
+
+// 依次请求各段文本并拼接输出的读取器
+type speechSegmentsReader struct {
+	ctx      context.Context // 后续各段请求使用
+	sp       *SiliconProxy
+	req      CreateSpeechRequest
+	segments []string
+	header   []byte
+	index    int
+	current  io.ReadCloser
+}
+
+func (r *speechSegmentsReader) Read(p []byte) (int, error) {
+	if len(r.header) > 0 {
+		n := copy(p, r.header)
+		r.header = r.header[n:]
+		return n, nil
+	}
+
+	for {
+		if r.current == nil {
+			if r.index >= len(r.segments) {
+				return 0, io.EOF
+			}
+			if err := r.next(); err != nil {
+				return 0, err
+			}
+		}
+
+		n, err := r.current.Read(p)
+		if err == io.EOF {
+			r.current.Close()
+			r.current = nil
+			if n > 0 {
+				return n, nil
+			}
+			continue
+		}
+		return n, err
+	}
+}
+
+func (r *speechSegmentsReader) Close() error {
+	if r.current != nil {
+		err := r.current.Close()
+		r.current = nil
+		return err
+	}
+	return nil
+}
+
+// 打开下一段文本的音频流
+func (r *speechSegmentsReader) next() error {
+	req := r.req
+	req.Input = r.segments[r.index]
+	body, err := r.sp.openSpeech(r.ctx, &req)
+	if err != nil {
+		return fmt.Errorf("合成第%d段语音失败: %w", r.index+1, err)
+	}
+	r.index++
+	r.current = body
+	return nil
+}
+
+// 按句子边界切分文本，每段不超过maxRunes个字符，单句超长时强制切分
+func splitSentences(text string, maxRunes int) []string {
+	if utf8.RuneCountInString(text) <= maxRunes {
+		return []string{text}
+	}
+
+	var sentences []string
+	var sb strings.Builder
+	for _, r := range text {
+		sb.WriteRune(r)
+		if strings.ContainsRune("。！？；.!?;\n", r) {
+			sentences = append(sentences, sb.String())
+			sb.Reset()
+		}
+	}
+	if sb.Len() > 0 {
+		sentences = append(sentences, sb.String())
+	}
+
+	var segments []string
+	var current []rune
+	flush := func() {
+		if s := strings.TrimSpace(string(current)); s != "" {
+			segments = append(segments, s)
+		}
+		current = current[:0]
+	}
+	for _, sentence := range sentences {
+		runes := []rune(sentence)
+		if len(current)+len(runes) > maxRunes {
+			flush()
+		}
+		for len(runes) > maxRunes {
+			current = append(current, runes[:maxRunes]...)
+			flush()
+			runes = runes[maxRunes:]
+		}
+		current = append(current, runes...)
+	}
+	flush()
+	return segments
+}
+
+// 生成长度未知的流式wav头（16位单声道PCM）
+func streamingWAVHeader(sampleRate int) []byte {
+	const (
+		channels      = 1
+		bitsPerSample = 16
+		unknownSize   = 0xFFFFFFFF
+	)
+	header := make([]byte, 44)
+	copy(header[0:], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:], unknownSize)
+	copy(header[8:], "WAVEfmt ")
+	binary.LittleEndian.PutUint32(header[16:], 16)
+	binary.LittleEndian.PutUint16(header[20:], 1)
+	binary.LittleEndian.PutUint16(header[22:], channels)
+	binary.LittleEndian.PutUint32(header[24:], uint32(sampleRate))
+	binary.LittleEndian.PutUint32(header[28:], uint32(sampleRate*channels*bitsPerSample/8))
+	binary.LittleEndian.PutUint16(header[32:], channels*bitsPerSample/8)
+	binary.LittleEndian.PutUint16(header[34:], bitsPerSample)
+	copy(header[36:], "data")
+	binary.LittleEndian.PutUint32(header[40:], unknownSize)
+	return header
+}
@@ -0,0 +1,111 @@
+package siliconproxy
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSplitSentences(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		maxRunes int
+		want     []string
+	}{
+		{name: "不超过上限时不切分", text: "你好。世界。", maxRunes: 6, want: []string{"你好。世界。"}},
+		{name: "按中文句号切分", text: "第一句。第二句。第三句。", maxRunes: 8, want: []string{"第一句。第二句。", "第三句。"}},
+		{name: "按英文标点切分并去掉空白", text: "One. Two! Three?", maxRunes: 10, want: []string{"One. Two!", "Three?"}},
+		{name: "换行也是句子边界", text: "abc\ndef\nghi", maxRunes: 8, want: []string{"abc\ndef", "ghi"}},
+		{name: "单句超长时强制切分", text: "一二三四五六七八九十", maxRunes: 4, want: []string{"一二三四", "五六七八", "九十"}},
+		{name: "超长句子前的内容单独成段", text: "好。一二三四五六", maxRunes: 4, want: []string{"好。", "一二三四", "五六"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitSentences(tt.text, tt.maxRunes)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Fatalf("切分结果为%q，期望%q", got, tt.want)
+			}
+			for _, s := range got {
+				if n := utf8.RuneCountInString(s); n > tt.maxRunes {
+					t.Fatalf("段落%q有%d个字符，超过上限%d", s, n, tt.maxRunes)
+				}
+			}
+		})
+	}
+}
+
+func TestStreamingWAVHeader(t *testing.T) {
+	h := streamingWAVHeader(24000)
+	if len(h) != 44 {
+		t.Fatalf("wav头长度为%d，期望44", len(h))
+	}
+	le := binary.LittleEndian
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{name: "RIFF标识", got: string(h[0:4]), want: "RIFF"},
+		{name: "RIFF长度未知", got: le.Uint32(h[4:]), want: uint32(0xFFFFFFFF)},
+		{name: "WAVE与fmt标识", got: string(h[8:16]), want: "WAVEfmt "},
+		{name: "fmt块长度", got: le.Uint32(h[16:]), want: uint32(16)},
+		{name: "PCM格式", got: le.Uint16(h[20:]), want: uint16(1)},
+		{name: "单声道", got: le.Uint16(h[22:]), want: uint16(1)},
+		{name: "采样率", got: le.Uint32(h[24:]), want: uint32(24000)},
+		{name: "字节率", got: le.Uint32(h[28:]), want: uint32(48000)},
+		{name: "块对齐", got: le.Uint16(h[32:]), want: uint16(2)},
+		{name: "位深", got: le.Uint16(h[34:]), want: uint16(16)},
+		{name: "data标识", got: string(h[36:40]), want: "data"},
+		{name: "data长度未知", got: le.Uint32(h[40:]), want: uint32(0xFFFFFFFF)},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s为%v，期望%v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+// 长文本wav合成时各段以pcm请求，输出只有一个wav头，音频按段落顺序拼接
+func TestCreateSpeechStreamWAVSegments(t *testing.T) {
+	var formats []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req CreateSpeechRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		formats = append(formats, req.ResponseFormat)
+		w.Write([]byte("[" + req.Input + "]"))
+	}))
+	defer srv.Close()
+	sp := NewSiliconProxy("token")
+	sp.SetBaseURL(srv.URL)
+
+	first := strings.Repeat("a", MaxSpeechSegmentRunes-1) + "."
+	second := "b."
+	body, err := sp.CreateSpeechStream(context.Background(), &CreateSpeechRequest{
+		Model:          "m",
+		Input:          first + second,
+		ResponseFormat: SpeechFormatWAV,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(formats) != 2 || formats[0] != SpeechFormatPCM || formats[1] != SpeechFormatPCM {
+		t.Fatalf("各段请求格式为%v，期望两段pcm", formats)
+	}
+	header := streamingWAVHeader(defaultPCMSampleRate)
+	if want := string(header) + "[" + first + "][" + second + "]"; string(data) != want {
+		t.Fatalf("输出长度%d，期望%d，或段落顺序错误", len(data), len(want))
+	}
+}
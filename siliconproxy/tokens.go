@@ -0,0 +1,17 @@
+package siliconproxy
+
+import "unicode"
+
+// EstimateTokens 粗略估算文本的token数
+// 中日韩字符按每字1个token计算，其余字符按每4个字符1个token计算
+func EstimateTokens(text string) int {
+	var cjk, other int
+	for _, r := range text {
+		if unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) {
+			cjk++
+		} else {
+			other++
+		}
+	}
+	return cjk + (other+3)/4
+}
@@ -0,0 +1,69 @@
+package siliconproxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/kriswu/go_deepseek/httpclient"
+)
+
+// TranscriptionRequest 表示语音转文本请求，FilePath和Reader二选一
+type TranscriptionRequest struct {
+	Model    string    // 如FunAudioLLM/SenseVoiceSmall
+	FilePath string    // 本地音频文件路径
+	Reader   io.Reader // 音频数据流
+	FileName string    // 使用Reader时上传的文件名，用于服务端识别格式
+}
+
+// TranscriptionResponse 表示语音转文本响应
+type TranscriptionResponse struct {
+	Text string `json:"text"`
+}
+
+// CreateTranscription 创建语音转文本请求，音频以multipart表单流式上传
+func (sp *SiliconProxy) CreateTranscription(ctx context.Context, req *TranscriptionRequest) (*TranscriptionResponse, error) {
+	url := sp.endpoint(TranscriptionsPath)
+
+	reader := req.Reader
+	fileName := req.FileName
+	if req.FilePath != "" {
+		file, err := os.Open(req.FilePath)
+		if err != nil {
+			return nil, fmt.Errorf("打开文件失败: %w", err)
+		}
+		defer file.Close()
+		reader = file
+		if fileName == "" {
+			fileName = filepath.Base(req.FilePath)
+		}
+	}
+	if reader == nil {
+		return nil, errors.New("未提供音频数据")
+	}
+	if fileName == "" {
+		fileName = "audio"
+	}
+
+	// 发送POST请求
+	resp, err := sp.client.PostMultipartWithAuth(ctx, url,
+		map[string]string{"model": req.Model},
+		[]httpclient.MultipartFile{{FieldName: "file", FileName: fileName, Reader: reader}},
+		sp.authToken())
+	resp, err = sp.handleAPIResponse(resp, err)
+	if err != nil {
+		return nil, err
+	}
+
+	// 解析响应
+	var result TranscriptionResponse
+	if err := json.Unmarshal([]byte(resp.Body), &result); err != nil {
+		return nil, fmt.Errorf("解析响应失败: %w", err)
+	}
+
+	return &result, nil
+}
@@ -1,36 +1,65 @@
 package siliconproxy
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 )
 
 // UserInfoResponse 表示用户账户信息响应
 type UserInfoResponse struct {
-	Object  string `json:"object"`
-	ID      string `json:"id"`
-	Name    string `json:"name"`
-	Email   string `json:"email"`
-	Created int64  `json:"created"`
-	Balance int64  `json:"balance"`
+	Code    int      `json:"code"`
+	Message string   `json:"message"`
+	Status  bool     `json:"status"`
+	Data    UserInfo `json:"data"`
+}
+
+// UserInfo 表示用户账户信息，余额均为以字符串表示的金额（元）
+type UserInfo struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	Image         string `json:"image"`
+	Email         string `json:"email"`
+	IsAdmin       bool   `json:"isAdmin"`
+	Balance       string `json:"balance"` // 赠送余额
+	Status        string `json:"status"`  // 账户状态
+	Introduction  string `json:"introduction"`
+	Role          string `json:"role"`
+	ChargeBalance string `json:"chargeBalance"` // 充值余额
+	TotalBalance  string `json:"totalBalance"`  // 总余额
+}
+
+// Balances 将三种余额解析为浮点数，返回赠送余额、充值余额和总余额
+func (u *UserInfo) Balances() (balance, charge, total float64, err error) {
+	values := make([]float64, 3)
+	for i, s := range []string{u.Balance, u.ChargeBalance, u.TotalBalance} {
+		if s == "" {
+			continue
+		}
+		if values[i], err = strconv.ParseFloat(s, 64); err != nil {
+			return 0, 0, 0, fmt.Errorf("解析余额%q失败: %w", s, err)
+		}
+	}
+	return values[0], values[1], values[2], nil
 }
 
 // GetUserInfo 获取用户账户信息
-func (sp *SiliconProxy) GetUserInfo() (*UserInfoResponse, error) {
-	url := BaseURL + UserInfoPath
-	
+func (sp *SiliconProxy) GetUserInfo(ctx context.Context) (*UserInfoResponse, error) {
+	url := sp.endpoint(UserInfoPath)
+
 	// 发送GET请求
-	resp, err := sp.client.GetWithAuth(url, sp.token)
+	resp, err := sp.client.GetWithAuth(ctx, url, sp.authToken())
 	resp, err = sp.handleAPIResponse(resp, err)
 	if err != nil {
 		return nil, err
 	}
-	
+
 	// 解析响应
 	var result UserInfoResponse
 	if err := json.Unmarshal([]byte(resp.Body), &result); err != nil {
 		return nil, fmt.Errorf("解析响应失败: %w", err)
 	}
-	
+
 	return &result, nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,39 @@
+package siliconproxy
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUserInfoBalances(t *testing.T) {
+	tests := []struct {
+		name                   string
+		data                   string
+		balance, charge, total float64
+		wantErr                bool
+	}{
+		{
+			name:    "字符串金额",
+			data:    `{"balance":"0.88","chargeBalance":"88.00","totalBalance":"88.88"}`,
+			balance: 0.88, charge: 88, total: 88.88,
+		},
+		{name: "缺少字段视为0", data: `{"totalBalance":"5"}`, total: 5},
+		{name: "负数", data: `{"totalBalance":"-1.5"}`, total: -1.5},
+		{name: "非数字", data: `{"totalBalance":"abc"}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var info UserInfo
+			if err := json.Unmarshal([]byte(tt.data), &info); err != nil {
+				t.Fatal(err)
+			}
+			balance, charge, total, err := info.Balances()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("错误为%v，期望出错: %v", err, tt.wantErr)
+			}
+			if balance != tt.balance || charge != tt.charge || total != tt.total {
+				t.Fatalf("余额为%v、%v、%v，期望%v、%v、%v", balance, charge, total, tt.balance, tt.charge, tt.total)
+			}
+		})
+	}
+}
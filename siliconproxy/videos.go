@@ -1,44 +1,130 @@
 package siliconproxy
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 )
 
 // VideoSubmitRequest 表示视频生成请求
 type VideoSubmitRequest struct {
-	Model       string `json:"model"`
-	Prompt      string `json:"prompt"`
-	Duration    int    `json:"duration,omitempty"`
-	Quality     string `json:"quality,omitempty"`
-	Style       string `json:"style,omitempty"`
-	User        string `json:"user,omitempty"`
+	Model          string `json:"model"`
+	Prompt         string `json:"prompt"`
+	NegativePrompt string `json:"negative_prompt,omitempty"`
+	ImageSize      string `json:"image_size,omitempty"` // 1280x720、720x1280或960x960
+	// 图生视频的输入图像，可以是URL或base64数据URI，见SetImageFromFile
+	Image string `json:"image,omitempty"`
+	Seed  int64  `json:"seed,omitempty"`
 }
 
+// VideoImageSizes 是视频模型支持的image_size
+var VideoImageSizes = []string{"1280x720", "720x1280", "960x960"}
+
+// SetImageFromFile 读取本地图像文件作为图生视频的输入
+func (req *VideoSubmitRequest) SetImageFromFile(path string) error {
+	uri, err := DataURIFromFile(path)
+	if err != nil {
+		return err
+	}
+	req.Image = uri
+	return nil
+}
+
+// SetImageFromBytes 使用图像数据作为图生视频的输入
+func (req *VideoSubmitRequest) SetImageFromBytes(data []byte) {
+	req.Image = DataURIFromBytes(data)
+}
+
+// Validate 检查请求参数，图生视频模型（名称包含I2V）必须提供输入图像
+func (req *VideoSubmitRequest) Validate() error {
+	if req.Model == "" {
+		return fmt.Errorf("模型不能为空")
+	}
+	if req.Prompt == "" {
+		return fmt.Errorf("提示词不能为空")
+	}
+	if strings.Contains(strings.ToUpper(req.Model), "I2V") && req.Image == "" {
+		return fmt.Errorf("模型%s需要输入图像", req.Model)
+	}
+	if req.Image != "" && !strings.HasPrefix(req.Image, "data:image/") &&
+		!strings.HasPrefix(req.Image, "http://") && !strings.HasPrefix(req.Image, "https://") {
+		return fmt.Errorf("输入图像必须是URL或图像数据URI")
+	}
+	if req.ImageSize != "" {
+		for _, size := range VideoImageSizes {
+			if size == req.ImageSize {
+				return nil
+			}
+		}
+		return fmt.Errorf("不支持的image_size %s，可选值: %s", req.ImageSize, strings.Join(VideoImageSizes, ", "))
+	}
+	return nil
+}
+
+// 视频任务状态
+const (
+	VideoStatusInQueue    = "InQueue"
+	VideoStatusInProgress = "InProgress"
+	VideoStatusSucceed    = "Succeed"
+	VideoStatusFailed     = "Failed"
+)
+
 // VideoSubmitResponse 表示视频生成响应
 type VideoSubmitResponse struct {
-	ID      string `json:"id"`
-	Created int64  `json:"created"`
-	Status  string `json:"status"`
+	RequestID string `json:"requestId"`
 }
 
 // VideoStatusRequest 表示获取视频状态请求
 type VideoStatusRequest struct {
-	ID string `json:"id"`
+	RequestID string `json:"requestId"`
 }
 
 // VideoStatusResponse 表示获取视频状态响应
 type VideoStatusResponse struct {
-	ID      string `json:"id"`
-	Created int64  `json:"created"`
-	Status  string `json:"status"`
-	URL     string `json:"url,omitempty"`
+	Status  string        `json:"status"`
+	Reason  string        `json:"reason,omitempty"`
+	Results *VideoResults `json:"results,omitempty"`
+}
+
+// VideoResults 表示视频生成结果
+type VideoResults struct {
+	Videos  []VideoResult `json:"videos"`
+	Timings *VideoTimings `json:"timings,omitempty"`
+	Seed    int64         `json:"seed,omitempty"`
+}
+
+// VideoResult 表示生成的视频，URL为有时效的签名地址
+type VideoResult struct {
+	URL string `json:"url"`
+}
+
+// VideoTimings 表示视频生成耗时
+type VideoTimings struct {
+	Inference float64 `json:"inference"`
+}
+
+// Done 判断任务是否已结束（成功或失败）
+func (r *VideoStatusResponse) Done() bool {
+	return r.Status == VideoStatusSucceed || r.Status == VideoStatusFailed
+}
+
+// VideoURL 返回第一个视频的地址，没有结果时返回空字符串
+func (r *VideoStatusResponse) VideoURL() string {
+	if r.Results == nil || len(r.Results.Videos) == 0 {
+		return ""
+	}
+	return r.Results.Videos[0].URL
 }
 
 // CreateVideoSubmit 创建视频生成请求
-func (sp *SiliconProxy) CreateVideoSubmit(req *VideoSubmitRequest) (*VideoSubmitResponse, error) {
-	url := BaseURL + VideosSubmitPath
+func (sp *SiliconProxy) CreateVideoSubmit(ctx context.Context, req *VideoSubmitRequest) (*VideoSubmitResponse, error) {
+	url := sp.endpoint(VideosSubmitPath)
 	
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
 	// 将请求转换为JSON
 	reqBody, err := json.Marshal(req)
 	if err != nil {
@@ -46,7 +132,7 @@ func (sp *SiliconProxy) CreateVideoSubmit(req *VideoSubmitRequest) (*VideoSubmit
 	}
 	
 	// 发送POST请求
-	resp, err := sp.client.PostWithAuth(url, "application/json", string(reqBody), sp.token)
+	resp, err := sp.client.PostWithAuth(ctx, url, "application/json", string(reqBody), sp.authToken())
 	resp, err = sp.handleAPIResponse(resp, err)
 	if err != nil {
 		return nil, err
@@ -62,8 +148,8 @@ func (sp *SiliconProxy) CreateVideoSubmit(req *VideoSubmitRequest) (*VideoSubmit
 }
 
 // GetVideoStatus 获取视频状态
-func (sp *SiliconProxy) GetVideoStatus(req *VideoStatusRequest) (*VideoStatusResponse, error) {
-	url := BaseURL + VideosStatusPath
+func (sp *SiliconProxy) GetVideoStatus(ctx context.Context, req *VideoStatusRequest) (*VideoStatusResponse, error) {
+	url := sp.endpoint(VideosStatusPath)
 	
 	// 将请求转换为JSON
 	reqBody, err := json.Marshal(req)
@@ -72,7 +158,7 @@ func (sp *SiliconProxy) GetVideoStatus(req *VideoStatusRequest) (*VideoStatusRes
 	}
 	
 	// 发送POST请求
-	resp, err := sp.client.PostWithAuth(url, "application/json", string(reqBody), sp.token)
+	resp, err := sp.client.PostWithAuth(ctx, url, "application/json", string(reqBody), sp.authToken())
 	resp, err = sp.handleAPIResponse(resp, err)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,103 @@
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+)
+
+// Config 表示服务端TLS配置
+type Config struct {
+	CertFile string
+	KeyFile  string
+	// 客户端CA证书文件，为空时不校验客户端证书
+	ClientCAFile string
+	// 为true时要求客户端必须提供证书，否则只校验提供了的证书
+	RequireClientCert bool
+}
+
+// Reloader 持有当前证书，Reload后新握手使用新证书，已建立的连接不受影响
+type Reloader struct {
+	conf Config
+
+	cert      atomic.Pointer[tls.Certificate]
+	clientCAs atomic.Pointer[x509.CertPool]
+}
+
+// New 加载证书并创建Reloader
+func New(conf Config) (*Reloader, error) {
+	if conf.CertFile == "" || conf.KeyFile == "" {
+		return nil, errors.New("TLS必须同时配置证书和私钥")
+	}
+	if conf.RequireClientCert && conf.ClientCAFile == "" {
+		return nil, errors.New("要求客户端证书时必须配置客户端CA")
+	}
+	r := &Reloader{conf: conf}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload 重新读取证书文件，失败时继续使用原证书
+func (r *Reloader) Reload() error {
+	cert, err := tls.LoadX509KeyPair(r.conf.CertFile, r.conf.KeyFile)
+	if err != nil {
+		return fmt.Errorf("加载服务端证书失败: %w", err)
+	}
+
+	var pool *x509.CertPool
+	if r.conf.ClientCAFile != "" {
+		data, err := os.ReadFile(r.conf.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("读取客户端CA失败: %w", err)
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return fmt.Errorf("客户端CA文件%s中没有有效证书", r.conf.ClientCAFile)
+		}
+	}
+
+	r.cert.Store(&cert)
+	r.clientCAs.Store(pool)
+	return nil
+}
+
+// Files 返回需要监视变化的文件
+func (r *Reloader) Files() []string {
+	files := []string{r.conf.CertFile, r.conf.KeyFile}
+	if r.conf.ClientCAFile != "" {
+		files = append(files, r.conf.ClientCAFile)
+	}
+	return files
+}
+
+// TLSConfig 返回服务端tls.Config，每次握手读取当前证书和客户端CA
+// nextProtos为ALPN协议列表，为空时只声明gRPC使用的h2
+func (r *Reloader) TLSConfig(nextProtos ...string) *tls.Config {
+	if len(nextProtos) == 0 {
+		nextProtos = []string{"h2"}
+	}
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			// 返回的配置会整体替换外层配置，需要重新声明ALPN协议
+			c := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*r.cert.Load()},
+				NextProtos:   nextProtos,
+			}
+			if pool := r.clientCAs.Load(); pool != nil {
+				c.ClientCAs = pool
+				c.ClientAuth = tls.VerifyClientCertIfGiven
+				if r.conf.RequireClientCert {
+					c.ClientAuth = tls.RequireAndVerifyClientCert
+				}
+			}
+			return c, nil
+		},
+	}
+}
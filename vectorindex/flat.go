@@ -0,0 +1,114 @@
+package vectorindex
+
+import (
+	"sort"
+	"sync"
+)
+
+// Flat 是暴力检索的向量索引，适合数据量较小的场景
+type Flat struct {
+	mu   sync.RWMutex
+	dim  int
+	ids  []string
+	vecs [][]float32
+	pos  map[string]int
+}
+
+// NewFlat 创建暴力检索索引，维度由第一次添加的向量决定
+func NewFlat() *Flat {
+	return &Flat{pos: make(map[string]int)}
+}
+
+// Add 添加或替换向量
+func (f *Flat) Add(id string, vec []float32) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.dim == 0 {
+		f.dim = len(vec)
+	} else if len(vec) != f.dim {
+		return ErrDimensionMismatch
+	}
+
+	normalized := Normalize(vec)
+	if i, ok := f.pos[id]; ok {
+		f.vecs[i] = normalized
+		return nil
+	}
+	f.pos[id] = len(f.ids)
+	f.ids = append(f.ids, id)
+	f.vecs = append(f.vecs, normalized)
+	return nil
+}
+
+// Remove 删除向量，用最后一个元素填补空位
+func (f *Flat) Remove(id string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	i, ok := f.pos[id]
+	if !ok {
+		return
+	}
+	last := len(f.ids) - 1
+	if i != last {
+		f.ids[i] = f.ids[last]
+		f.vecs[i] = f.vecs[last]
+		f.pos[f.ids[i]] = i
+	}
+	f.ids = f.ids[:last]
+	f.vecs = f.vecs[:last]
+	delete(f.pos, id)
+}
+
+// Search 返回相似度最高的k个结果
+func (f *Flat) Search(vec []float32, k int) ([]Result, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if len(f.ids) == 0 || k <= 0 {
+		return nil, nil
+	}
+	if len(vec) != f.dim {
+		return nil, ErrDimensionMismatch
+	}
+
+	query := Normalize(vec)
+	results := make([]Result, len(f.ids))
+	for i, v := range f.vecs {
+		results[i] = Result{ID: f.ids[i], Score: dot(query, v)}
+	}
+	sort.Slice(results, func(a, b int) bool {
+		return results[a].Score > results[b].Score
+	})
+	if len(results) > k {
+		results = results[:k]
+	}
+	return results, nil
+}
+
+// Len 返回向量数量
+func (f *Flat) Len() int {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return len(f.ids)
+}
+
+// Dim 返回向量维度
+func (f *Flat) Dim() int {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.dim
+}
+
+// Items 返回全部向量
+func (f *Flat) Items() []Item {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	items := make([]Item, len(f.ids))
+	for i, id := range f.ids {
+		items[i] = Item{ID: id, Vector: f.vecs[i]}
+	}
+	return items
+}
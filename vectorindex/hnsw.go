@@ -0,0 +1,292 @@
+package vectorindex
+
+import (
+	"container/heap"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+)
+
+// HNSWConfig 表示HNSW索引参数
+type HNSWConfig struct {
+	M              int // 每层最大邻居数，默认16，第0层为2M
+	EfConstruction int // 构建时的候选集大小，默认200
+	EfSearch       int // 检索时的候选集大小，默认64
+}
+
+// HNSW 是分层可导航小世界图索引，适合数据量较大的近似检索
+// 删除采用标记方式，被删除的节点仍参与图导航但不会出现在结果中
+type HNSW struct {
+	mu        sync.RWMutex
+	conf      HNSWConfig
+	levelMult float64
+	rng       *rand.Rand
+
+	dim      int
+	nodes    []*hnswNode
+	byID     map[string]int
+	entry    int
+	maxLevel int
+}
+
+type hnswNode struct {
+	id      string
+	vec     []float32
+	friends [][]int
+	deleted bool
+}
+
+// NewHNSW 创建HNSW索引
+func NewHNSW(conf HNSWConfig) *HNSW {
+	if conf.M <= 0 {
+		conf.M = 16
+	}
+	if conf.EfConstruction <= 0 {
+		conf.EfConstruction = 200
+	}
+	if conf.EfSearch <= 0 {
+		conf.EfSearch = 64
+	}
+	return &HNSW{
+		conf:      conf,
+		levelMult: 1 / math.Log(float64(conf.M)),
+		rng:       rand.New(rand.NewSource(1)),
+		byID:      make(map[string]int),
+		entry:     -1,
+	}
+}
+
+// Add 添加或替换向量
+func (h *HNSW) Add(id string, vec []float32) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.dim == 0 {
+		h.dim = len(vec)
+	} else if len(vec) != h.dim {
+		return ErrDimensionMismatch
+	}
+
+	// 替换时标记旧节点为删除，再插入新节点
+	if old, ok := h.byID[id]; ok {
+		h.nodes[old].deleted = true
+	}
+
+	q := Normalize(vec)
+	level := int(-math.Log(1-h.rng.Float64()) * h.levelMult)
+	n := len(h.nodes)
+	node := &hnswNode{id: id, vec: q, friends: make([][]int, level+1)}
+	h.nodes = append(h.nodes, node)
+	h.byID[id] = n
+
+	if h.entry < 0 {
+		h.entry = n
+		h.maxLevel = level
+		return nil
+	}
+
+	ep := h.entry
+	for l := h.maxLevel; l > level; l-- {
+		ep = h.greedy(q, ep, l)
+	}
+	for l := min(level, h.maxLevel); l >= 0; l-- {
+		candidates := h.searchLayer(q, ep, h.conf.EfConstruction, l)
+		neighbors := candidates
+		if len(neighbors) > h.conf.M {
+			neighbors = neighbors[:h.conf.M]
+		}
+		node.friends[l] = make([]int, 0, len(neighbors))
+		for _, c := range neighbors {
+			node.friends[l] = append(node.friends[l], c.node)
+			h.connect(c.node, n, l)
+		}
+		ep = candidates[0].node
+	}
+	if level > h.maxLevel {
+		h.maxLevel = level
+		h.entry = n
+	}
+	return nil
+}
+
+// Remove 标记删除向量
+func (h *HNSW) Remove(id string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if i, ok := h.byID[id]; ok {
+		h.nodes[i].deleted = true
+		delete(h.byID, id)
+	}
+}
+
+// Search 返回相似度最高的k个结果
+func (h *HNSW) Search(vec []float32, k int) ([]Result, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if h.entry < 0 || k <= 0 {
+		return nil, nil
+	}
+	if len(vec) != h.dim {
+		return nil, ErrDimensionMismatch
+	}
+
+	q := Normalize(vec)
+	ep := h.entry
+	for l := h.maxLevel; l > 0; l-- {
+		ep = h.greedy(q, ep, l)
+	}
+	ef := max(h.conf.EfSearch, 2*k)
+	candidates := h.searchLayer(q, ep, ef, 0)
+
+	results := make([]Result, 0, k)
+	for _, c := range candidates {
+		node := h.nodes[c.node]
+		if node.deleted {
+			continue
+		}
+		results = append(results, Result{ID: node.id, Score: 1 - c.dist})
+		if len(results) == k {
+			break
+		}
+	}
+	return results, nil
+}
+
+// Len 返回未删除的向量数量
+func (h *HNSW) Len() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.byID)
+}
+
+// Dim 返回向量维度
+func (h *HNSW) Dim() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.dim
+}
+
+// Items 返回全部未删除的向量
+func (h *HNSW) Items() []Item {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	items := make([]Item, 0, len(h.byID))
+	for _, i := range h.byID {
+		items = append(items, Item{ID: h.nodes[i].id, Vector: h.nodes[i].vec})
+	}
+	sort.Slice(items, func(a, b int) bool { return items[a].ID < items[b].ID })
+	return items
+}
+
+// 在指定层上贪心地移动到离q最近的节点
+func (h *HNSW) greedy(q []float32, ep, layer int) int {
+	best := ep
+	bestDist := h.distance(q, ep)
+	for changed := true; changed; {
+		changed = false
+		for _, f := range h.nodes[best].friends[layer] {
+			if d := h.distance(q, f); d < bestDist {
+				best, bestDist = f, d
+				changed = true
+			}
+		}
+	}
+	return best
+}
+
+// 在指定层上进行束搜索，返回按距离升序排列的候选
+func (h *HNSW) searchLayer(q []float32, ep, ef, layer int) []hnswCandidate {
+	visited := map[int]bool{ep: true}
+	start := hnswCandidate{node: ep, dist: h.distance(q, ep)}
+	candidates := &minHeap{start}
+	results := &maxHeap{start}
+
+	for candidates.Len() > 0 {
+		c := heap.Pop(candidates).(hnswCandidate)
+		if c.dist > (*results)[0].dist && results.Len() >= ef {
+			break
+		}
+		for _, f := range h.nodes[c.node].friends[layer] {
+			if visited[f] {
+				continue
+			}
+			visited[f] = true
+			d := h.distance(q, f)
+			if results.Len() < ef || d < (*results)[0].dist {
+				heap.Push(candidates, hnswCandidate{node: f, dist: d})
+				heap.Push(results, hnswCandidate{node: f, dist: d})
+				if results.Len() > ef {
+					heap.Pop(results)
+				}
+			}
+		}
+	}
+
+	sorted := make([]hnswCandidate, results.Len())
+	for i := len(sorted) - 1; i >= 0; i-- {
+		sorted[i] = heap.Pop(results).(hnswCandidate)
+	}
+	return sorted
+}
+
+// 将to加入from在指定层的邻居，超出上限时只保留最近的邻居
+func (h *HNSW) connect(from, to, layer int) {
+	node := h.nodes[from]
+	node.friends[layer] = append(node.friends[layer], to)
+
+	limit := h.conf.M
+	if layer == 0 {
+		limit = 2 * h.conf.M
+	}
+	if len(node.friends[layer]) <= limit {
+		return
+	}
+
+	friends := node.friends[layer]
+	sort.Slice(friends, func(a, b int) bool {
+		return h.distance(node.vec, friends[a]) < h.distance(node.vec, friends[b])
+	})
+	node.friends[layer] = friends[:limit]
+}
+
+// 余弦距离，向量均已单位化
+func (h *HNSW) distance(q []float32, node int) float64 {
+	return 1 - dot(q, h.nodes[node].vec)
+}
+
+type hnswCandidate struct {
+	node int
+	dist float64
+}
+
+// 按距离升序的小顶堆
+type minHeap []hnswCandidate
+
+func (m minHeap) Len() int            { return len(m) }
+func (m minHeap) Less(i, j int) bool  { return m[i].dist < m[j].dist }
+func (m minHeap) Swap(i, j int)       { m[i], m[j] = m[j], m[i] }
+func (m *minHeap) Push(x interface{}) { *m = append(*m, x.(hnswCandidate)) }
+func (m *minHeap) Pop() interface{} {
+	old := *m
+	x := old[len(old)-1]
+	*m = old[:len(old)-1]
+	return x
+}
+
+// 按距离降序的大顶堆
+type maxHeap []hnswCandidate
+
+func (m maxHeap) Len() int            { return len(m) }
+func (m maxHeap) Less(i, j int) bool  { return m[i].dist > m[j].dist }
+func (m maxHeap) Swap(i, j int)       { m[i], m[j] = m[j], m[i] }
+func (m *maxHeap) Push(x interface{}) { *m = append(*m, x.(hnswCandidate)) }
+func (m *maxHeap) Pop() interface{} {
+	old := *m
+	x := old[len(old)-1]
+	*m = old[:len(old)-1]
+	return x
+}
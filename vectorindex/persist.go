@@ -0,0 +1,79 @@
+package vectorindex
+
+import (
+	"encoding/gob"
+	"fmt"
+	"os"
+)
+
+// 索引类型
+const (
+	TypeFlat = "flat"
+	TypeHNSW = "hnsw"
+)
+
+// 持久化文件格式，只保存向量，加载时重建索引结构
+type snapshot struct {
+	Type  string
+	Items []Item
+}
+
+// New 按类型创建空索引
+func New(indexType string) (Index, error) {
+	switch indexType {
+	case "", TypeFlat:
+		return NewFlat(), nil
+	case TypeHNSW:
+		return NewHNSW(HNSWConfig{}), nil
+	default:
+		return nil, fmt.Errorf("不支持的索引类型: %s", indexType)
+	}
+}
+
+// Save 将索引保存到文件
+func Save(path, indexType string, idx Index) error {
+	tmp := path + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("创建索引文件失败: %w", err)
+	}
+
+	if err := gob.NewEncoder(file).Encode(snapshot{Type: indexType, Items: idx.Items()}); err != nil {
+		file.Close()
+		os.Remove(tmp)
+		return fmt.Errorf("写入索引文件失败: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("关闭索引文件失败: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("重命名索引文件失败: %w", err)
+	}
+	return nil
+}
+
+// Load 从文件加载索引，返回索引及其类型
+func Load(path string) (Index, string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("打开索引文件失败: %w", err)
+	}
+	defer file.Close()
+
+	var snap snapshot
+	if err := gob.NewDecoder(file).Decode(&snap); err != nil {
+		return nil, "", fmt.Errorf("解析索引文件失败: %w", err)
+	}
+
+	idx, err := New(snap.Type)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, item := range snap.Items {
+		if err := idx.Add(item.ID, item.Vector); err != nil {
+			return nil, "", fmt.Errorf("重建索引失败: %w", err)
+		}
+	}
+	return idx, snap.Type, nil
+}
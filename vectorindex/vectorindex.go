@@ -0,0 +1,87 @@
+package vectorindex
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrDimensionMismatch 表示向量维度与索引维度不一致
+var ErrDimensionMismatch = errors.New("向量维度不一致")
+
+// Result 表示一条检索结果
+type Result struct {
+	ID    string
+	Score float64 // 余弦相似度，取值范围[-1, 1]
+}
+
+// Item 表示索引中的一个向量
+type Item struct {
+	ID     string
+	Vector []float32
+}
+
+// Index 是向量索引接口
+type Index interface {
+	// Add 添加或替换向量
+	Add(id string, vec []float32) error
+	// Remove 删除向量
+	Remove(id string)
+	// Search 返回与vec余弦相似度最高的k个结果，按相似度降序排列
+	Search(vec []float32, k int) ([]Result, error)
+	// Len 返回向量数量
+	Len() int
+	// Dim 返回索引的向量维度，尚未添加过向量时为0
+	Dim() int
+	// Items 返回全部向量，用于持久化
+	Items() []Item
+}
+
+// Normalize 返回单位化后的向量副本，零向量原样返回
+func Normalize(vec []float32) []float32 {
+	var sum float64
+	for _, v := range vec {
+		sum += float64(v) * float64(v)
+	}
+	out := make([]float32, len(vec))
+	if sum == 0 {
+		copy(out, vec)
+		return out
+	}
+	norm := math.Sqrt(sum)
+	for i, v := range vec {
+		out[i] = float32(float64(v) / norm)
+	}
+	return out
+}
+
+// Cosine 计算两个向量的余弦相似度
+func Cosine(a, b []float32) float64 {
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
+
+// FromFloat64 将float64向量转换为float32向量
+func FromFloat64(vec []float64) []float32 {
+	out := make([]float32, len(vec))
+	for i, v := range vec {
+		out[i] = float32(v)
+	}
+	return out
+}
+
+// 计算两个单位向量的点积
+func dot(a, b []float32) float64 {
+	var sum float64
+	for i := range a {
+		sum += float64(a[i]) * float64(b[i])
+	}
+	return sum
+}
@@ -0,0 +1,243 @@
+package vectorindex
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+var indexTypes = []string{TypeFlat, TypeHNSW}
+
+func resultIDs(results []Result) []string {
+	ids := make([]string, len(results))
+	for i, r := range results {
+		ids[i] = r.ID
+	}
+	return ids
+}
+
+func TestIndexOperations(t *testing.T) {
+	tests := []struct {
+		name    string
+		ops     func(idx Index) error
+		query   []float32
+		k       int
+		want    []string
+		wantLen int
+		wantErr error
+	}{
+		{
+			name: "按相似度降序",
+			ops: func(idx Index) error {
+				idx.Add("x", []float32{1, 0, 0})
+				idx.Add("xy", []float32{1, 1, 0})
+				return idx.Add("z", []float32{0, 0, 1})
+			},
+			query:   []float32{2, 0, 0},
+			k:       3,
+			want:    []string{"x", "xy", "z"},
+			wantLen: 3,
+		},
+		{
+			name: "k小于向量数",
+			ops: func(idx Index) error {
+				idx.Add("x", []float32{1, 0})
+				return idx.Add("y", []float32{0, 1})
+			},
+			query:   []float32{0, 1},
+			k:       1,
+			want:    []string{"y"},
+			wantLen: 2,
+		},
+		{
+			name: "替换已有向量",
+			ops: func(idx Index) error {
+				idx.Add("a", []float32{1, 0})
+				idx.Add("b", []float32{0.9, 0.1})
+				return idx.Add("a", []float32{0, 1})
+			},
+			query:   []float32{1, 0},
+			k:       2,
+			want:    []string{"b", "a"},
+			wantLen: 2,
+		},
+		{
+			name: "删除后不再返回",
+			ops: func(idx Index) error {
+				idx.Add("a", []float32{1, 0})
+				idx.Add("b", []float32{0, 1})
+				idx.Remove("a")
+				idx.Remove("missing")
+				return nil
+			},
+			query:   []float32{1, 0},
+			k:       2,
+			want:    []string{"b"},
+			wantLen: 1,
+		},
+		{
+			name: "添加维度不一致",
+			ops: func(idx Index) error {
+				idx.Add("a", []float32{1, 0})
+				return idx.Add("b", []float32{1, 0, 0})
+			},
+			wantErr: ErrDimensionMismatch,
+		},
+		{
+			name: "查询维度不一致",
+			ops: func(idx Index) error {
+				return idx.Add("a", []float32{1, 0})
+			},
+			query:   []float32{1, 0, 0},
+			k:       1,
+			wantErr: ErrDimensionMismatch,
+		},
+		{
+			name:  "空索引",
+			ops:   func(idx Index) error { return nil },
+			query: []float32{1},
+			k:     1,
+		},
+	}
+
+	for _, indexType := range indexTypes {
+		for _, tt := range tests {
+			t.Run(indexType+"/"+tt.name, func(t *testing.T) {
+				idx, err := New(indexType)
+				if err != nil {
+					t.Fatal(err)
+				}
+				err = tt.ops(idx)
+				if err == nil && tt.query != nil {
+					var results []Result
+					results, err = idx.Search(tt.query, tt.k)
+					if err == nil {
+						got := resultIDs(results)
+						if len(got) != len(tt.want) {
+							t.Fatalf("结果为%v，期望%v", got, tt.want)
+						}
+						for i := range got {
+							if got[i] != tt.want[i] {
+								t.Fatalf("结果为%v，期望%v", got, tt.want)
+							}
+						}
+					}
+				}
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("错误为%v，期望%v", err, tt.wantErr)
+				}
+				if tt.wantErr == nil && idx.Len() != tt.wantLen {
+					t.Fatalf("向量数%d，期望%d", idx.Len(), tt.wantLen)
+				}
+			})
+		}
+	}
+}
+
+func TestDimSurvivesRemoval(t *testing.T) {
+	for _, indexType := range indexTypes {
+		idx, _ := New(indexType)
+		if idx.Dim() != 0 {
+			t.Fatalf("%s: 空索引维度为%d", indexType, idx.Dim())
+		}
+		idx.Add("a", []float32{1, 2, 3})
+		idx.Remove("a")
+		if idx.Dim() != 3 {
+			t.Fatalf("%s: 删除后维度为%d，期望3", indexType, idx.Dim())
+		}
+		if err := idx.Add("b", []float32{1}); !errors.Is(err, ErrDimensionMismatch) {
+			t.Fatalf("%s: 错误为%v", indexType, err)
+		}
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	for _, indexType := range indexTypes {
+		t.Run(indexType, func(t *testing.T) {
+			idx, _ := New(indexType)
+			idx.Add("a", []float32{1, 0})
+			idx.Add("b", []float32{0, 1})
+			idx.Add("c", []float32{1, 1})
+			idx.Remove("c")
+
+			path := filepath.Join(t.TempDir(), "index.gob")
+			if err := Save(path, indexType, idx); err != nil {
+				t.Fatal(err)
+			}
+			loaded, loadedType, err := Load(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if loadedType != indexType || loaded.Len() != 2 {
+				t.Fatalf("类型%s，向量数%d", loadedType, loaded.Len())
+			}
+			results, err := loaded.Search([]float32{0, 1}, 1)
+			if err != nil || len(results) != 1 || results[0].ID != "b" {
+				t.Fatalf("结果为%v，错误%v", results, err)
+			}
+		})
+	}
+
+	if _, err := New("ivf"); err == nil {
+		t.Fatal("不支持的索引类型应返回错误")
+	}
+}
+
+// HNSW的近似检索结果应与暴力检索基本一致
+func TestHNSWRecall(t *testing.T) {
+	const n, dim, k, queries = 2000, 32, 10, 50
+	rng := rand.New(rand.NewSource(42))
+	randomVec := func() []float32 {
+		v := make([]float32, dim)
+		for i := range v {
+			v[i] = float32(rng.NormFloat64())
+		}
+		return v
+	}
+
+	flat, hnsw := NewFlat(), NewHNSW(HNSWConfig{})
+	for i := 0; i < n; i++ {
+		v := randomVec()
+		flat.Add(strconv.Itoa(i), v)
+		hnsw.Add(strconv.Itoa(i), v)
+	}
+
+	hits := 0
+	for q := 0; q < queries; q++ {
+		v := randomVec()
+		exact, _ := flat.Search(v, k)
+		approx, _ := hnsw.Search(v, k)
+		want := make(map[string]bool, k)
+		for _, r := range exact {
+			want[r.ID] = true
+		}
+		for _, r := range approx {
+			if want[r.ID] {
+				hits++
+			}
+		}
+	}
+	if recall := float64(hits) / (queries * k); recall < 0.9 {
+		t.Fatalf("召回率%.2f低于0.9", recall)
+	}
+}
+
+func TestCosine(t *testing.T) {
+	tests := []struct {
+		a, b []float32
+		want float64
+	}{
+		{[]float32{1, 0}, []float32{2, 0}, 1},
+		{[]float32{1, 0}, []float32{0, 3}, 0},
+		{[]float32{1, 1}, []float32{-1, -1}, -1},
+		{[]float32{0, 0}, []float32{1, 0}, 0},
+	}
+	for _, tt := range tests {
+		if got := Cosine(tt.a, tt.b); math.Abs(got-tt.want) > 1e-6 {
+			t.Errorf("Cosine(%v, %v) = %v，期望%v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,491 @@
+package videojob
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kriswu/go_deepseek/httpclient"
+	"github.com/kriswu/go_deepseek/siliconproxy"
+)
+
+// StatusTimeout 表示任务在超时前未结束，是本地状态，上游不会返回
+const StatusTimeout = "Timeout"
+
+// 默认的轮询参数
+const (
+	DefaultPollInterval    = 5 * time.Second
+	DefaultMaxPollInterval = time.Minute
+	DefaultTimeout         = 30 * time.Minute
+	webhookAttempts        = 3
+)
+
+// ErrJobNotFound 表示任务不存在
+var ErrJobNotFound = errors.New("任务不存在")
+
+// Config 表示任务管理器配置
+type Config struct {
+	Dir             string        // 任务状态持久化目录
+	DownloadDir     string        // 非空时在任务成功后把视频下载到该目录
+	MaxDownload     int64         // 下载大小上限，0表示不限制
+	PollInterval    time.Duration // 首次轮询间隔，默认5秒
+	MaxPollInterval time.Duration // 轮询间隔上限，默认1分钟
+	Timeout         time.Duration // 任务超时时间，默认30分钟
+	// 允许的webhook主机名，为空时不接受webhook
+	WebhookHosts []string
+	// 返回租户使用的上游代理，用于轮询重启后恢复的租户任务
+	TenantProxy func(tenant string) *siliconproxy.SiliconProxy
+}
+
+// Job 表示一个视频生成任务
+type Job struct {
+	ID          string                          `json:"id"` // 即上游的requestId
+	Request     siliconproxy.VideoSubmitRequest `json:"request"`
+	Status      string                          `json:"status"`
+	Reason      string                          `json:"reason,omitempty"`
+	VideoURL    string                          `json:"video_url,omitempty"`
+	LocalPath   string                          `json:"local_path,omitempty"`
+	Webhook     string                          `json:"webhook,omitempty"`
+	Tenant      string                          `json:"tenant,omitempty"` // 提交任务的租户，为空表示默认账户
+	SubmittedAt time.Time                       `json:"submitted_at"`
+	UpdatedAt   time.Time                       `json:"updated_at"`
+	CompletedAt time.Time                       `json:"completed_at,omitempty"`
+}
+
+// Done 判断任务是否已结束
+func (j *Job) Done() bool {
+	return j.Status == siliconproxy.VideoStatusSucceed ||
+		j.Status == siliconproxy.VideoStatusFailed ||
+		j.Status == StatusTimeout
+}
+
+// SubmitOptions 表示提交任务时的通知方式和所属租户
+type SubmitOptions struct {
+	Webhook  string    // 任务结束后以POST JSON方式通知的地址，需通过CheckWebhook校验
+	Callback func(Job) // 任务结束后在后台goroutine中调用
+	Tenant   string    // 任务所属租户，记录在任务中
+	// 提交和轮询使用的上游代理，为nil时使用管理器的默认代理
+	Proxy *siliconproxy.SiliconProxy
+}
+
+// Manager 负责提交视频任务、持久化任务状态并轮询直到结束
+type Manager struct {
+	sp           *siliconproxy.SiliconProxy
+	conf         Config
+	webhook      *httpclient.Client
+	webhookHosts map[string]bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu          sync.Mutex
+	jobs        map[string]*Job
+	proxies     map[string]*siliconproxy.SiliconProxy // 本进程提交的任务使用的代理
+	callbacks   map[string]func(Job)
+	subscribers map[string][]chan Job
+	onComplete  []func(Job)
+}
+
+// NewManager 创建任务管理器，并恢复持久化目录中未结束的任务
+func NewManager(sp *siliconproxy.SiliconProxy, conf Config) (*Manager, error) {
+	if conf.PollInterval <= 0 {
+		conf.PollInterval = DefaultPollInterval
+	}
+	if conf.MaxPollInterval <= 0 {
+		conf.MaxPollInterval = DefaultMaxPollInterval
+	}
+	if conf.Timeout <= 0 {
+		conf.Timeout = DefaultTimeout
+	}
+	if err := os.MkdirAll(conf.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("创建任务目录失败: %w", err)
+	}
+	if conf.DownloadDir != "" {
+		if err := os.MkdirAll(conf.DownloadDir, 0o755); err != nil {
+			return nil, fmt.Errorf("创建下载目录失败: %w", err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m := &Manager{
+		sp:           sp,
+		conf:         conf,
+		webhook:      newWebhookClient(),
+		webhookHosts: make(map[string]bool, len(conf.WebhookHosts)),
+		ctx:          ctx,
+		cancel:       cancel,
+		jobs:         make(map[string]*Job),
+		proxies:      make(map[string]*siliconproxy.SiliconProxy),
+		callbacks:    make(map[string]func(Job)),
+		subscribers:  make(map[string][]chan Job),
+	}
+	for _, host := range conf.WebhookHosts {
+		m.webhookHosts[strings.ToLower(host)] = true
+	}
+
+	if err := m.load(); err != nil {
+		cancel()
+		return nil, err
+	}
+	for _, job := range m.jobs {
+		if !job.Done() {
+			m.startPolling(job.ID)
+		}
+	}
+	return m, nil
+}
+
+// OnComplete 注册任务结束时的全局回调
+func (m *Manager) OnComplete(fn func(Job)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onComplete = append(m.onComplete, fn)
+}
+
+// Submit 提交视频生成任务并开始后台轮询
+func (m *Manager) Submit(ctx context.Context, req *siliconproxy.VideoSubmitRequest, opts SubmitOptions) (*Job, error) {
+	if opts.Webhook != "" {
+		if err := m.CheckWebhook(ctx, opts.Webhook); err != nil {
+			return nil, err
+		}
+	}
+	sp := opts.Proxy
+	if sp == nil {
+		sp = m.sp
+	}
+
+	resp, err := sp.CreateVideoSubmit(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.RequestID == "" {
+		return nil, errors.New("提交视频任务失败: 响应缺少requestId")
+	}
+	// requestId会用作文件名
+	if strings.ContainsAny(resp.RequestID, `/\.`) {
+		return nil, fmt.Errorf("提交视频任务失败: requestId不合法: %q", resp.RequestID)
+	}
+
+	now := time.Now()
+	job := &Job{
+		ID:          resp.RequestID,
+		Request:     *req,
+		Status:      siliconproxy.VideoStatusInQueue,
+		Webhook:     opts.Webhook,
+		Tenant:      opts.Tenant,
+		SubmittedAt: now,
+		UpdatedAt:   now,
+	}
+
+	m.mu.Lock()
+	m.jobs[job.ID] = job
+	m.proxies[job.ID] = sp
+	if opts.Callback != nil {
+		m.callbacks[job.ID] = opts.Callback
+	}
+	err = m.saveLocked(job)
+	snapshot := *job
+	m.mu.Unlock()
+	if err != nil {
+		log.Printf("保存视频任务%s失败: %v", job.ID, err)
+	}
+
+	m.startPolling(job.ID)
+	return &snapshot, nil
+}
+
+// Get 获取任务当前状态
+func (m *Manager) Get(id string) (Job, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job, ok := m.jobs[id]
+	if !ok {
+		return Job{}, false
+	}
+	return *job, true
+}
+
+// List 返回全部任务
+func (m *Manager) List() []Job {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	jobs := make([]Job, 0, len(m.jobs))
+	for _, job := range m.jobs {
+		jobs = append(jobs, *job)
+	}
+	return jobs
+}
+
+// Subscribe 返回在任务结束时接收一次结果的通道，任务已结束时立即可读
+func (m *Manager) Subscribe(id string) (<-chan Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job, ok := m.jobs[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrJobNotFound, id)
+	}
+	ch := make(chan Job, 1)
+	if job.Done() {
+		ch <- *job
+		close(ch)
+		return ch, nil
+	}
+	m.subscribers[id] = append(m.subscribers[id], ch)
+	return ch, nil
+}
+
+// Wait 阻塞直到任务结束或ctx取消
+func (m *Manager) Wait(ctx context.Context, id string) (Job, error) {
+	ch, err := m.Subscribe(id)
+	if err != nil {
+		return Job{}, err
+	}
+	select {
+	case job := <-ch:
+		return job, nil
+	case <-ctx.Done():
+		return Job{}, ctx.Err()
+	}
+}
+
+// Close 停止全部轮询，未结束的任务会在下次创建管理器时恢复
+func (m *Manager) Close() {
+	m.cancel()
+	m.wg.Wait()
+}
+
+func (m *Manager) startPolling(id string) {
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		m.poll(id)
+	}()
+}
+
+// 按指数退避轮询任务状态，直到结束、超时或管理器关闭
+func (m *Manager) poll(id string) {
+	m.mu.Lock()
+	submittedAt := m.jobs[id].SubmittedAt
+	m.mu.Unlock()
+
+	interval := m.conf.PollInterval
+	deadline := submittedAt.Add(m.conf.Timeout)
+	for {
+		wait := interval
+		if remaining := time.Until(deadline); remaining < wait {
+			wait = max(remaining, 0)
+		}
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+
+		if time.Now().After(deadline) {
+			m.finish(id, StatusTimeout, "任务超时", "")
+			return
+		}
+
+		sp, err := m.proxyFor(id)
+		if err != nil {
+			log.Printf("查询视频任务%s状态失败: %v", id, err)
+			interval = min(interval*3/2, m.conf.MaxPollInterval)
+			continue
+		}
+		status, err := sp.GetVideoStatus(m.ctx, &siliconproxy.VideoStatusRequest{RequestID: id})
+		if err != nil {
+			log.Printf("查询视频任务%s状态失败: %v", id, err)
+		} else if status.Done() {
+			m.finish(id, status.Status, status.Reason, status.VideoURL())
+			return
+		} else {
+			m.update(id, status.Status)
+		}
+
+		interval = min(interval*3/2, m.conf.MaxPollInterval)
+	}
+}
+
+// 返回轮询任务使用的上游代理，租户任务不会回退到默认代理
+func (m *Manager) proxyFor(id string) (*siliconproxy.SiliconProxy, error) {
+	m.mu.Lock()
+	sp := m.proxies[id]
+	tenant := m.jobs[id].Tenant
+	m.mu.Unlock()
+
+	if sp != nil {
+		return sp, nil
+	}
+	if tenant == "" {
+		return m.sp, nil
+	}
+	if m.conf.TenantProxy != nil {
+		if sp := m.conf.TenantProxy(tenant); sp != nil {
+			return sp, nil
+		}
+	}
+	return nil, fmt.Errorf("租户%s不存在", tenant)
+}
+
+// 更新未结束任务的状态
+func (m *Manager) update(id, status string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job := m.jobs[id]
+	if job.Status == status {
+		return
+	}
+	job.Status = status
+	job.UpdatedAt = time.Now()
+	if err := m.saveLocked(job); err != nil {
+		log.Printf("保存视频任务%s失败: %v", id, err)
+	}
+}
+
+// 记录任务结果，按需下载视频，然后发送全部通知
+func (m *Manager) finish(id, status, reason, videoURL string) {
+	var localPath string
+	if status == siliconproxy.VideoStatusSucceed && videoURL != "" && m.conf.DownloadDir != "" {
+		path, err := m.downloadVideo(id, videoURL)
+		if err != nil {
+			log.Printf("下载视频任务%s失败: %v", id, err)
+		} else {
+			localPath = path
+		}
+	}
+
+	m.mu.Lock()
+	job := m.jobs[id]
+	now := time.Now()
+	job.Status = status
+	job.Reason = reason
+	job.VideoURL = videoURL
+	job.LocalPath = localPath
+	job.UpdatedAt = now
+	job.CompletedAt = now
+	if err := m.saveLocked(job); err != nil {
+		log.Printf("保存视频任务%s失败: %v", id, err)
+	}
+
+	snapshot := *job
+	delete(m.proxies, id)
+	callback := m.callbacks[id]
+	delete(m.callbacks, id)
+	subscribers := m.subscribers[id]
+	delete(m.subscribers, id)
+	global := append([]func(Job){}, m.onComplete...)
+	m.mu.Unlock()
+
+	for _, ch := range subscribers {
+		ch <- snapshot
+		close(ch)
+	}
+	if callback != nil {
+		callback(snapshot)
+	}
+	for _, fn := range global {
+		fn(snapshot)
+	}
+	if snapshot.Webhook != "" {
+		m.sendWebhook(snapshot)
+	}
+}
+
+// 下载视频到本地，签名地址有时效，需要在任务结束后尽快下载
+func (m *Manager) downloadVideo(id, url string) (string, error) {
+	path := filepath.Join(m.conf.DownloadDir, id+".mp4")
+	file, err := os.Create(path + ".part")
+	if err != nil {
+		return "", fmt.Errorf("创建文件失败: %w", err)
+	}
+
+	_, err = m.sp.DownloadTo(m.ctx, url, file, m.conf.MaxDownload)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path + ".part")
+		return "", err
+	}
+	if err := os.Rename(path+".part", path); err != nil {
+		return "", fmt.Errorf("重命名文件失败: %w", err)
+	}
+	return path, nil
+}
+
+// 以POST JSON方式通知webhook，失败时重试
+func (m *Manager) sendWebhook(job Job) {
+	body, err := json.Marshal(job)
+	if err != nil {
+		log.Printf("序列化视频任务%s失败: %v", job.ID, err)
+		return
+	}
+
+	backoff := time.Second
+	for attempt := 1; attempt <= webhookAttempts; attempt++ {
+		resp, err := m.webhook.Post(m.ctx, job.Webhook, "application/json", string(body))
+		if err == nil && resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return
+		}
+		if err == nil {
+			err = fmt.Errorf("状态码: %d", resp.StatusCode)
+		}
+		log.Printf("通知视频任务%s的webhook失败(第%d次): %v", job.ID, attempt, err)
+
+		if attempt < webhookAttempts {
+			timer := time.NewTimer(backoff)
+			select {
+			case <-m.ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+			backoff *= 2
+		}
+	}
+}
+
+// 保存任务状态，调用方需持有锁
+func (m *Manager) saveLocked(job *Job) error {
+	data, err := json.MarshalIndent(job, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(m.conf.Dir, job.ID+".json")
+	if err := os.WriteFile(path+".tmp", data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+// 从持久化目录加载全部任务
+func (m *Manager) load() error {
+	paths, err := filepath.Glob(filepath.Join(m.conf.Dir, "*.json"))
+	if err != nil {
+		return fmt.Errorf("读取任务目录失败: %w", err)
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("读取任务文件失败: %w", err)
+		}
+		var job Job
+		if err := json.Unmarshal(data, &job); err != nil {
+			return fmt.Errorf("解析任务文件%s失败: %w", path, err)
+		}
+		m.jobs[job.ID] = &job
+	}
+	return nil
+}
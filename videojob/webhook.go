@@ -0,0 +1,75 @@
+package videojob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/kriswu/go_deepseek/httpclient"
+)
+
+// ErrWebhookNotAllowed 表示webhook地址不在允许范围内
+var ErrWebhookNotAllowed = errors.New("webhook地址不允许")
+
+// 运营商级NAT地址段，云环境中常用于内部服务
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// 判断地址是否指向本机或内部网络，webhook不能访问这些地址
+func blockedIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() || sharedAddressSpace.Contains(ip)
+}
+
+// CheckWebhook 校验webhook地址：必须是http或https，主机名在Config.WebhookHosts中，
+// 且解析出的地址都不是回环、私有或链路本地地址
+// 发送时连接层会再次检查实际连接的地址，防止解析结果在校验后被替换
+func (m *Manager) CheckWebhook(ctx context.Context, raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return fmt.Errorf("%w: 必须是http或https地址: %q", ErrWebhookNotAllowed, raw)
+	}
+	host := strings.ToLower(u.Hostname())
+	if !m.webhookHosts[host] {
+		return fmt.Errorf("%w: 主机%s不在允许列表中", ErrWebhookNotAllowed, host)
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return fmt.Errorf("%w: 解析主机%s失败: %v", ErrWebhookNotAllowed, host, err)
+	}
+	for _, addr := range addrs {
+		if blockedIP(addr.IP) {
+			return fmt.Errorf("%w: 主机%s解析到内部地址%s", ErrWebhookNotAllowed, host, addr.IP)
+		}
+	}
+	return nil
+}
+
+// 创建发送webhook的客户端，拒绝连接到内部地址
+// 不使用环境变量中的代理，否则连接检查只能看到代理地址
+func newWebhookClient() *httpclient.Client {
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || blockedIP(ip) {
+				return fmt.Errorf("%w: 拒绝连接内部地址%s", ErrWebhookNotAllowed, host)
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return httpclient.NewClient(httpclient.WithTimeout(10*time.Second), httpclient.WithTransport(transport))
+}
@@ -0,0 +1,66 @@
+package videojob
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCheckWebhook(t *testing.T) {
+	m := &Manager{webhookHosts: map[string]bool{
+		"127.0.0.1":       true,
+		"10.1.2.3":        true,
+		"100.64.0.1":      true,
+		"169.254.169.254": true,
+		"203.0.113.10":    true,
+		"::1":             true,
+	}}
+
+	tests := []struct {
+		name    string
+		url     string
+		wantErr bool
+	}{
+		{name: "允许的公网地址", url: "https://203.0.113.10/hook"},
+		{name: "不支持的协议", url: "ftp://203.0.113.10/hook", wantErr: true},
+		{name: "缺少主机", url: "https:///hook", wantErr: true},
+		{name: "不在允许列表中", url: "https://198.51.100.1/hook", wantErr: true},
+		{name: "回环地址", url: "http://127.0.0.1:8080/hook", wantErr: true},
+		{name: "IPv6回环地址", url: "http://[::1]/hook", wantErr: true},
+		{name: "私有地址", url: "http://10.1.2.3/hook", wantErr: true},
+		{name: "运营商级NAT地址", url: "http://100.64.0.1/hook", wantErr: true},
+		{name: "链路本地元数据地址", url: "http://169.254.169.254/latest", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := m.CheckWebhook(context.Background(), tt.url)
+			if tt.wantErr {
+				if !errors.Is(err, ErrWebhookNotAllowed) {
+					t.Fatalf("CheckWebhook(%q) = %v, want ErrWebhookNotAllowed", tt.url, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CheckWebhook(%q) = %v", tt.url, err)
+			}
+		})
+	}
+}
+
+func TestWebhookClientRejectsInternalAddress(t *testing.T) {
+	var called bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer srv.Close()
+
+	_, err := newWebhookClient().Post(context.Background(), srv.URL, "application/json", "{}")
+	if !errors.Is(err, ErrWebhookNotAllowed) {
+		t.Fatalf("Post(%s) = %v, want ErrWebhookNotAllowed", srv.URL, err)
+	}
+	if called {
+		t.Fatal("webhook客户端连接了回环地址")
+	}
+}
@@ -0,0 +1,380 @@
+package voiceregistry
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kriswu/go_deepseek/siliconproxy"
+)
+
+// ErrVoiceNotFound 表示音色不存在
+var ErrVoiceNotFound = errors.New("音色不存在")
+
+// Metadata 表示本地维护的音色元数据
+type Metadata struct {
+	OwnerTeam        string `json:"owner_team,omitempty"`
+	Language         string `json:"language,omitempty"`
+	SampleTranscript string `json:"sample_transcript,omitempty"`
+}
+
+// Voice 表示注册表中的一个音色
+type Voice struct {
+	URI         string    `json:"uri"`
+	Model       string    `json:"model"`
+	Name        string    `json:"name"`
+	Text        string    `json:"text,omitempty"`
+	ContentHash string    `json:"content_hash,omitempty"` // 参考音频的sha256，仅通过注册表上传的音色有
+	Tenant      string    `json:"tenant,omitempty"`       // 所属租户，为空表示默认账户
+	Metadata    Metadata  `json:"metadata"`
+	CreatedAt   time.Time `json:"created_at"`
+	SyncedAt    time.Time `json:"synced_at,omitempty"`
+}
+
+// Filter 表示音色查询条件，空字段匹配任意值
+type Filter struct {
+	OwnerTeam string
+	Language  string
+	Model     string
+	Query     string // 对名称、文字和示例文本做不区分大小写的子串匹配
+}
+
+// UploadRequest 表示通过注册表上传音色的请求
+type UploadRequest struct {
+	Model    string
+	Name     string
+	Text     string // 参考音频对应的文字
+	Audio    []byte // 参考音频内容
+	FileName string
+	Metadata Metadata
+}
+
+// Registry 是带本地元数据的音色注册表，数据保存在单个JSON文件中
+// 每个音色属于一个租户，Registry只能看到和修改所属租户的音色
+type Registry struct {
+	sp     *siliconproxy.SiliconProxy
+	tenant string
+	state  *registryState
+}
+
+type registryState struct {
+	path string
+
+	mu      sync.RWMutex
+	voices  map[string]*Voice  // URI到音色
+	uploads map[string]*upload // 进行中的上传，键为租户、模型和内容哈希
+}
+
+// 进行中的上传，结束后关闭done
+type upload struct {
+	done  chan struct{}
+	voice *Voice
+	err   error
+}
+
+// Open 打开音色注册表，文件不存在时创建空注册表
+// 返回的注册表属于默认账户，租户使用WithTenant获取自己的视图
+func Open(sp *siliconproxy.SiliconProxy, path string) (*Registry, error) {
+	r := &Registry{sp: sp, state: &registryState{
+		path:    path,
+		voices:  make(map[string]*Voice),
+		uploads: make(map[string]*upload),
+	}}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return r, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取音色注册表失败: %w", err)
+	}
+
+	var voices []*Voice
+	if err := json.Unmarshal(data, &voices); err != nil {
+		return nil, fmt.Errorf("解析音色注册表失败: %w", err)
+	}
+	for _, v := range voices {
+		r.state.voices[v.URI] = v
+	}
+	return r, nil
+}
+
+// WithTenant 返回租户的注册表视图，与原注册表共享存储
+// 视图只能看到和修改该租户的音色，调用上游时使用sp
+func (r *Registry) WithTenant(tenant string, sp *siliconproxy.SiliconProxy) *Registry {
+	return &Registry{sp: sp, tenant: tenant, state: r.state}
+}
+
+// Sync 将租户上游账户的音色列表同步到本地，新音色登记为该租户的音色，
+// 该租户在上游已删除的音色从注册表移除，本地元数据会被保留
+// 已登记给其他租户的音色不受影响
+func (r *Registry) Sync(ctx context.Context) (added, removed int, err error) {
+	list, err := r.sp.GetVoiceList(ctx)
+	if err != nil {
+		return 0, 0, fmt.Errorf("获取音色列表失败: %w", err)
+	}
+
+	st := r.state
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	now := time.Now()
+	upstream := make(map[string]bool, len(list.Result))
+	for _, data := range list.Result {
+		upstream[data.URI] = true
+		voice, ok := st.voices[data.URI]
+		if !ok {
+			voice = &Voice{URI: data.URI, Tenant: r.tenant, CreatedAt: now}
+			st.voices[data.URI] = voice
+			added++
+		}
+		if voice.Tenant != r.tenant {
+			continue
+		}
+		voice.Model = data.Model
+		voice.Name = data.CustomName
+		voice.Text = data.Text
+		voice.SyncedAt = now
+	}
+	for uri, voice := range st.voices {
+		if voice.Tenant == r.tenant && !upstream[uri] {
+			delete(st.voices, uri)
+			removed++
+		}
+	}
+
+	if err := st.saveLocked(); err != nil {
+		return added, removed, err
+	}
+	return added, removed, nil
+}
+
+// Upload 上传参考音频并登记到注册表
+// 同一租户同一模型下内容相同的音频不会重复上传，直接返回已有音色，duplicate为true
+// 相同内容并发上传时只有一个请求调用上游，其他请求等待其结果
+func (r *Registry) Upload(ctx context.Context, req *UploadRequest) (voice *Voice, duplicate bool, err error) {
+	if req.Name == "" {
+		return nil, false, errors.New("音色名称不能为空")
+	}
+	sum := sha256.Sum256(req.Audio)
+	hash := hex.EncodeToString(sum[:])
+	key := r.tenant + "\x00" + req.Model + "\x00" + hash
+
+	st := r.state
+	for {
+		st.mu.Lock()
+		for _, v := range st.voices {
+			if v.Tenant == r.tenant && v.ContentHash == hash && v.Model == req.Model {
+				existing := *v
+				st.mu.Unlock()
+				return &existing, true, nil
+			}
+		}
+		inflight, ok := st.uploads[key]
+		if !ok {
+			break
+		}
+		st.mu.Unlock()
+
+		select {
+		case <-inflight.done:
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		}
+		if inflight.err == nil {
+			existing := *inflight.voice
+			return &existing, true, nil
+		}
+		// 上传失败时重新检查，由某个等待的请求重新上传
+	}
+	inflight := &upload{done: make(chan struct{})}
+	st.uploads[key] = inflight
+	st.mu.Unlock()
+
+	voice, err = r.upload(ctx, req, hash)
+
+	st.mu.Lock()
+	delete(st.uploads, key)
+	st.mu.Unlock()
+	inflight.voice, inflight.err = voice, err
+	close(inflight.done)
+
+	if err != nil {
+		return nil, false, err
+	}
+	result := *voice
+	return &result, false, nil
+}
+
+// 调用上游上传音色并登记，返回登记的音色
+func (r *Registry) upload(ctx context.Context, req *UploadRequest, hash string) (*Voice, error) {
+	fileName := req.FileName
+	if fileName == "" {
+		fileName = "audio"
+	}
+	resp, err := r.sp.UploadVoice(ctx, &siliconproxy.UploadVoiceRequest{
+		Model:      req.Model,
+		CustomName: req.Name,
+		Text:       req.Text,
+		Reader:     bytes.NewReader(req.Audio),
+		FileName:   fileName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	v := &Voice{
+		URI:         resp.URI,
+		Model:       req.Model,
+		Name:        req.Name,
+		Text:        req.Text,
+		ContentHash: hash,
+		Tenant:      r.tenant,
+		Metadata:    req.Metadata,
+		CreatedAt:   now,
+		SyncedAt:    now,
+	}
+
+	st := r.state
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.voices[v.URI] = v
+	if err := st.saveLocked(); err != nil {
+		return nil, err
+	}
+	result := *v
+	return &result, nil
+}
+
+// SetMetadata 更新音色的本地元数据
+func (r *Registry) SetMetadata(uri string, meta Metadata) error {
+	st := r.state
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	voice, ok := st.voices[uri]
+	if !ok || voice.Tenant != r.tenant {
+		return fmt.Errorf("%w: %s", ErrVoiceNotFound, uri)
+	}
+	voice.Metadata = meta
+	return st.saveLocked()
+}
+
+// List 返回符合条件的音色，按名称排序
+func (r *Registry) List(filter Filter) []Voice {
+	r.state.mu.RLock()
+	defer r.state.mu.RUnlock()
+
+	query := strings.ToLower(filter.Query)
+	var voices []Voice
+	for _, v := range r.state.voices {
+		if v.Tenant != r.tenant {
+			continue
+		}
+		if filter.OwnerTeam != "" && v.Metadata.OwnerTeam != filter.OwnerTeam {
+			continue
+		}
+		if filter.Language != "" && v.Metadata.Language != filter.Language {
+			continue
+		}
+		if filter.Model != "" && v.Model != filter.Model {
+			continue
+		}
+		if query != "" &&
+			!strings.Contains(strings.ToLower(v.Name), query) &&
+			!strings.Contains(strings.ToLower(v.Text), query) &&
+			!strings.Contains(strings.ToLower(v.Metadata.SampleTranscript), query) {
+			continue
+		}
+		voices = append(voices, *v)
+	}
+	sort.Slice(voices, func(a, b int) bool {
+		if voices[a].Name != voices[b].Name {
+			return voices[a].Name < voices[b].Name
+		}
+		return voices[a].URI < voices[b].URI
+	})
+	return voices
+}
+
+// Get 按名称或URI查找音色，名称重复时返回错误
+func (r *Registry) Get(nameOrURI string) (*Voice, error) {
+	r.state.mu.RLock()
+	defer r.state.mu.RUnlock()
+
+	if v, ok := r.state.voices[nameOrURI]; ok && v.Tenant == r.tenant {
+		voice := *v
+		return &voice, nil
+	}
+
+	var found *Voice
+	for _, v := range r.state.voices {
+		if v.Tenant != r.tenant || v.Name != nameOrURI {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("存在多个名为%s的音色，请使用URI", nameOrURI)
+		}
+		found = v
+	}
+	if found == nil {
+		return nil, fmt.Errorf("%w: %s", ErrVoiceNotFound, nameOrURI)
+	}
+	voice := *found
+	return &voice, nil
+}
+
+// Delete 按名称或URI删除音色，同时删除上游音色
+func (r *Registry) Delete(ctx context.Context, nameOrURI string) (*Voice, error) {
+	voice, err := r.Get(nameOrURI)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.sp.DeleteVoice(ctx, &siliconproxy.DeleteVoiceRequest{URI: voice.URI}); err != nil {
+		return nil, err
+	}
+
+	st := r.state
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	delete(st.voices, voice.URI)
+	if err := st.saveLocked(); err != nil {
+		return nil, err
+	}
+	return voice, nil
+}
+
+// 保存注册表，调用方需持有写锁
+func (st *registryState) saveLocked() error {
+	voices := make([]*Voice, 0, len(st.voices))
+	for _, v := range st.voices {
+		voices = append(voices, v)
+	}
+	sort.Slice(voices, func(a, b int) bool { return voices[a].URI < voices[b].URI })
+
+	data, err := json.MarshalIndent(voices, "", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化音色注册表失败: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(st.path), 0o755); err != nil {
+		return fmt.Errorf("创建目录失败: %w", err)
+	}
+	if err := os.WriteFile(st.path+".tmp", data, 0o644); err != nil {
+		return fmt.Errorf("写入音色注册表失败: %w", err)
+	}
+	if err := os.Rename(st.path+".tmp", st.path); err != nil {
+		return fmt.Errorf("重命名音色注册表失败: %w", err)
+	}
+	return nil
+}
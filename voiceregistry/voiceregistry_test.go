@@ -0,0 +1,132 @@
+package voiceregistry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kriswu/go_deepseek/siliconproxy"
+)
+
+// 模拟上游：每次上传返回新的URI，上传前等待一段时间以便并发请求重叠
+func newTestRegistry(t *testing.T, uploads *atomic.Int64) *Registry {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case siliconproxy.UploadVoicePath:
+			n := uploads.Add(1)
+			time.Sleep(50 * time.Millisecond)
+			json.NewEncoder(w).Encode(map[string]string{"uri": fmt.Sprintf("speech:voice-%d", n)})
+		case siliconproxy.DeleteVoicePath:
+			w.Write([]byte("{}"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	sp := siliconproxy.NewSiliconProxy("token")
+	sp.SetBaseURL(srv.URL)
+	r, err := Open(sp, filepath.Join(t.TempDir(), "voices.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func uploadRequest(name string) *UploadRequest {
+	return &UploadRequest{Model: "m", Name: name, Audio: []byte("same audio")}
+}
+
+func TestUploadConcurrentDuplicates(t *testing.T) {
+	var uploads atomic.Int64
+	r := newTestRegistry(t, &uploads)
+
+	const n = 5
+	uris := make([]string, n)
+	duplicates := make([]bool, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			voice, dup, err := r.Upload(context.Background(), uploadRequest("alice"))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			uris[i], duplicates[i] = voice.URI, dup
+		}()
+	}
+	wg.Wait()
+
+	if uploads.Load() != 1 {
+		t.Fatalf("上游上传%d次，期望1次", uploads.Load())
+	}
+	fresh := 0
+	for i := range uris {
+		if uris[i] != uris[0] {
+			t.Fatalf("并发上传返回了不同的音色: %v", uris)
+		}
+		if !duplicates[i] {
+			fresh++
+		}
+	}
+	if fresh != 1 {
+		t.Fatalf("%d个请求返回非重复结果，期望1个", fresh)
+	}
+	if voices := r.List(Filter{}); len(voices) != 1 {
+		t.Fatalf("注册表中有%d个音色，期望1个", len(voices))
+	}
+}
+
+func TestTenantIsolation(t *testing.T) {
+	var uploads atomic.Int64
+	r := newTestRegistry(t, &uploads)
+	a := r.WithTenant("team-a", r.sp)
+	b := r.WithTenant("team-b", r.sp)
+
+	voice, _, err := a.Upload(context.Background(), uploadRequest("alice"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 其他租户上传相同内容不视为重复
+	if _, dup, err := b.Upload(context.Background(), uploadRequest("alice")); err != nil || dup {
+		t.Fatalf("其他租户上传相同内容: 重复%v，错误%v", dup, err)
+	}
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{name: "按URI获取", call: func() error { _, err := b.Get(voice.URI); return err }},
+		{name: "更新元数据", call: func() error { return b.SetMetadata(voice.URI, Metadata{Language: "en"}) }},
+		{name: "删除", call: func() error { _, err := b.Delete(context.Background(), voice.URI); return err }},
+		{name: "默认账户按URI获取", call: func() error { _, err := r.Get(voice.URI); return err }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); !errors.Is(err, ErrVoiceNotFound) {
+				t.Fatalf("访问其他租户的音色返回%v，期望ErrVoiceNotFound", err)
+			}
+		})
+	}
+
+	if got := a.List(Filter{}); len(got) != 1 || got[0].URI != voice.URI {
+		t.Fatalf("租户a的音色列表为%+v", got)
+	}
+	if got := r.List(Filter{}); len(got) != 0 {
+		t.Fatalf("默认账户看到了租户的音色: %+v", got)
+	}
+	if _, err := a.Get("alice"); err != nil {
+		t.Fatalf("租户按名称获取自己的音色失败: %v", err)
+	}
+}